@@ -0,0 +1,287 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package auto provides a convenience entry point that chooses a clustering
+// algorithm and its parameters for a data set.
+package auto
+
+import (
+	"github.com/biogo/cluster/ckmeans"
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/kmeans"
+	"github.com/biogo/cluster/meanshift"
+
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Candidate describes a clustering that was considered by Fit.
+type Candidate struct {
+	Algorithm  string                 // Algorithm is the name of the clustering algorithm.
+	Parameters map[string]interface{} // Parameters holds the algorithm parameters.
+	Clusters   int                    // Clusters is the number of clusters found.
+	Score      float64                // Score is the mean silhouette of the clustering.
+}
+
+// Report describes the choice made by Fit.
+type Report struct {
+	N, Dims int // N and Dims are the number of elements and dimensionality of the data.
+
+	// Chosen is the selected clustering.
+	Chosen Candidate
+
+	// Candidates holds all the clusterings that were scored, including Chosen.
+	Candidates []Candidate
+}
+
+// Options control the search performed by Fit. The zero value is usable.
+type Options struct {
+	// MaxK is the largest number of clusters considered by the centroid-based
+	// algorithms. If MaxK is zero, a value of 10 is used.
+	MaxK int
+
+	// Restarts is the number of seedings tried for each k when using kmeans.
+	// If Restarts is zero, a value of 3 is used.
+	Restarts int
+
+	// Sample is the maximum number of elements used to estimate bandwidths and
+	// calculate silhouette scores. If Sample is zero, a value of 1000 is used.
+	Sample int
+
+	// Rand is the source of randomness used to draw the sample and to seed
+	// kmeans. If Rand is nil, the math/rand default source is used.
+	Rand *rand.Rand
+}
+
+func (o *Options) defaults() {
+	if o.MaxK == 0 {
+		o.MaxK = 10
+	}
+	if o.Restarts == 0 {
+		o.Restarts = 3
+	}
+	if o.Sample == 0 {
+		o.Sample = 1000
+	}
+}
+
+// Fit clusters data using an algorithm and parameters chosen by inspection of the
+// data. One-dimensional data are clustered using optimal dynamic programming k-means
+// and higher dimensional data are clustered using both k-means and mean shift with
+// a range of parameters. Each resulting clustering with at least two clusters is
+// scored by its mean silhouette and the highest scoring clustering is returned,
+// along with a Report of the candidates considered. If opts is nil, default options
+// are used. Weights are not considered when scoring.
+func Fit(data cluster.Interface, opts *Options) (cluster.Clusterer, Report, error) {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	o.defaults()
+
+	n := data.Len()
+	if n < 3 {
+		return nil, Report{}, errors.New("auto: too few data")
+	}
+	dims := len(data.Values(0))
+	for i := 1; i < n; i++ {
+		if len(data.Values(i)) != dims {
+			return nil, Report{}, &cluster.Error{Pkg: "auto", Err: cluster.ErrDimensionMismatch}
+		}
+	}
+	maxK := o.MaxK
+	if maxK > n-1 {
+		maxK = n - 1
+	}
+
+	rep := Report{N: n, Dims: dims}
+	sample := subsample(n, o.Sample, o.Rand)
+
+	var (
+		best  cluster.Clusterer
+		score = math.Inf(-1)
+	)
+	consider := func(c cluster.Clusterer, cand Candidate) {
+		cand.Clusters = len(c.Centers())
+		if cand.Clusters < 2 {
+			return
+		}
+		cand.Score = silhouette(c, sample)
+		rep.Candidates = append(rep.Candidates, cand)
+		if cand.Score > score {
+			best, score = c, cand.Score
+			rep.Chosen = cand
+		}
+	}
+
+	if dims == 1 {
+		for k := 2; k <= maxK; k++ {
+			ck, err := ckmeans.New(data, k)
+			if err != nil {
+				return nil, Report{}, err
+			}
+			err = ck.Cluster()
+			if err != nil {
+				return nil, Report{}, err
+			}
+			consider(ck, Candidate{
+				Algorithm:  "ckmeans",
+				Parameters: map[string]interface{}{"k": k},
+			})
+		}
+	} else {
+		for k := 2; k <= maxK; k++ {
+			var (
+				km  *kmeans.Kmeans
+				min = math.Inf(1)
+			)
+			for r := 0; r < o.Restarts; r++ {
+				c, err := kmeans.New(data, kmeans.WithRand(o.Rand))
+				if err != nil {
+					return nil, Report{}, err
+				}
+				c.Seed(k)
+				err = c.Cluster()
+				if err != nil {
+					return nil, Report{}, err
+				}
+				if ss := sum(c.Within()); ss < min {
+					km, min = c, ss
+				}
+			}
+			consider(km, Candidate{
+				Algorithm:  "kmeans",
+				Parameters: map[string]interface{}{"k": k},
+			})
+		}
+
+		h := bandwidth(data, sample)
+		if h > 0 {
+			for _, f := range []float64{0.5, 1, 2} {
+				ms := meanshift.New(data, meanshift.NewUniform(f*h), 1e-6*h*h, 100)
+				if ms.Cluster() != nil {
+					continue
+				}
+				consider(ms, Candidate{
+					Algorithm:  "meanshift",
+					Parameters: map[string]interface{}{"kernel": "uniform", "bandwidth": f * h},
+				})
+			}
+		}
+	}
+
+	if best == nil {
+		return nil, rep, errors.New("auto: no clustering found")
+	}
+	return best, rep, nil
+}
+
+// subsample returns a sorted random sample of at most m indices in [0, n) drawn
+// from rnd, or from the global math/rand source if rnd is nil.
+func subsample(n, m int, rnd *rand.Rand) []int {
+	if n <= m {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i
+		}
+		return s
+	}
+	perm := rand.Perm
+	if rnd != nil {
+		perm = rnd.Perm
+	}
+	s := perm(n)[:m]
+	sort.Ints(s)
+	return s
+}
+
+// bandwidth returns an estimate of a mean shift bandwidth for data based on the
+// median distance from each sampled element to its nearest 30% of sampled elements.
+func bandwidth(data cluster.Interface, sample []int) float64 {
+	if len(sample) < 2 {
+		return 0
+	}
+	k := int(0.3 * float64(len(sample)))
+	if k < 1 {
+		k = 1
+	}
+	d := make([]float64, 0, len(sample))
+	kth := make([]float64, len(sample))
+	for i, a := range sample {
+		d = d[:0]
+		for _, b := range sample {
+			if a != b {
+				d = append(d, dist(data.Values(a), data.Values(b)))
+			}
+		}
+		sort.Float64s(d)
+		kth[i] = d[k-1]
+	}
+	sort.Float64s(kth)
+	return kth[len(kth)/2]
+}
+
+// silhouette returns the mean silhouette width of the sampled values of c.
+func silhouette(c cluster.Clusterer, sample []int) float64 {
+	vals := c.Values()
+	k := len(c.Centers())
+	sum := make([]float64, k)
+	count := make([]int, k)
+	var total float64
+	for _, i := range sample {
+		for j := range sum {
+			sum[j] = 0
+			count[j] = 0
+		}
+		vi := vals[i]
+		for _, j := range sample {
+			if i == j {
+				continue
+			}
+			l := vals[j].Cluster()
+			if l < 0 {
+				continue
+			}
+			sum[l] += dist(vi.V(), vals[j].V())
+			count[l]++
+		}
+		own := vi.Cluster()
+		if own < 0 || count[own] == 0 {
+			continue
+		}
+		a := sum[own] / float64(count[own])
+		b := math.Inf(1)
+		for l := range sum {
+			if l != own && count[l] != 0 {
+				if m := sum[l] / float64(count[l]); m < b {
+					b = m
+				}
+			}
+		}
+		if math.IsInf(b, 1) {
+			continue
+		}
+		total += (b - a) / math.Max(a, b)
+	}
+	return total / float64(len(sample))
+}
+
+func dist(a, b []float64) float64 {
+	var ss float64
+	for i, v := range a {
+		d := v - b[i]
+		ss += d * d
+	}
+	return math.Sqrt(ss)
+}
+
+func sum(x []float64) float64 {
+	var s float64
+	for _, v := range x {
+		s += v
+	}
+	return s
+}
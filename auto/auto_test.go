@@ -0,0 +1,90 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package auto_test
+
+import (
+	"github.com/biogo/cluster/auto"
+
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+// blobs returns n points around each of the given centers drawn using rnd.
+func blobs(rnd *rand.Rand, n int, sd float64, centers ...[]float64) Points {
+	var p Points
+	for _, c := range centers {
+		for i := 0; i < n; i++ {
+			v := make([]float64, len(c))
+			for j, x := range c {
+				v[j] = x + rnd.NormFloat64()*sd
+			}
+			p = append(p, v)
+		}
+	}
+	return p
+}
+
+func (s *S) TestFit(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, t := range []struct {
+		data Points
+		alg  string
+		k    int
+	}{
+		{
+			data: blobs(rnd, 20, 1, []float64{0}, []float64{50}, []float64{100}),
+			alg:  "ckmeans",
+			k:    3,
+		},
+		{
+			data: blobs(rnd, 20, 1, []float64{0, 0}, []float64{30, 0}, []float64{0, 30}, []float64{30, 30}),
+			alg:  "kmeans|meanshift",
+			k:    4,
+		},
+	} {
+		cl, rep, err := auto.Fit(t.data, &auto.Options{Rand: rnd})
+		c.Assert(err, check.Equals, nil)
+		c.Check(rep.N, check.Equals, len(t.data))
+		c.Check(rep.Dims, check.Equals, len(t.data[0]))
+		c.Check(rep.Chosen.Clusters, check.Equals, t.k)
+		c.Check(len(cl.Centers()), check.Equals, t.k)
+		c.Check(rep.Chosen.Algorithm, check.Matches, t.alg)
+		for _, cand := range rep.Candidates {
+			c.Check(cand.Score <= rep.Chosen.Score, check.Equals, true)
+		}
+		for i, v := range cl.Values() {
+			c.Check(v.Cluster(), check.Equals, cl.Values()[i/20*20].Cluster())
+		}
+	}
+
+	data := blobs(rnd, 20, 1, []float64{0, 0}, []float64{30, 0}, []float64{0, 30})
+	var reps [2]auto.Report
+	for i := range reps {
+		var err error
+		_, reps[i], err = auto.Fit(data, &auto.Options{Sample: 30, Rand: rand.New(rand.NewSource(2))})
+		c.Assert(err, check.Equals, nil)
+	}
+	c.Check(reps[1], check.DeepEquals, reps[0])
+}
+
+func (s *S) TestFitErrors(c *check.C) {
+	_, _, err := auto.Fit(Points{{1}, {2}}, nil)
+	c.Check(err, check.ErrorMatches, "auto: too few data")
+	_, _, err = auto.Fit(Points{{1}, {2}, {3, 4}}, nil)
+	c.Check(err, check.ErrorMatches, "auto: mismatched dimensions")
+}
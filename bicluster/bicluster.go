@@ -0,0 +1,307 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bicluster implements the Cheng and Church biclustering algorithm for
+// finding coherent submatrices of expression matrices.
+//
+// Reference:
+//
+//	Cheng Y, Church GM. Biclustering of expression data. Proceedings of the Eighth
+//	International Conference on Intelligent Systems for Molecular Biology 93-103
+//	(2000).
+package bicluster
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// Bicluster is a submatrix of a data matrix.
+type Bicluster struct {
+	Rows, Cols []int   // Rows and Cols are the indices of the rows and columns of the submatrix.
+	Residue    float64 // Residue is the mean squared residue of the submatrix.
+}
+
+// multipleDeletion is the minimum number of rows or columns for which multiple
+// node deletion is used.
+const multipleDeletion = 100
+
+// ChengChurch returns at most n biclusters of the matrix held by data, where the
+// elements of data are the rows of the matrix. Each bicluster has a mean squared
+// residue no greater than delta. When more than 100 rows or columns remain, rows
+// and columns with a mean squared residue greater than alpha times that of the
+// current submatrix are deleted together. After each bicluster is found, its
+// elements are masked by uniformly distributed random values drawn from the range
+// of the data so that subsequent biclusters are distinct. The masking values are
+// drawn from rnd, or from the global math/rand source if rnd is nil.
+func ChengChurch(data cluster.Interface, delta, alpha float64, n int, rnd *rand.Rand) ([]Bicluster, error) {
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "bicluster", Err: cluster.ErrEmptyData}
+	}
+	if delta < 0 {
+		return nil, errors.New("bicluster: invalid delta")
+	}
+	if alpha < 1 {
+		return nil, errors.New("bicluster: invalid alpha")
+	}
+	cols := len(data.Values(0))
+	a := make([][]float64, data.Len())
+	min, max := math.Inf(1), math.Inf(-1)
+	for i := range a {
+		vec := data.Values(i)
+		if len(vec) != cols {
+			return nil, &cluster.Error{Pkg: "bicluster", Err: cluster.ErrDimensionMismatch}
+		}
+		a[i] = append([]float64(nil), vec...)
+		for _, v := range vec {
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+
+	uniform := rand.Float64
+	if rnd != nil {
+		uniform = rnd.Float64
+	}
+	var bcs []Bicluster
+	for len(bcs) < n {
+		m := newSubmatrix(a)
+		m.tol = 1e-12 * (max - min) * (max - min)
+		if len(a) >= multipleDeletion || cols >= multipleDeletion {
+			m.multipleDelete(delta, alpha)
+		}
+		m.singleDelete(delta)
+		m.add()
+		r, c := m.indices()
+		if len(r) == 0 || len(c) == 0 {
+			break
+		}
+		bcs = append(bcs, Bicluster{Rows: r, Cols: c, Residue: m.residue()})
+		for _, i := range r {
+			for _, j := range c {
+				a[i][j] = min + uniform()*(max-min)
+			}
+		}
+	}
+	return bcs, nil
+}
+
+// submatrix is a selection of rows and columns of a matrix.
+type submatrix struct {
+	a          [][]float64
+	rows, cols []bool
+
+	rowMean, colMean []float64
+	mean             float64
+
+	// tol is the tolerance for rounding error in residue comparisons.
+	tol float64
+}
+
+func newSubmatrix(a [][]float64) *submatrix {
+	m := &submatrix{
+		a:       a,
+		rows:    make([]bool, len(a)),
+		cols:    make([]bool, len(a[0])),
+		rowMean: make([]float64, len(a)),
+		colMean: make([]float64, len(a[0])),
+	}
+	for i := range m.rows {
+		m.rows[i] = true
+	}
+	for j := range m.cols {
+		m.cols[j] = true
+	}
+	m.means()
+	return m
+}
+
+func count(s []bool) int {
+	var n int
+	for _, b := range s {
+		if b {
+			n++
+		}
+	}
+	return n
+}
+
+// means calculates the row, column and submatrix means over the selected elements.
+// Row and column means are calculated for all rows and columns.
+func (m *submatrix) means() {
+	nr, nc := float64(count(m.rows)), float64(count(m.cols))
+	for j := range m.colMean {
+		m.colMean[j] = 0
+	}
+	m.mean = 0
+	for i, row := range m.a {
+		var s float64
+		for j, v := range row {
+			if m.cols[j] {
+				s += v
+			}
+			if m.rows[i] {
+				m.colMean[j] += v
+			}
+		}
+		m.rowMean[i] = s / nc
+		if m.rows[i] {
+			m.mean += s
+		}
+	}
+	for j := range m.colMean {
+		m.colMean[j] /= nr
+	}
+	m.mean /= nr * nc
+}
+
+func (m *submatrix) r(i, j int) float64 {
+	return m.a[i][j] - m.rowMean[i] - m.colMean[j] + m.mean
+}
+
+// rowResidue returns the mean squared residue of row i over the selected columns.
+func (m *submatrix) rowResidue(i int) float64 {
+	var s float64
+	for j, ok := range m.cols {
+		if ok {
+			r := m.r(i, j)
+			s += r * r
+		}
+	}
+	return s / float64(count(m.cols))
+}
+
+// colResidue returns the mean squared residue of column j over the selected rows.
+func (m *submatrix) colResidue(j int) float64 {
+	var s float64
+	for i, ok := range m.rows {
+		if ok {
+			r := m.r(i, j)
+			s += r * r
+		}
+	}
+	return s / float64(count(m.rows))
+}
+
+// residue returns the mean squared residue of the submatrix.
+func (m *submatrix) residue() float64 {
+	var s float64
+	for i, ok := range m.rows {
+		if ok {
+			s += m.rowResidue(i)
+		}
+	}
+	return s / float64(count(m.rows))
+}
+
+func (m *submatrix) multipleDelete(delta, alpha float64) {
+	for {
+		h := m.residue()
+		if h <= delta {
+			return
+		}
+		var removed bool
+		if count(m.rows) >= multipleDeletion {
+			for i, ok := range m.rows {
+				if ok && m.rowResidue(i) > alpha*h {
+					m.rows[i] = false
+					removed = true
+				}
+			}
+			m.means()
+			h = m.residue()
+		}
+		if count(m.cols) >= multipleDeletion {
+			for j, ok := range m.cols {
+				if ok && m.colResidue(j) > alpha*h {
+					m.cols[j] = false
+					removed = true
+				}
+			}
+			m.means()
+		}
+		if !removed {
+			return
+		}
+	}
+}
+
+func (m *submatrix) singleDelete(delta float64) {
+	for m.residue() > delta {
+		bestRow, rowMax := -1, math.Inf(-1)
+		if count(m.rows) > 1 {
+			for i, ok := range m.rows {
+				if ok {
+					if d := m.rowResidue(i); d > rowMax {
+						bestRow, rowMax = i, d
+					}
+				}
+			}
+		}
+		bestCol, colMax := -1, math.Inf(-1)
+		if count(m.cols) > 1 {
+			for j, ok := range m.cols {
+				if ok {
+					if d := m.colResidue(j); d > colMax {
+						bestCol, colMax = j, d
+					}
+				}
+			}
+		}
+		switch {
+		case bestRow < 0 && bestCol < 0:
+			return
+		case rowMax >= colMax:
+			m.rows[bestRow] = false
+		default:
+			m.cols[bestCol] = false
+		}
+		m.means()
+	}
+}
+
+// add adds the columns and then the rows not in the submatrix whose mean squared
+// residue is no greater than that of the submatrix, within rounding error,
+// repeating until no further addition is possible.
+func (m *submatrix) add() {
+	for {
+		var added bool
+		h := m.residue()
+		for j, ok := range m.cols {
+			if !ok && m.colResidue(j) <= h+m.tol {
+				m.cols[j] = true
+				added = true
+			}
+		}
+		m.means()
+		h = m.residue()
+		for i, ok := range m.rows {
+			if !ok && m.rowResidue(i) <= h+m.tol {
+				m.rows[i] = true
+				added = true
+			}
+		}
+		m.means()
+		if !added {
+			return
+		}
+	}
+}
+
+func (m *submatrix) indices() (rows, cols []int) {
+	for i, ok := range m.rows {
+		if ok {
+			rows = append(rows, i)
+		}
+	}
+	for j, ok := range m.cols {
+		if ok {
+			cols = append(cols, j)
+		}
+	}
+	return rows, cols
+}
@@ -0,0 +1,68 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bicluster_test
+
+import (
+	"github.com/biogo/cluster/bicluster"
+
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+// Expression is a genes×samples expression matrix.
+type Expression [][]float64
+
+func (e Expression) Len() int               { return len(e) }
+func (e Expression) Values(i int) []float64 { return e[i] }
+
+func (s *S) TestChengChurch(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	m := make(Expression, 40)
+	for i := range m {
+		m[i] = make([]float64, 20)
+		for j := range m[i] {
+			m[i][j] = rnd.Float64() * 10
+		}
+	}
+	// Plant an additive bicluster in rows 5-14 and columns 3-8.
+	for i := 5; i < 15; i++ {
+		r := rnd.Float64() * 3
+		for j := 3; j < 9; j++ {
+			m[i][j] = r + float64(j)/2
+		}
+	}
+
+	bcs, err := bicluster.ChengChurch(m, 0.05, 1.2, 2, rnd)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(len(bcs), check.Equals, 2)
+	c.Check(bcs[0].Rows, check.DeepEquals, []int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14})
+	c.Check(bcs[0].Cols, check.DeepEquals, []int{3, 4, 5, 6, 7, 8})
+	c.Check(bcs[0].Residue < 1e-12, check.Equals, true)
+	c.Check(bcs[1].Residue <= 0.05, check.Equals, true)
+
+	var again [2][]bicluster.Bicluster
+	for i := range again {
+		again[i], err = bicluster.ChengChurch(m, 0.05, 1.2, 3, rand.New(rand.NewSource(2)))
+		c.Assert(err, check.Equals, nil)
+	}
+	c.Check(again[1], check.DeepEquals, again[0])
+
+	_, err = bicluster.ChengChurch(Expression{}, 0.05, 1.2, 1, nil)
+	c.Check(err, check.ErrorMatches, "bicluster: no data")
+	_, err = bicluster.ChengChurch(m, -1, 1.2, 1, nil)
+	c.Check(err, check.ErrorMatches, "bicluster: invalid delta")
+	_, err = bicluster.ChengChurch(m, 0.05, 0.5, 1, nil)
+	c.Check(err, check.ErrorMatches, "bicluster: invalid alpha")
+	_, err = bicluster.ChengChurch(Expression{{1}, {1, 2}}, 0.05, 1.2, 1, nil)
+	c.Check(err, check.ErrorMatches, "bicluster: mismatched dimensions")
+}
@@ -0,0 +1,303 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package birch implements the BIRCH clustering feature tree for summarising large
+// streams of ℝⁿ data in bounded memory. The leaf entries of the tree can then be
+// clustered by any Clusterer that accepts weighted data.
+//
+// Reference:
+//
+//	Zhang T, Ramakrishnan R, Livny M. BIRCH: an efficient data clustering method for
+//	very large databases. Proceedings of the ACM SIGMOD International Conference on
+//	Management of Data 103-114 (1996).
+package birch
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+)
+
+// CF is a clustering feature summarising a set of weighted points.
+type CF struct {
+	N  float64   // N is the total weight of the points.
+	LS []float64 // LS is the weighted linear sum of the points.
+	SS float64   // SS is the weighted sum of the squared norms of the points.
+}
+
+// add adds the points summarised by o to c.
+func (c *CF) add(o CF) {
+	if c.LS == nil {
+		c.LS = make([]float64, len(o.LS))
+	}
+	c.N += o.N
+	for i, v := range o.LS {
+		c.LS[i] += v
+	}
+	c.SS += o.SS
+}
+
+// Centroid returns the weighted mean of the points summarised by c.
+func (c CF) Centroid() []float64 {
+	m := make([]float64, len(c.LS))
+	for i, v := range c.LS {
+		m[i] = v / c.N
+	}
+	return m
+}
+
+// Radius returns the root mean squared distance of the points summarised by c from
+// their centroid.
+func (c CF) Radius() float64 {
+	var mm float64
+	for _, v := range c.LS {
+		m := v / c.N
+		mm += m * m
+	}
+	r := c.SS/c.N - mm
+	if r < 0 {
+		return 0
+	}
+	return math.Sqrt(r)
+}
+
+// merged returns the radius of the union of the points summarised by a and b.
+func merged(a, b CF) float64 {
+	m := CF{LS: make([]float64, len(a.LS))}
+	m.add(a)
+	m.add(b)
+	return m.Radius()
+}
+
+// sqDist returns the squared distance between the centroids of a and b.
+func sqDist(a, b CF) float64 {
+	var d float64
+	for i, v := range a.LS {
+		e := v/a.N - b.LS[i]/b.N
+		d += e * e
+	}
+	return d
+}
+
+type entry struct {
+	cf    CF
+	child *node
+}
+
+type node struct {
+	leaf    bool
+	entries []entry
+}
+
+// Tree is a clustering feature tree.
+type Tree struct {
+	threshold float64
+	branching int
+	leafSize  int
+	maxLeaves int
+
+	dims   int
+	root   *node
+	leaves int
+}
+
+// New returns a new empty Tree. Leaf entries absorb points while their radius is no
+// greater than threshold, non-leaf nodes hold at most branching entries and leaf
+// nodes hold at most leafSize entries.
+func New(threshold float64, branching, leafSize int) (*Tree, error) {
+	if threshold < 0 {
+		return nil, errors.New("birch: invalid threshold")
+	}
+	if branching < 2 || leafSize < 2 {
+		return nil, errors.New("birch: invalid node size")
+	}
+	return &Tree{
+		threshold: threshold,
+		branching: branching,
+		leafSize:  leafSize,
+		root:      &node{leaf: true},
+	}, nil
+}
+
+// SetMaxLeaves bounds the number of leaf entries held by the tree to n. When an
+// insertion causes the bound to be exceeded, the threshold is increased and the
+// tree is rebuilt from its leaf entries. If n is zero, the number of leaf entries
+// is not bounded.
+func (t *Tree) SetMaxLeaves(n int) { t.maxLeaves = n }
+
+// Threshold returns the current leaf entry radius threshold of the tree.
+func (t *Tree) Threshold() float64 { return t.threshold }
+
+// Insert adds the point v with weight w to the tree.
+func (t *Tree) Insert(v []float64, w float64) error {
+	if t.dims == 0 {
+		t.dims = len(v)
+	} else if len(v) != t.dims {
+		return &cluster.Error{Pkg: "birch", Err: cluster.ErrDimensionMismatch}
+	}
+	if w <= 0 {
+		return nil
+	}
+	cf := CF{N: w, LS: make([]float64, len(v))}
+	for i, x := range v {
+		cf.LS[i] = w * x
+		cf.SS += w * x * x
+	}
+	t.insert(cf)
+	for t.maxLeaves > 0 && t.leaves > t.maxLeaves {
+		t.rebuild()
+	}
+	return nil
+}
+
+// ReadFrom inserts all the values of s into the tree, returning the number of
+// values read.
+func (t *Tree) ReadFrom(s cluster.Stream) (int, error) {
+	var n int
+	for {
+		v, w, ok := s.Next()
+		if !ok {
+			return n, nil
+		}
+		err := t.Insert(v, w)
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+func (t *Tree) insert(cf CF) {
+	if split := t.insertNode(t.root, cf); split != nil {
+		t.root = &node{entries: []entry{
+			{cf: sum(t.root), child: t.root},
+			{cf: sum(split), child: split},
+		}}
+	}
+}
+
+// sum returns the clustering feature of all the entries of n.
+func sum(n *node) CF {
+	var s CF
+	for _, e := range n.entries {
+		s.add(e.cf)
+	}
+	return s
+}
+
+// closest returns the index of the entry of n with the centroid closest to cf.
+func closest(n *node, cf CF) int {
+	best, min := -1, math.Inf(1)
+	for i, e := range n.entries {
+		if d := sqDist(e.cf, cf); d < min {
+			best, min = i, d
+		}
+	}
+	return best
+}
+
+// insertNode inserts cf below n, returning a new sibling of n if n was split.
+func (t *Tree) insertNode(n *node, cf CF) *node {
+	if n.leaf {
+		if i := closest(n, cf); i >= 0 && merged(n.entries[i].cf, cf) <= t.threshold {
+			n.entries[i].cf.add(cf)
+			return nil
+		}
+		n.entries = append(n.entries, entry{cf: cf})
+		t.leaves++
+		if len(n.entries) > t.leafSize {
+			return split(n)
+		}
+		return nil
+	}
+
+	i := closest(n, cf)
+	e := &n.entries[i]
+	if s := t.insertNode(e.child, cf); s != nil {
+		e.cf = sum(e.child)
+		n.entries = append(n.entries, entry{cf: sum(s), child: s})
+		if len(n.entries) > t.branching {
+			return split(n)
+		}
+		return nil
+	}
+	e.cf.add(cf)
+	return nil
+}
+
+// split divides the entries of n between n and a new node using the farthest
+// pair of entries as seeds, and returns the new node.
+func split(n *node) *node {
+	a, b, max := 0, 1, -1.
+	for i := range n.entries {
+		for j := i + 1; j < len(n.entries); j++ {
+			if d := sqDist(n.entries[i].cf, n.entries[j].cf); d > max {
+				a, b, max = i, j, d
+			}
+		}
+	}
+	ea, eb := n.entries[a], n.entries[b]
+	var left, right []entry
+	for _, e := range n.entries {
+		if sqDist(e.cf, ea.cf) <= sqDist(e.cf, eb.cf) {
+			left = append(left, e)
+		} else {
+			right = append(right, e)
+		}
+	}
+	n.entries = left
+	return &node{leaf: n.leaf, entries: right}
+}
+
+// rebuild doubles the threshold, or sets it to the smallest distance between leaf
+// entry centroids if that is larger, and reinserts the leaf entries.
+func (t *Tree) rebuild() {
+	leaves := t.Leaves()
+	min := math.Inf(1)
+	for i := range leaves {
+		for j := i + 1; j < len(leaves); j++ {
+			if d := sqDist(leaves[i], leaves[j]); d < min {
+				min = d
+			}
+		}
+	}
+	t.threshold = math.Max(2*t.threshold, math.Sqrt(min))
+	t.root = &node{leaf: true}
+	t.leaves = 0
+	for _, cf := range leaves {
+		t.insert(cf)
+	}
+}
+
+// Leaves returns the clustering features of the leaf entries of the tree.
+func (t *Tree) Leaves() []CF {
+	var cfs []CF
+	var walk func(*node)
+	walk = func(n *node) {
+		for _, e := range n.entries {
+			if n.leaf {
+				cfs = append(cfs, e.cf)
+			} else {
+				walk(e.child)
+			}
+		}
+	}
+	walk(t.root)
+	return cfs
+}
+
+// Summary is a weighted cluster.Interface over the centroids of a set of
+// clustering features. A Summary can be clustered by any Clusterer that makes use
+// of cluster.Weighter.
+type Summary []CF
+
+// Len returns the number of clustering features.
+func (s Summary) Len() int { return len(s) }
+
+// Values returns the centroid of clustering feature i.
+func (s Summary) Values(i int) []float64 { return s[i].Centroid() }
+
+// Weight returns the weight of clustering feature i.
+func (s Summary) Weight(i int) float64 { return s[i].N }
@@ -0,0 +1,91 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package birch_test
+
+import (
+	"github.com/biogo/cluster/birch"
+	"github.com/biogo/cluster/kmeans"
+
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+// blobs is a cluster.Stream of points drawn around a set of centers.
+type blobs struct {
+	centers [][]float64
+	n, i    int
+	rnd     *rand.Rand
+}
+
+func (b *blobs) Next() ([]float64, float64, bool) {
+	if b.i == b.n {
+		return nil, 0, false
+	}
+	c := b.centers[b.i%len(b.centers)]
+	b.i++
+	return []float64{c[0] + b.rnd.NormFloat64(), c[1] + b.rnd.NormFloat64()}, 1, true
+}
+
+func (s *S) TestBIRCH(c *check.C) {
+	centers := [][]float64{{0, 0}, {20, 0}, {0, 20}}
+	t, err := birch.New(0.5, 10, 10)
+	c.Assert(err, check.Equals, nil)
+	t.SetMaxLeaves(100)
+	n, err := t.ReadFrom(&blobs{centers: centers, n: 30000, rnd: rand.New(rand.NewSource(1))})
+	c.Assert(err, check.Equals, nil)
+	c.Check(n, check.Equals, 30000)
+
+	leaves := t.Leaves()
+	c.Check(len(leaves) <= 100, check.Equals, true)
+	c.Check(t.Threshold() > 0.5, check.Equals, true)
+	var w float64
+	for _, cf := range leaves {
+		w += cf.N
+	}
+	c.Check(w, check.Equals, 30000.)
+
+	km, err := kmeans.New(birch.Summary(leaves), kmeans.WithRand(rand.New(rand.NewSource(1))))
+	c.Assert(err, check.Equals, nil)
+	km.Seed(3)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	var got [][]float64
+	for _, cen := range km.Centers() {
+		got = append(got, cen.V())
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i][0]*2+got[i][1] < got[j][0]*2+got[j][1] })
+	for i, m := range [][]float64{{0, 0}, {0, 20}, {20, 0}} {
+		c.Check(math.Hypot(got[i][0]-m[0], got[i][1]-m[1]) < 0.2, check.Equals, true, check.Commentf("%v", got))
+	}
+}
+
+func (s *S) TestCF(c *check.C) {
+	t, err := birch.New(10, 2, 2)
+	c.Assert(err, check.Equals, nil)
+	for _, v := range [][]float64{{0, 0}, {2, 0}, {0, 2}, {2, 2}} {
+		c.Assert(t.Insert(v, 1), check.Equals, nil)
+	}
+	leaves := t.Leaves()
+	c.Assert(len(leaves), check.Equals, 1)
+	c.Check(leaves[0].Centroid(), check.DeepEquals, []float64{1, 1})
+	c.Check(leaves[0].Radius(), check.Equals, math.Sqrt2)
+	c.Check(t.Insert([]float64{1}, 1), check.ErrorMatches, "birch: mismatched dimensions")
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := birch.New(-1, 2, 2)
+	c.Check(err, check.ErrorMatches, "birch: invalid threshold")
+	_, err = birch.New(1, 1, 2)
+	c.Check(err, check.ErrorMatches, "birch: invalid node size")
+}
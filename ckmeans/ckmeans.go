@@ -0,0 +1,254 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ckmeans implements globally optimal k-means clustering of one-dimensional
+// data by dynamic programming as described by Wang and Song.
+//
+// Reference:
+//
+//	Wang H, Song M. Ckmeans.1d.dp: optimal k-means clustering in one dimension by
+//	dynamic programming. The R Journal 3(2):29-33 (2011).
+package ckmeans
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+	"sort"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	w       float64
+	cluster int
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+type center struct {
+	point
+	w       float64
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// Ckmeans implements optimal k-means clustering of one-dimensional data. The
+// partition found minimises the weighted within-cluster sum of squares over all
+// partitions of the data into k clusters.
+type Ckmeans struct {
+	k      int
+	values []value
+	means  []center
+}
+
+// New creates a new Ckmeans object that will partition data into k clusters. The
+// values of data must be one-dimensional.
+func New(data cluster.Interface, k int) (*Ckmeans, error) {
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "ckmeans", Err: cluster.ErrEmptyData}
+	}
+	if k < 1 {
+		return nil, errors.New("ckmeans: invalid k")
+	}
+	if k > data.Len() {
+		return nil, errors.New("ckmeans: too many clusters")
+	}
+	va := make([]value, data.Len())
+	w, _ := data.(cluster.Weighter)
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != 1 {
+			return nil, &cluster.Error{Pkg: "ckmeans", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: point{vec[0]}, w: 1}
+		if w != nil {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return &Ckmeans{k: k, values: va}, nil
+}
+
+// byValue sorts indices into values by the value they refer to.
+type byValue struct {
+	idx    []int
+	values []value
+}
+
+func (s byValue) Len() int { return len(s.idx) }
+func (s byValue) Less(i, j int) bool {
+	return s.values[s.idx[i]].point[0] < s.values[s.idx[j]].point[0]
+}
+func (s byValue) Swap(i, j int) { s.idx[i], s.idx[j] = s.idx[j], s.idx[i] }
+
+// Cluster runs a clustering of the data. The dynamic programme is solved in
+// O(kn log n) time using divide and conquer over the monotone optimal split points.
+func (km *Ckmeans) Cluster() error {
+	n := len(km.values)
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Sort(byValue{idx: idx, values: km.values})
+
+	// Prefix sums are calculated about the median to reduce cancellation error.
+	shift := km.values[idx[n/2]].point[0]
+	sw := make([]float64, n+1)
+	swx := make([]float64, n+1)
+	swxx := make([]float64, n+1)
+	for i, j := range idx {
+		v := km.values[j]
+		x := v.point[0] - shift
+		sw[i+1] = sw[i] + v.w
+		swx[i+1] = swx[i] + v.w*x
+		swxx[i+1] = swxx[i] + v.w*x*x
+	}
+	// cost returns the weighted sum of squares of sorted elements j through i.
+	cost := func(j, i int) float64 {
+		w := sw[i+1] - sw[j]
+		if w <= 0 {
+			return 0
+		}
+		s := swx[i+1] - swx[j]
+		ss := swxx[i+1] - swxx[j] - s*s/w
+		if ss < 0 {
+			return 0
+		}
+		return ss
+	}
+
+	// split[q][i] holds the index of the first element of the last of
+	// q+1 clusters partitioning sorted elements 0 through i.
+	split := make([][]int, km.k)
+	prev := make([]float64, n)
+	cur := make([]float64, n)
+	split[0] = make([]int, n)
+	for i := range prev {
+		prev[i] = cost(0, i)
+	}
+	for q := 1; q < km.k; q++ {
+		split[q] = make([]int, n)
+		var fill func(lo, hi, optLo, optHi int)
+		fill = func(lo, hi, optLo, optHi int) {
+			if lo > hi {
+				return
+			}
+			mid := (lo + hi) / 2
+			if optLo < q {
+				optLo = q
+			}
+			last := optHi
+			if last > mid {
+				last = mid
+			}
+			best := optLo
+			min := prev[best-1] + cost(best, mid)
+			for j := optLo + 1; j <= last; j++ {
+				d := prev[j-1] + cost(j, mid)
+				if d < min {
+					best, min = j, d
+				}
+			}
+			cur[mid] = min
+			split[q][mid] = best
+			fill(lo, mid-1, optLo, best)
+			fill(mid+1, hi, best, optHi)
+		}
+		fill(q, n-1, q, n-1)
+		prev, cur = cur, prev
+	}
+
+	km.means = make([]center, km.k)
+	for q, i := km.k-1, n-1; q >= 0; q-- {
+		j := split[q][i]
+		c := &km.means[q]
+		c.point = point{0}
+		for _, m := range idx[j : i+1] {
+			v := &km.values[m]
+			v.cluster = q
+			c.point[0] += v.w * v.point[0]
+			c.w += v.w
+		}
+		if c.w > 0 {
+			c.point[0] /= c.w
+		} else {
+			c.point[0] = km.values[idx[j]].point[0]
+		}
+		i = j - 1
+	}
+	for i, v := range km.values {
+		km.means[v.cluster].indices = append(km.means[v.cluster].indices, i)
+	}
+
+	return nil
+}
+
+// Total calculates the total weighted sum of squares for the data relative to the
+// weighted data mean.
+func (km *Ckmeans) Total() float64 {
+	var m, w float64
+	for _, v := range km.values {
+		m += v.w * v.point[0]
+		w += v.w
+	}
+	m /= w
+
+	var ss float64
+	for _, v := range km.values {
+		d := v.point[0] - m
+		ss += v.w * d * d
+	}
+	return ss
+}
+
+// Within calculates the weighted sum of squares within each cluster.
+// Returns nil if Cluster has not been called.
+func (km *Ckmeans) Within() []float64 {
+	if km.means == nil {
+		return nil
+	}
+	ss := make([]float64, len(km.means))
+	for _, v := range km.values {
+		d := km.means[v.cluster].point[0] - v.point[0]
+		ss[v.cluster] += v.w * d * d
+	}
+	return ss
+}
+
+// Assign returns the index of the center nearest to the one-dimensional value x. If
+// Cluster has not been called, Assign returns -1.
+func (km *Ckmeans) Assign(x []float64) int {
+	c, min := -1, math.Inf(1)
+	for j, m := range km.means {
+		if d := math.Abs(x[0] - m.point[0]); d < min {
+			c, min = j, d
+		}
+	}
+	return c
+}
+
+// Centers returns the k centers determined by a previous call to Cluster. Centers
+// are ordered by increasing location.
+func (km *Ckmeans) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(km.means))
+	for i := range km.means {
+		cs[i] = &km.means[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Ckmeans.
+func (km *Ckmeans) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(km.values))
+	for i := range km.values {
+		vs[i] = &km.values[i]
+	}
+	return vs
+}
@@ -0,0 +1,132 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ckmeans_test
+
+import (
+	"github.com/biogo/cluster/ckmeans"
+	"github.com/biogo/cluster/cluster"
+
+	"math"
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Lengths []float64
+
+func (l Lengths) Len() int               { return len(l) }
+func (l Lengths) Values(i int) []float64 { return []float64{l[i]} }
+
+type Weighted struct {
+	Lengths
+	w []float64
+}
+
+func (w Weighted) Weight(i int) float64 { return w.w[i] }
+
+func sum(x []float64) float64 {
+	var s float64
+	for _, v := range x {
+		s += v
+	}
+	return s
+}
+
+func (s *S) TestCkmeans(c *check.C) {
+	data := Lengths{30, 12, 1, 11, 3, 2, 10}
+	km, err := ckmeans.New(data, 3)
+	c.Assert(err, check.Equals, nil)
+	var p cluster.Predictor = km
+	c.Check(p.Assign([]float64{5}), check.Equals, -1)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	c.Check(p.Assign([]float64{6}), check.Equals, 0)
+	c.Check(p.Assign([]float64{20}), check.Equals, 1)
+	c.Check(p.Assign([]float64{100}), check.Equals, 2)
+	var got []cluster.Indices
+	var means []float64
+	for _, cen := range km.Centers() {
+		got = append(got, cen.Members())
+		means = append(means, cen.V()[0])
+	}
+	c.Check(got, check.DeepEquals, []cluster.Indices{{2, 4, 5}, {1, 3, 6}, {0}})
+	c.Check(means, check.DeepEquals, []float64{2, 11, 30})
+	c.Check(sum(km.Within()), check.Equals, 4.)
+	for i, v := range km.Values() {
+		c.Check(v.V()[0], check.Equals, data[i])
+	}
+}
+
+// brute returns the minimum weighted within-cluster sum of squares over all
+// partitions of sorted x into k contiguous clusters.
+func brute(x, w []float64, k int) float64 {
+	if k == 1 {
+		var m, sw float64
+		for i := range x {
+			m += w[i] * x[i]
+			sw += w[i]
+		}
+		m /= sw
+		var ss float64
+		for i := range x {
+			d := x[i] - m
+			ss += w[i] * d * d
+		}
+		return ss
+	}
+	min := math.Inf(1)
+	for i := k - 1; i < len(x); i++ {
+		ss := brute(x[:i], w[:i], k-1) + brute(x[i:], w[i:], 1)
+		if ss < min {
+			min = ss
+		}
+	}
+	return min
+}
+
+func (s *S) TestOptimal(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for t := 0; t < 20; t++ {
+		n := 3 + rnd.Intn(10)
+		x := make([]float64, n)
+		w := make([]float64, n)
+		var v float64
+		for i := range x {
+			v += rnd.Float64() * 5
+			x[i] = v
+			w[i] = 0.5 + rnd.Float64()
+		}
+		perm := rnd.Perm(n)
+		px := make([]float64, n)
+		pw := make([]float64, n)
+		for i, j := range perm {
+			px[i], pw[i] = x[j], w[j]
+		}
+		for k := 1; k <= 4 && k <= n; k++ {
+			km, err := ckmeans.New(Weighted{Lengths: px, w: pw}, k)
+			c.Assert(err, check.Equals, nil)
+			c.Assert(km.Cluster(), check.Equals, nil)
+			got, want := sum(km.Within()), brute(x, w, k)
+			c.Check(math.Abs(got-want) < 1e-9, check.Equals, true, check.Commentf("n=%d k=%d got=%v want=%v", n, k, got, want))
+		}
+	}
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := ckmeans.New(Lengths{}, 1)
+	c.Check(err, check.ErrorMatches, "ckmeans: no data")
+	_, err = ckmeans.New(Lengths{1}, 0)
+	c.Check(err, check.ErrorMatches, "ckmeans: invalid k")
+	_, err = ckmeans.New(Lengths{1}, 2)
+	c.Check(err, check.ErrorMatches, "ckmeans: too many clusters")
+	_, err = ckmeans.New(cluster.NewDense(2, 2), 1)
+	c.Check(err, check.ErrorMatches, "ckmeans: mismatched dimensions")
+}
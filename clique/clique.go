@@ -0,0 +1,315 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package clique implements the CLIQUE grid-based subspace clustering algorithm.
+//
+// Reference:
+//
+//	Agrawal R, Gehrke J, Gunopulos D, Raghavan P. Automatic subspace clustering of
+//	high dimensional data for data mining applications. Proceedings of the ACM
+//	SIGMOD International Conference on Management of Data 94-105 (1998).
+package clique
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+	"sort"
+)
+
+// Noise is the cluster label of values that are not in any dense unit.
+const Noise = -1
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	cluster int
+}
+
+func (v *value) Cluster() int { return v.cluster }
+
+type center struct {
+	point
+	dims    []int
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// unit is a cell of the grid in an axis-aligned subspace. The ith interval of the
+// unit lies on dimension dims[i].
+type unit struct {
+	dims      []int
+	intervals []int
+	members   []int
+}
+
+// subspace returns a key identifying the subspace of u.
+func (u *unit) subspace() string { return key(u.dims) }
+
+func key(x []int) string {
+	b := make([]byte, 0, 4*len(x))
+	for _, v := range x {
+		b = append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+	return string(b)
+}
+
+// CLIQUE implements subspace clustering of ℝⁿ data using the CLIQUE algorithm.
+// Each dimension of the data is partitioned into equal width intervals spanning the
+// range of the data. A unit of the resulting grid in a subspace is dense if it
+// holds more than a fraction tau of the data, and a cluster is a maximal set of
+// connected dense units within a subspace. Dense units are found bottom-up, since
+// a unit can only be dense if all its projections into lower dimensional
+// subspaces are dense.
+//
+// Clusters in different subspaces may share values. The Centers of a CLIQUE are
+// all the clusters found, ordered by decreasing subspace dimensionality, and the
+// Cluster of each value is the first cluster it is a member of.
+type CLIQUE struct {
+	xi     int
+	tau    float64
+	values []value
+	cell   [][]int
+	cens   []center
+}
+
+// New creates a new CLIQUE Clusterer object populated with data from an Interface
+// value, data. Each dimension is divided into xi intervals, and a unit is dense if
+// it holds more than tau·n values, where n is the number of values in data.
+func New(data cluster.Interface, xi int, tau float64) (*CLIQUE, error) {
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "clique", Err: cluster.ErrEmptyData}
+	}
+	if xi < 1 {
+		return nil, errors.New("clique: invalid interval count")
+	}
+	if tau < 0 || tau >= 1 {
+		return nil, errors.New("clique: tau out of range")
+	}
+	dims := len(data.Values(0))
+	va := make([]value, data.Len())
+	min := make([]float64, dims)
+	max := make([]float64, dims)
+	for j := range min {
+		min[j], max[j] = math.Inf(1), math.Inf(-1)
+	}
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dims {
+			return nil, &cluster.Error{Pkg: "clique", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...), cluster: Noise}
+		for j, x := range vec {
+			min[j] = math.Min(min[j], x)
+			max[j] = math.Max(max[j], x)
+		}
+	}
+	cell := make([][]int, len(va))
+	for i, v := range va {
+		cell[i] = make([]int, dims)
+		for j, x := range v.point {
+			w := max[j] - min[j]
+			if w == 0 {
+				continue
+			}
+			c := int(float64(xi) * (x - min[j]) / w)
+			if c == xi {
+				c--
+			}
+			cell[i][j] = c
+		}
+	}
+	return &CLIQUE{xi: xi, tau: tau, values: va, cell: cell}, nil
+}
+
+// Cluster runs a clustering of the data using the CLIQUE algorithm.
+func (c *CLIQUE) Cluster() error {
+	min := c.tau * float64(len(c.values))
+	dims := len(c.values[0].point)
+
+	// Find the dense units of each single dimension.
+	var level []*unit
+	for j := 0; j < dims; j++ {
+		counts := make([][]int, c.xi)
+		for i, cl := range c.cell {
+			counts[cl[j]] = append(counts[cl[j]], i)
+		}
+		for k, m := range counts {
+			if float64(len(m)) > min {
+				level = append(level, &unit{dims: []int{j}, intervals: []int{k}, members: m})
+			}
+		}
+	}
+
+	var all [][]*unit
+	for len(level) != 0 {
+		all = append(all, level)
+		level = c.join(level, min)
+	}
+
+	c.cens = c.cens[:0]
+	for i := range c.values {
+		c.values[i].cluster = Noise
+	}
+	for d := len(all) - 1; d >= 0; d-- {
+		bySpace := make(map[string][]*unit)
+		var order []string
+		for _, u := range all[d] {
+			k := u.subspace()
+			if _, ok := bySpace[k]; !ok {
+				order = append(order, k)
+			}
+			bySpace[k] = append(bySpace[k], u)
+		}
+		for _, k := range order {
+			for _, comp := range connected(bySpace[k]) {
+				c.add(comp)
+			}
+		}
+	}
+
+	return nil
+}
+
+// join returns the dense units of one dimension higher than the units in level,
+// which must all share a dimensionality. Candidate units are formed by joining
+// pairs of units that share all but their last dimension, and are pruned if any of
+// their projections are not dense before their density is counted.
+func (c *CLIQUE) join(level []*unit, min float64) []*unit {
+	dense := make(map[string]bool, len(level))
+	for _, u := range level {
+		dense[key(u.dims)+key(u.intervals)] = true
+	}
+	var next []*unit
+	for i, a := range level {
+		n := len(a.dims)
+	outer:
+		for _, b := range level[i+1:] {
+			if a.dims[n-1] >= b.dims[n-1] {
+				continue
+			}
+			for k := 0; k < n-1; k++ {
+				if a.dims[k] != b.dims[k] || a.intervals[k] != b.intervals[k] {
+					continue outer
+				}
+			}
+			u := &unit{
+				dims:      append(append([]int(nil), a.dims...), b.dims[n-1]),
+				intervals: append(append([]int(nil), a.intervals...), b.intervals[n-1]),
+			}
+			// Prune candidates with a projection that is not dense.
+			d := make([]int, n)
+			iv := make([]int, n)
+			for skip := 0; skip < n-1; skip++ {
+				d, iv = d[:0], iv[:0]
+				for k := range u.dims {
+					if k != skip {
+						d = append(d, u.dims[k])
+						iv = append(iv, u.intervals[k])
+					}
+				}
+				if !dense[key(d)+key(iv)] {
+					continue outer
+				}
+			}
+			for _, m := range a.members {
+				if c.cell[m][b.dims[n-1]] == b.intervals[n-1] {
+					u.members = append(u.members, m)
+				}
+			}
+			if float64(len(u.members)) > min {
+				next = append(next, u)
+			}
+		}
+	}
+	return next
+}
+
+// connected returns the connected components of the dense units in us, which must
+// all lie in the same subspace. Units are connected if they share a face.
+func connected(us []*unit) [][]*unit {
+	idx := make(map[string]int, len(us))
+	for i, u := range us {
+		idx[key(u.intervals)] = i
+	}
+	seen := make([]bool, len(us))
+	var comps [][]*unit
+	for i := range us {
+		if seen[i] {
+			continue
+		}
+		seen[i] = true
+		comp := []*unit{us[i]}
+		for q := 0; q < len(comp); q++ {
+			iv := append([]int(nil), comp[q].intervals...)
+			for k := range iv {
+				for _, step := range []int{-1, 1} {
+					iv[k] += step
+					if j, ok := idx[key(iv)]; ok && !seen[j] {
+						seen[j] = true
+						comp = append(comp, us[j])
+					}
+					iv[k] -= step
+				}
+			}
+		}
+		comps = append(comps, comp)
+	}
+	return comps
+}
+
+// add adds a cluster formed from the union of the members of the units in comp.
+func (c *CLIQUE) add(comp []*unit) {
+	id := len(c.cens)
+	cen := center{
+		point: make(point, len(c.values[0].point)),
+		dims:  append([]int(nil), comp[0].dims...),
+	}
+	for _, u := range comp {
+		cen.indices = append(cen.indices, u.members...)
+	}
+	sort.Ints(cen.indices)
+	for _, i := range cen.indices {
+		v := &c.values[i]
+		if v.cluster == Noise {
+			v.cluster = id
+		}
+		for j, x := range v.point {
+			cen.point[j] += x
+		}
+	}
+	inv := 1 / float64(len(cen.indices))
+	for j := range cen.point {
+		cen.point[j] *= inv
+	}
+	c.cens = append(c.cens, cen)
+}
+
+// Dims returns the dimensions of the subspace of the ith cluster returned by
+// Centers.
+func (c *CLIQUE) Dims(i int) []int { return c.cens[i].dims }
+
+// Centers returns the clusters determined by a previous call to Cluster. The
+// location of each center is the mean of its members in the full space.
+func (c *CLIQUE) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(c.cens))
+	for i := range c.cens {
+		cs[i] = &c.cens[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the CLIQUE.
+func (c *CLIQUE) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(c.values))
+	for i := range c.values {
+		vs[i] = &c.values[i]
+	}
+	return vs
+}
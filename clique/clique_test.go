@@ -0,0 +1,84 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clique_test
+
+import (
+	"github.com/biogo/cluster/clique"
+
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+func (s *S) TestCLIQUE(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	// A cluster in dimensions 0 and 1 and a cluster in dimensions 2 and 3,
+	// with the remaining dimensions uniformly distributed.
+	var pts Points
+	for i := 0; i < 200; i++ {
+		p := make([]float64, 4)
+		for j := range p {
+			p[j] = rnd.Float64() * 10
+		}
+		if i%2 == 0 {
+			p[0], p[1] = 2+rnd.Float64()*0.5, 7+rnd.Float64()*0.5
+		} else {
+			p[2], p[3] = 5+rnd.Float64()*0.5, 5+rnd.Float64()*0.5
+		}
+		pts = append(pts, p)
+	}
+	cl, err := clique.New(pts, 10, 0.2)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(cl.Cluster(), check.Equals, nil)
+
+	var dims [][]int
+	for i, cen := range cl.Centers() {
+		if len(cl.Dims(i)) != 2 {
+			continue
+		}
+		dims = append(dims, cl.Dims(i))
+		// Uniformly placed values may fall in the dense units by chance.
+		parity := 0
+		if cl.Dims(i)[0] == 2 {
+			parity = 1
+		}
+		var n int
+		for _, j := range cen.Members() {
+			if j%2 == parity {
+				n++
+			}
+		}
+		c.Check(n, check.Equals, 100)
+		c.Check(len(cen.Members()) < 110, check.Equals, true)
+	}
+	c.Check(dims, check.DeepEquals, [][]int{{0, 1}, {2, 3}})
+	c.Check(len(cl.Dims(0)), check.Equals, 2)
+	for _, v := range cl.Values() {
+		c.Check(v.Cluster(), check.Not(check.Equals), clique.Noise)
+	}
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := clique.New(Points{}, 10, 0.1)
+	c.Check(err, check.ErrorMatches, "clique: no data")
+	_, err = clique.New(Points{{1}}, 0, 0.1)
+	c.Check(err, check.ErrorMatches, "clique: invalid interval count")
+	_, err = clique.New(Points{{1}}, 10, 1)
+	c.Check(err, check.ErrorMatches, "clique: tau out of range")
+	_, err = clique.New(Points{{1}, {1, 2}}, 10, 0.1)
+	c.Check(err, check.ErrorMatches, "clique: mismatched dimensions")
+}
@@ -0,0 +1,89 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import "math/bits"
+
+// Bits is a packed row-major matrix of binary presence/absence profiles, such as
+// gene presence/absence matrices, satisfying Interface. Feature j of element i is
+// held in bit j%64 of Data[i*Stride+j/64].
+type Bits struct {
+	Rows, Cols, Stride int
+	Data               []uint64
+}
+
+// NewBits returns a new Bits with rows elements of cols features, all absent.
+func NewBits(rows, cols int) *Bits {
+	stride := (cols + 63) / 64
+	return &Bits{Rows: rows, Cols: cols, Stride: stride, Data: make([]uint64, rows*stride)}
+}
+
+// Len returns the number of elements in the Bits.
+func (b *Bits) Len() int { return b.Rows }
+
+// Values returns the profile of element i as a newly allocated slice holding 1 for
+// present features and 0 for absent features.
+func (b *Bits) Values(i int) []float64 {
+	v := make([]float64, b.Cols)
+	for j, w := range b.Row(i) {
+		for ; w != 0; w &= w - 1 {
+			v[j*64+bits.TrailingZeros64(w)] = 1
+		}
+	}
+	return v
+}
+
+// Row returns the packed profile of element i. The returned slice shares the
+// backing store of the Bits.
+func (b *Bits) Row(i int) []uint64 {
+	off := i * b.Stride
+	return b.Data[off : off+b.Stride : off+b.Stride]
+}
+
+// At returns whether feature j is present in element i.
+func (b *Bits) At(i, j int) bool {
+	return b.Data[i*b.Stride+j/64]&(1<<uint(j%64)) != 0
+}
+
+// Set sets whether feature j is present in element i.
+func (b *Bits) Set(i, j int, present bool) {
+	if present {
+		b.Data[i*b.Stride+j/64] |= 1 << uint(j%64)
+	} else {
+		b.Data[i*b.Stride+j/64] &^= 1 << uint(j%64)
+	}
+}
+
+// Hamming returns the Hamming distance between elements i and j, calculated
+// directly from the packed profiles.
+func (b *Bits) Hamming(i, j int) float64 { return float64(HammingBits(b.Row(i), b.Row(j))) }
+
+// Jaccard returns the Jaccard distance between elements i and j, calculated
+// directly from the packed profiles.
+func (b *Bits) Jaccard(i, j int) float64 { return JaccardBits(b.Row(i), b.Row(j)) }
+
+// HammingBits returns the number of bits that differ between the packed profiles x
+// and y.
+func HammingBits(x, y []uint64) int {
+	var n int
+	for i, w := range x {
+		n += bits.OnesCount64(w ^ y[i])
+	}
+	return n
+}
+
+// JaccardBits returns the Jaccard distance between the packed profiles x and y. The
+// distance between two empty profiles is zero.
+func JaccardBits(x, y []uint64) float64 {
+	var and, or int
+	for i, w := range x {
+		and += bits.OnesCount64(w & y[i])
+		or += bits.OnesCount64(w | y[i])
+	}
+	if or == 0 {
+		return 0
+	}
+	return 1 - float64(and)/float64(or)
+}
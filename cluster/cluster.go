@@ -5,6 +5,10 @@
 // Package cluster provides interfaces and types for data clustering in ℝⁿ.
 package cluster
 
+import (
+	"context"
+)
+
 // Indices is a list of indexes into a array or slice of Values.
 type Indices []int
 
@@ -20,6 +24,76 @@ type Clusterer interface {
 	Values() []Value
 }
 
+// Labeler is a Clusterer that provides the cluster label of each of its values
+// directly.
+type Labeler interface {
+	Clusterer
+
+	// Assignments returns the index into the slice returned by Centers() of the
+	// cluster of each Value, in the order of the slice returned by Values().
+	Assignments() []int
+}
+
+// Assignments returns the cluster label of each of the values of c. If c is a
+// Labeler, its Assignments method is used, otherwise the labels are collected from
+// c.Values().
+func Assignments(c Clusterer) []int {
+	if l, ok := c.(Labeler); ok {
+		return l.Assignments()
+	}
+	vals := c.Values()
+	a := make([]int, len(vals))
+	for i, v := range vals {
+		a[i] = v.Cluster()
+	}
+	return a
+}
+
+// Predictor is a fitted model that can label data that were not clustered.
+type Predictor interface {
+	// Assign returns the index into the slice returned by Centers() of the
+	// cluster that x would be assigned to, or a negative value if x is not
+	// assigned to any cluster.
+	Assign(x []float64) int
+}
+
+// SoftClusterer is a Clusterer that assigns each value a graded membership of every
+// cluster, such as a mixture model or soft k-means. The cluster of a Value is its
+// cluster of greatest membership.
+type SoftClusterer interface {
+	Clusterer
+
+	// Memberships returns the membership of each Value in each Center. The ith
+	// row of the returned matrix corresponds to the ith element of the slice
+	// returned by Values() and holds the memberships in the order of the slice
+	// returned by Centers(). Memberships are non-negative and sum to at most
+	// one for each Value.
+	Memberships() [][]float64
+}
+
+// ContextClusterer is a Clusterer whose clustering can be cancelled.
+type ContextClusterer interface {
+	Clusterer
+
+	// ClusterContext clusters the data as for Cluster, checking ctx between
+	// iterations. If ctx is done before the clustering is complete,
+	// ClusterContext stops and returns ctx.Err().
+	ClusterContext(ctx context.Context) error
+}
+
+// ClusterContext clusters the data of c. If c is a ContextClusterer, its
+// ClusterContext method is used, otherwise ctx is checked once before Cluster is
+// called.
+func ClusterContext(ctx context.Context, c Clusterer) error {
+	if cc, ok := c.(ContextClusterer); ok {
+		return cc.ClusterContext(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.Cluster()
+}
+
 // Interface is a type that can be clustered by a Clusterer.
 type Interface interface {
 	Len() int               // Return the length of the data vector.
@@ -54,3 +128,12 @@ type Center interface {
 	// refers to the Values associated with the Center.
 	Members() Indices
 }
+
+// Stream is a source of weighted data values that are read incrementally rather
+// than being held in memory. NewIterator presents an Interface as a Stream and
+// Collect gathers a Stream into an Interface.
+type Stream interface {
+	// Next returns the next value in the stream and its weight. The returned
+	// slice may be retained by the caller. If the stream is exhausted ok is false.
+	Next() (v []float64, w float64, ok bool)
+}
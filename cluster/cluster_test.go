@@ -0,0 +1,429 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	cluster int
+}
+
+func (v value) Cluster() int { return v.cluster }
+
+type center struct {
+	point
+	members cluster.Indices
+}
+
+func (c center) Members() cluster.Indices { return c.members }
+
+// partition is a fixed clustering result.
+type partition struct {
+	values  []cluster.Value
+	centers []cluster.Center
+}
+
+func newPartition(pts [][]float64, labels []int, k int) partition {
+	var p partition
+	cens := make([]center, k)
+	for i, v := range pts {
+		p.values = append(p.values, value{point: v, cluster: labels[i]})
+		cens[labels[i]].members = append(cens[labels[i]].members, i)
+	}
+	for _, c := range cens {
+		p.centers = append(p.centers, c)
+	}
+	return p
+}
+
+func (p partition) Cluster() error            { return nil }
+func (p partition) Centers() []cluster.Center { return p.centers }
+func (p partition) Values() []cluster.Value   { return p.values }
+
+var pts = [][]float64{
+	{0, 0}, {2, 0}, {1, 1}, {2, 2}, {0, 2}, {1, 0}, {1, 1},
+	{10, 10}, {12, 12}, {11, 11},
+	{20, 5},
+}
+
+var labels = []int{0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 2}
+
+func (s *S) TestBoundingBoxes(c *check.C) {
+	p := newPartition(pts, labels, 4)
+	c.Check(cluster.BoundingBoxes(p), check.DeepEquals, []cluster.Box{
+		{Min: []float64{0, 0}, Max: []float64{2, 2}},
+		{Min: []float64{10, 10}, Max: []float64{12, 12}},
+		{Min: []float64{20, 5}, Max: []float64{20, 5}},
+		{},
+	})
+}
+
+func (s *S) TestConvexHulls(c *check.C) {
+	p := newPartition(pts, labels, 4)
+	h, err := cluster.ConvexHulls(p)
+	c.Assert(err, check.Equals, nil)
+	c.Check(h, check.DeepEquals, [][][2]float64{
+		{{0, 0}, {2, 0}, {2, 2}, {0, 2}},
+		{{10, 10}, {12, 12}},
+		{{20, 5}},
+		nil,
+	})
+
+	_, err = cluster.ConvexHulls(newPartition([][]float64{{1, 2, 3}}, []int{0}, 1))
+	c.Check(err, check.ErrorMatches, "cluster: mismatched dimensions")
+}
+
+func (s *S) TestMetrics(c *check.C) {
+	x, y := []float64{1, 2, 3}, []float64{4, -2, 3}
+	for _, t := range []struct {
+		m    cluster.Metric
+		want float64
+	}{
+		{m: cluster.Euclidean, want: 5},
+		{m: cluster.SquaredEuclidean, want: 25},
+		{m: cluster.Manhattan, want: 7},
+		{m: cluster.Chebyshev, want: 4},
+		{m: cluster.Cosine, want: 1 - 9/(math.Sqrt(14)*math.Sqrt(29))},
+	} {
+		c.Check(math.Abs(t.m.Distance(x, y)-t.want) < 1e-12, check.Equals, true)
+		c.Check(t.m.Distance(x, x) < 1e-12, check.Equals, true)
+	}
+	c.Check(cluster.Cosine.Distance([]float64{0, 0}, []float64{1, 1}), check.Equals, 1.)
+	c.Check(cluster.Cosine.Distance([]float64{1, 1}, []float64{-2, -2}), check.Equals, 2.)
+}
+
+func (s *S) TestBinaryMetrics(c *check.C) {
+	x, y := []float64{1, 0, 1, 1, 0}, []float64{1, 1, 0, 1, 0}
+	c.Check(cluster.Hamming.Distance(x, y), check.Equals, 2.)
+	c.Check(cluster.Jaccard.Distance(x, y), check.Equals, 0.5)
+	c.Check(cluster.Jaccard.Distance([]float64{0, 0}, []float64{0, 0}), check.Equals, 0.)
+
+	b := cluster.NewBits(3, 70)
+	c.Check(b.Stride, check.Equals, 2)
+	for _, j := range []int{0, 2, 3, 65, 69} {
+		b.Set(0, j, true)
+	}
+	for _, j := range []int{0, 1, 3, 65} {
+		b.Set(1, j, true)
+	}
+	b.Set(1, 68, true)
+	b.Set(1, 68, false)
+	c.Check(b.At(0, 69), check.Equals, true)
+	c.Check(b.At(1, 68), check.Equals, false)
+	c.Check(b.Len(), check.Equals, 3)
+
+	v := b.Values(0)
+	c.Assert(v, check.HasLen, 70)
+	for j, x := range v {
+		c.Check(x == 1, check.Equals, b.At(0, j))
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			c.Check(b.Hamming(i, j), check.Equals, cluster.Hamming.Distance(b.Values(i), b.Values(j)))
+			c.Check(b.Jaccard(i, j), check.Equals, cluster.Jaccard.Distance(b.Values(i), b.Values(j)))
+		}
+	}
+	c.Check(b.Hamming(0, 1), check.Equals, 3.)
+	c.Check(b.Jaccard(0, 1), check.Equals, 0.5)
+	c.Check(b.Jaccard(2, 2), check.Equals, 0.)
+}
+
+func (s *S) TestCorrelation(c *check.C) {
+	x := []float64{1, 2, 3, 4, 5}
+	for _, t := range []struct {
+		m    cluster.Metric
+		y    []float64
+		want float64
+	}{
+		{m: cluster.Pearson, y: []float64{10, 20, 30, 40, 50}, want: 0},
+		{m: cluster.Pearson, y: []float64{5, 4, 3, 2, 1}, want: 2},
+		{m: cluster.Pearson, y: []float64{2, 2, 2, 2, 2}, want: 1},
+		{m: cluster.Pearson, y: []float64{1, 3, 2, 5, 4}, want: 0.2},
+		{m: cluster.Spearman, y: []float64{1, 4, 9, 16, 25}, want: 0},
+		{m: cluster.Spearman, y: []float64{25, 16, 9, 4, 1}, want: 2},
+		{m: cluster.Spearman, y: []float64{3, 3, 3, 3, 3}, want: 1},
+		{m: cluster.Spearman, y: []float64{1, 3, 2, 5, 4}, want: 0.2},
+		{m: cluster.Spearman, y: []float64{1, 2, 2, 4, 5}, want: 1 - 9.5/math.Sqrt(10*9.5)},
+	} {
+		got := t.m.Distance(x, t.y)
+		c.Check(math.Abs(got-t.want) < 1e-12, check.Equals, true, check.Commentf("got %v want %v", got, t.want))
+	}
+	c.Check(cluster.Pearson.Distance([]float64{1, 1}, []float64{1, 1}), check.Equals, 1.)
+}
+
+func (s *S) TestDistanceMatrix(c *check.C) {
+	m := cluster.NewDistanceMatrix(4)
+	c.Check(m.Len(), check.Equals, 4)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < i; j++ {
+			m.SetDistance(j, i, float64(10*i+j))
+		}
+	}
+	for i := 0; i < 4; i++ {
+		c.Check(m.Distance(i, i), check.Equals, 0.)
+		for j := 0; j < i; j++ {
+			c.Check(m.Distance(i, j), check.Equals, float64(10*i+j))
+			c.Check(m.Distance(j, i), check.Equals, float64(10*i+j))
+		}
+	}
+	c.Check(func() { m.SetDistance(1, 1, 1) }, check.Panics, "cluster: cannot set self distance")
+	var _ cluster.DistanceMatrixer = m
+}
+
+func (s *S) TestComplete(c *check.C) {
+	nan := math.NaN()
+	data := &cluster.Dense{
+		Rows: 4, Cols: 2, Stride: 2,
+		Data:    []float64{1, nan, 2, 4, 6, 8, nan, 6},
+		Weights: []float64{1, 1, 2, 1},
+	}
+
+	_, err := cluster.Complete(data, cluster.RejectNaN)
+	c.Check(err, check.ErrorMatches, "cluster: NaN value")
+
+	d, err := cluster.Complete(data, cluster.ImputeMean)
+	c.Assert(err, check.Equals, nil)
+	c.Check(d.Data, check.DeepEquals, []float64{1, 6.5, 2, 4, 6, 8, 3.75, 6})
+	c.Check(d.Weights, check.DeepEquals, data.Weights)
+	c.Check(math.IsNaN(data.Data[1]), check.Equals, true)
+
+	d, err = cluster.Complete(data, cluster.ImputeMedian)
+	c.Assert(err, check.Equals, nil)
+	c.Check(d.Data, check.DeepEquals, []float64{1, 7, 2, 4, 6, 8, 4, 6})
+
+	d, err = cluster.Complete(cluster.NewDense(2, 2), cluster.RejectNaN)
+	c.Assert(err, check.Equals, nil)
+	c.Check(d.Data, check.DeepEquals, []float64{0, 0, 0, 0})
+
+	_, err = cluster.Complete(&cluster.Dense{Rows: 2, Cols: 1, Stride: 1, Data: []float64{nan, nan}}, cluster.ImputeMean)
+	c.Check(err, check.ErrorMatches, "cluster: no values present in dimension")
+
+	m := cluster.PairwiseComplete(cluster.Euclidean)
+	c.Check(m.Distance([]float64{1, nan, 3}, []float64{4, 5, 7}), check.Equals, 5.)
+	c.Check(m.Distance([]float64{1, 2}, []float64{4, 6}), check.Equals, 5.)
+	c.Check(math.IsInf(m.Distance([]float64{nan, 1}, []float64{1, nan}), 1), check.Equals, true)
+}
+
+// labeled is a partition that satisfies Labeler.
+type labeled struct {
+	partition
+	labels []int
+}
+
+func (p labeled) Assignments() []int { return p.labels }
+
+func (s *S) TestAssignments(c *check.C) {
+	p := newPartition(pts, labels, 3)
+	c.Check(cluster.Assignments(p), check.DeepEquals, labels)
+	l := labeled{partition: p, labels: []int{2, 1, 0}}
+	c.Check(cluster.Assignments(l), check.DeepEquals, l.labels)
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := cluster.Complete(&cluster.Dense{Rows: 2, Cols: 1, Stride: 1, Data: []float64{0, 1}}, cluster.RejectNaN)
+	c.Check(err, check.Equals, nil)
+	_, err = cluster.Complete(cluster.NewDense(0, 2), cluster.ImputeMean)
+	c.Check(err, check.ErrorMatches, "cluster: no data")
+	c.Check(errors.Is(err, cluster.ErrEmptyData), check.Equals, true)
+	_, err = cluster.Complete(jagged{{1, 2}, {1}}, cluster.RejectNaN)
+	c.Check(err, check.ErrorMatches, "cluster: mismatched dimensions")
+	c.Check(errors.Is(err, cluster.ErrDimensionMismatch), check.Equals, true)
+	c.Check(errors.Is(err, cluster.ErrEmptyData), check.Equals, false)
+	var e *cluster.Error
+	c.Assert(errors.As(err, &e), check.Equals, true)
+	c.Check(e.Pkg, check.Equals, "cluster")
+}
+
+type jagged [][]float64
+
+func (j jagged) Len() int               { return len(j) }
+func (j jagged) Values(i int) []float64 { return j[i] }
+
+// counted is a partition that counts calls to Cluster.
+type counted struct {
+	partition
+	calls *int
+}
+
+func (p counted) Cluster() error { *p.calls++; return nil }
+
+// cancellable is a counted partition that satisfies ContextClusterer.
+type cancellable struct {
+	counted
+}
+
+func (p cancellable) ClusterContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return p.Cluster()
+}
+
+func (s *S) TestClusterContext(c *check.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, cl := range []func(*int) cluster.Clusterer{
+		func(n *int) cluster.Clusterer { return counted{partition: newPartition(pts, labels, 3), calls: n} },
+		func(n *int) cluster.Clusterer {
+			return cancellable{counted{partition: newPartition(pts, labels, 3), calls: n}}
+		},
+	} {
+		var n int
+		c.Check(cluster.ClusterContext(context.Background(), cl(&n)), check.Equals, nil)
+		c.Check(n, check.Equals, 1)
+		c.Check(cluster.ClusterContext(ctx, cl(&n)), check.Equals, context.Canceled)
+		c.Check(n, check.Equals, 1)
+	}
+}
+
+// celsius is a named float32 type for testing Data constraints.
+type celsius float32
+
+func (s *S) TestDenseOf(c *check.C) {
+	d := cluster.NewDenseOf[celsius](3, 2)
+	for i := range d.Data {
+		d.Data[i] = celsius(i) + 0.25
+	}
+	var _ cluster.Data[celsius] = d
+	c.Check(d.Len(), check.Equals, 3)
+	c.Check(d.Values(1), check.DeepEquals, []celsius{2.25, 3.25})
+	c.Check(d.Weight(2), check.Equals, 1.)
+
+	a := cluster.Adapt[celsius](d)
+	c.Check(a.Len(), check.Equals, 3)
+	c.Check(a.Values(2), check.DeepEquals, []float64{4.25, 5.25})
+	w, ok := a.(cluster.Weighter)
+	c.Assert(ok, check.Equals, true)
+	d.Weights = []float64{1, 2, 3}
+	c.Check(w.Weight(1), check.Equals, 2.)
+
+	dense := cluster.NewDense(2, 2)
+	c.Check(cluster.Adapt[float64](dense), check.Equals, cluster.Interface(dense))
+	_, ok = cluster.Adapt[float32](unweighted32{{1, 2}}).(cluster.Weighter)
+	c.Check(ok, check.Equals, false)
+}
+
+type unweighted32 [][]float32
+
+func (u unweighted32) Len() int               { return len(u) }
+func (u unweighted32) Values(i int) []float32 { return u[i] }
+
+func (s *S) TestSparse(c *check.C) {
+	dense := cluster.NewDense(4, 5)
+	copy(dense.Data, []float64{
+		0, 1, 0, 0, 2,
+		0, 0, 0, 0, 0,
+		3, 0, 0, 0, 1,
+		0, 1, 4, 0, 0,
+	})
+	dense.Weights = []float64{1, 2, 3, 4}
+	m, err := cluster.NewCSR(dense)
+	c.Assert(err, check.Equals, nil)
+	c.Check(m.Len(), check.Equals, 4)
+	c.Check(m.Dims(), check.Equals, 5)
+	c.Check(m.Weight(2), check.Equals, 3.)
+	idx, val := m.Row(0)
+	c.Check(idx, check.DeepEquals, []int{1, 4})
+	c.Check(val, check.DeepEquals, []float64{1, 2})
+	idx, val = m.Row(1)
+	c.Check(idx, check.HasLen, 0)
+	c.Check(val, check.HasLen, 0)
+	c.Check(cluster.CheckSparse(m), check.Equals, nil)
+
+	for _, t := range []struct {
+		sparse cluster.SparseMetric
+		dense  cluster.Metric
+	}{
+		{cluster.SparseEuclidean, cluster.Euclidean},
+		{cluster.SparseSquaredEuclidean, cluster.SquaredEuclidean},
+		{cluster.SparseManhattan, cluster.Manhattan},
+		{cluster.SparseCosine, cluster.Cosine},
+		{cluster.SparseJaccard, cluster.Jaccard},
+	} {
+		dm := cluster.SparseDistances(m, t.sparse)
+		c.Check(dm.Len(), check.Equals, 4)
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				c.Check(math.Abs(dm.Distance(i, j)-t.dense.Distance(dense.Values(i), dense.Values(j))) < 1e-12, check.Equals, true)
+			}
+		}
+		w, ok := dm.(cluster.Weighter)
+		c.Assert(ok, check.Equals, true)
+		c.Check(w.Weight(3), check.Equals, 4.)
+	}
+
+	m.Indices[1] = 0
+	c.Check(cluster.CheckSparse(m), check.ErrorMatches, "cluster: invalid sparse data")
+	m.Indices[1] = 5
+	c.Check(errors.Is(cluster.CheckSparse(m), cluster.ErrInvalidSparse), check.Equals, true)
+	_, err = cluster.NewCSR(jagged{{1, 2}, {1}})
+	c.Check(errors.Is(err, cluster.ErrDimensionMismatch), check.Equals, true)
+}
+
+// failing is a Stream that reports an error after its values are read.
+type failing struct {
+	vals [][]float64
+	err  error
+}
+
+func (f *failing) Next() ([]float64, float64, bool) {
+	if len(f.vals) == 0 {
+		return nil, 0, false
+	}
+	v := f.vals[0]
+	f.vals = f.vals[1:]
+	return v, 1, true
+}
+
+func (f *failing) Err() error { return f.err }
+
+func (s *S) TestStream(c *check.C) {
+	d := cluster.NewDense(3, 2)
+	copy(d.Data, []float64{1, 2, 3, 4, 5, 6})
+	d.Weights = []float64{1, 0.5, 2}
+
+	it := cluster.NewIterator(d)
+	for pass := 0; pass < 2; pass++ {
+		got, err := cluster.Collect(it)
+		c.Assert(err, check.Equals, nil)
+		c.Check(got, check.DeepEquals, d)
+		_, _, ok := it.Next()
+		c.Check(ok, check.Equals, false)
+		it.Reset()
+	}
+
+	it = cluster.NewIterator(jagged{{1, 2}, {3, 4}})
+	v, w, ok := it.Next()
+	c.Check(v, check.DeepEquals, []float64{1, 2})
+	c.Check(w, check.Equals, 1.)
+	c.Check(ok, check.Equals, true)
+
+	_, err := cluster.Collect(cluster.NewIterator(jagged{{1, 2}, {1}}))
+	c.Check(errors.Is(err, cluster.ErrDimensionMismatch), check.Equals, true)
+	_, err = cluster.Collect(cluster.NewIterator(jagged{}))
+	c.Check(errors.Is(err, cluster.ErrEmptyData), check.Equals, true)
+	_, err = cluster.Collect(&failing{vals: [][]float64{{1}}, err: errors.New("read failed")})
+	c.Check(err, check.ErrorMatches, "read failed")
+}
@@ -0,0 +1,40 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+// Dense is a packed row-major matrix of data values satisfying Interface and
+// Weighter. Element i of a Dense is held in Data[i*Stride : i*Stride+Cols].
+type Dense struct {
+	Rows, Cols, Stride int
+	Data               []float64
+
+	// Weights holds the weights of the elements. If Weights
+	// is nil, all elements have a weight of 1.
+	Weights []float64
+}
+
+// NewDense returns a new Dense with rows elements of cols dimensions and no
+// padding between elements.
+func NewDense(rows, cols int) *Dense {
+	return &Dense{Rows: rows, Cols: cols, Stride: cols, Data: make([]float64, rows*cols)}
+}
+
+// Len returns the number of elements in the Dense.
+func (d *Dense) Len() int { return d.Rows }
+
+// Values returns the data values for element i. The returned slice shares the
+// backing store of the Dense.
+func (d *Dense) Values(i int) []float64 {
+	off := i * d.Stride
+	return d.Data[off : off+d.Cols : off+d.Cols]
+}
+
+// Weight returns the weight for element i.
+func (d *Dense) Weight(i int) float64 {
+	if d.Weights == nil {
+		return 1
+	}
+	return d.Weights[i]
+}
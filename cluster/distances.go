@@ -0,0 +1,54 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+// DistanceMatrixer is a set of elements with available pairwise distances, such as
+// precomputed alignment distances, for clustering without coordinates. Distance
+// must be symmetric and the distance from an element to itself must be zero. A
+// DistanceMatrixer may also satisfy Weighter.
+type DistanceMatrixer interface {
+	Len() int                  // Return the number of elements.
+	Distance(i, j int) float64 // Return the distance between elements i and j.
+}
+
+// DistanceMatrix is a symmetric matrix of precomputed pairwise distances satisfying
+// DistanceMatrixer. Only the strict lower triangle is stored.
+type DistanceMatrix struct {
+	n int
+	d []float64
+}
+
+// NewDistanceMatrix returns a new DistanceMatrix for n elements with all distances
+// zero.
+func NewDistanceMatrix(n int) *DistanceMatrix {
+	return &DistanceMatrix{n: n, d: make([]float64, n*(n-1)/2)}
+}
+
+// Len returns the number of elements in the DistanceMatrix.
+func (m *DistanceMatrix) Len() int { return m.n }
+
+// Distance returns the distance between elements i and j.
+func (m *DistanceMatrix) Distance(i, j int) float64 {
+	if i == j {
+		return 0
+	}
+	return m.d[m.index(i, j)]
+}
+
+// SetDistance sets the distance between elements i and j to d. It panics if i and
+// j are equal.
+func (m *DistanceMatrix) SetDistance(i, j int, d float64) {
+	if i == j {
+		panic("cluster: cannot set self distance")
+	}
+	m.d[m.index(i, j)] = d
+}
+
+func (m *DistanceMatrix) index(i, j int) int {
+	if i < j {
+		i, j = j, i
+	}
+	return i*(i-1)/2 + j
+}
@@ -0,0 +1,43 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import "errors"
+
+// Failure modes shared by clusterers. Errors reporting these conditions are
+// returned wrapped in an Error that identifies the reporting package, so callers
+// should test for them using errors.Is.
+var (
+	// ErrEmptyData indicates that the data to be clustered had no elements.
+	ErrEmptyData = errors.New("no data")
+
+	// ErrDimensionMismatch indicates that elements of the data, or points
+	// provided with it, had differing dimensions.
+	ErrDimensionMismatch = errors.New("mismatched dimensions")
+
+	// ErrNoCenters indicates that an operation required cluster centers
+	// but none were available.
+	ErrNoCenters = errors.New("no centers")
+
+	// ErrMaxIterations indicates that an iterative clustering did not
+	// converge within its maximum number of iterations.
+	ErrMaxIterations = errors.New("exceeded maximum iterations")
+
+	// ErrInvalidSparse indicates that the indices of sparse data were out
+	// of range, out of order or did not match the number of values.
+	ErrInvalidSparse = errors.New("invalid sparse data")
+)
+
+// Error is an error reported by the package Pkg. Its message is the package name
+// followed by the message of Err.
+type Error struct {
+	Pkg string // Pkg is the name of the package reporting the error.
+	Err error  // Err is the underlying error.
+}
+
+func (e *Error) Error() string { return e.Pkg + ": " + e.Err.Error() }
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error { return e.Err }
@@ -0,0 +1,89 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+// Float is the constraint satisfied by the coordinate types of Data.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Data is a collection of elements in ℝⁿ with coordinates of type F. Data[float64]
+// has the same method set as Interface, so every Interface is a Data[float64].
+// Data may be differentially weighted by also satisfying Weighter.
+type Data[F Float] interface {
+	Len() int         // Return the length of the data vector.
+	Values(i int) []F // Return the data values for element i.
+}
+
+// DenseOf is a packed row-major matrix of data values satisfying Data[F] and
+// Weighter. Element i of a DenseOf is held in Data[i*Stride : i*Stride+Cols].
+// DenseOf[float32] holds data in half the memory required by Dense.
+type DenseOf[F Float] struct {
+	Rows, Cols, Stride int
+	Data               []F
+
+	// Weights holds the weights of the elements. If Weights
+	// is nil, all elements have a weight of 1.
+	Weights []float64
+}
+
+// NewDenseOf returns a new DenseOf with rows elements of cols dimensions and no
+// padding between elements.
+func NewDenseOf[F Float](rows, cols int) *DenseOf[F] {
+	return &DenseOf[F]{Rows: rows, Cols: cols, Stride: cols, Data: make([]F, rows*cols)}
+}
+
+// Len returns the number of elements in the DenseOf.
+func (d *DenseOf[F]) Len() int { return d.Rows }
+
+// Values returns the data values for element i. The returned slice shares the
+// backing store of the DenseOf.
+func (d *DenseOf[F]) Values(i int) []F {
+	off := i * d.Stride
+	return d.Data[off : off+d.Cols : off+d.Cols]
+}
+
+// Weight returns the weight for element i.
+func (d *DenseOf[F]) Weight(i int) float64 {
+	if d.Weights == nil {
+		return 1
+	}
+	return d.Weights[i]
+}
+
+// Adapt returns an Interface holding the elements of d for use with clusterers
+// that do not accept Data. If d is a Data[float64], it is returned unaltered.
+// Otherwise each call to Values of the returned Interface allocates a new slice
+// holding the converted coordinates. The returned Interface is a Weighter if d
+// is a Weighter.
+func Adapt[F Float](d Data[F]) Interface {
+	if i, ok := d.(Interface); ok {
+		return i
+	}
+	a := adapted[F]{d}
+	if w, ok := d.(Weighter); ok {
+		return weightedAdapted[F]{a, w}
+	}
+	return a
+}
+
+type adapted[F Float] struct {
+	data Data[F]
+}
+
+func (a adapted[F]) Len() int { return a.data.Len() }
+func (a adapted[F]) Values(i int) []float64 {
+	v := a.data.Values(i)
+	p := make([]float64, len(v))
+	for j, x := range v {
+		p[j] = float64(x)
+	}
+	return p
+}
+
+type weightedAdapted[F Float] struct {
+	adapted[F]
+	Weighter
+}
@@ -0,0 +1,117 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"sort"
+)
+
+// Box is an axis-aligned bounding box in ℝⁿ.
+type Box struct {
+	Min, Max []float64
+}
+
+// BoundingBoxes returns the bounding box of the members of each cluster found by c.
+// The ith Box corresponds to the ith element of c.Centers(). The Box of a cluster
+// with no members has nil Min and Max.
+func BoundingBoxes(c Clusterer) []Box {
+	vals := c.Values()
+	cens := c.Centers()
+	boxes := make([]Box, len(cens))
+	for i, cen := range cens {
+		b := &boxes[i]
+		for _, j := range cen.Members() {
+			v := vals[j].V()
+			if b.Min == nil {
+				b.Min = append([]float64(nil), v...)
+				b.Max = append([]float64(nil), v...)
+				continue
+			}
+			for k, x := range v {
+				if x < b.Min[k] {
+					b.Min[k] = x
+				}
+				if x > b.Max[k] {
+					b.Max[k] = x
+				}
+			}
+		}
+	}
+	return boxes
+}
+
+// ConvexHulls returns the convex hull of the members of each cluster found by c. The
+// ith hull corresponds to the ith element of c.Centers(). Hull vertices are given in
+// counter-clockwise order starting from the vertex with the lowest x, then y, value
+// and collinear points are omitted. Clusters with fewer than three distinct
+// non-collinear members have degenerate hulls holding only the extreme points.
+// ConvexHulls returns an error wrapping ErrDimensionMismatch if the values of c are
+// not two-dimensional.
+func ConvexHulls(c Clusterer) ([][][2]float64, error) {
+	vals := c.Values()
+	cens := c.Centers()
+	hulls := make([][][2]float64, len(cens))
+	for i, cen := range cens {
+		m := cen.Members()
+		p := make([][2]float64, len(m))
+		for k, j := range m {
+			v := vals[j].V()
+			if len(v) != 2 {
+				return nil, &Error{Pkg: "cluster", Err: ErrDimensionMismatch}
+			}
+			p[k] = [2]float64{v[0], v[1]}
+		}
+		hulls[i] = hull(p)
+	}
+	return hulls, nil
+}
+
+// hull returns the convex hull of p using Andrew's monotone chain algorithm.
+// The order of elements in p is altered.
+func hull(p [][2]float64) [][2]float64 {
+	sort.Sort(byXY(p))
+	u := p[:0]
+	for i, v := range p {
+		if i == 0 || v != p[i-1] {
+			u = append(u, v)
+		}
+	}
+	p = u
+	if len(p) < 3 {
+		return append([][2]float64(nil), p...)
+	}
+
+	h := make([][2]float64, 0, 2*len(p))
+	for _, v := range p {
+		for len(h) >= 2 && cross(h[len(h)-2], h[len(h)-1], v) <= 0 {
+			h = h[:len(h)-1]
+		}
+		h = append(h, v)
+	}
+	for i, lo := len(p)-2, len(h)+1; i >= 0; i-- {
+		v := p[i]
+		for len(h) >= lo && cross(h[len(h)-2], h[len(h)-1], v) <= 0 {
+			h = h[:len(h)-1]
+		}
+		h = append(h, v)
+	}
+	return h[:len(h)-1]
+}
+
+// cross returns the z component of the cross product of the vectors oa and ob.
+func cross(o, a, b [2]float64) float64 {
+	return (a[0]-o[0])*(b[1]-o[1]) - (a[1]-o[1])*(b[0]-o[0])
+}
+
+type byXY [][2]float64
+
+func (p byXY) Len() int      { return len(p) }
+func (p byXY) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p byXY) Less(i, j int) bool {
+	if p[i][0] != p[j][0] {
+		return p[i][0] < p[j][0]
+	}
+	return p[i][1] < p[j][1]
+}
@@ -0,0 +1,79 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"runtime/debug"
+)
+
+// Manifest records the information needed to reproduce a clustering.
+type Manifest struct {
+	Algorithm  string                 // Algorithm is the name of the clustering algorithm.
+	Parameters map[string]interface{} // Parameters holds the algorithm parameters.
+
+	// Seed is the seed of the random source used by the clustering,
+	// or nil if the global math/rand source was used.
+	Seed *int64
+
+	Data       Fingerprint // Data identifies the clustered data.
+	Iterations int         // Iterations is the number of iterations performed.
+	Version    string      // Version is the version of this module.
+}
+
+// Fingerprint identifies a data set.
+type Fingerprint struct {
+	Hash string // Hash is the hex encoded SHA-256 digest of the values and weights.
+	N    int    // N is the number of elements in the data.
+	Dims int    // Dims is the dimensionality of the data.
+}
+
+// FingerprintOf returns the Fingerprint of data. The hash covers the values of all
+// elements in order and the weights of elements if data is a Weighter.
+func FingerprintOf(data Interface) Fingerprint {
+	h := sha256.New()
+	var buf [8]byte
+	write := func(v float64) {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		h.Write(buf[:])
+	}
+	var dims int
+	if data.Len() != 0 {
+		dims = len(data.Values(0))
+	}
+	w, isWeighter := data.(Weighter)
+	for i := 0; i < data.Len(); i++ {
+		for _, v := range data.Values(i) {
+			write(v)
+		}
+		if isWeighter {
+			write(w.Weight(i))
+		}
+	}
+	return Fingerprint{Hash: hex.EncodeToString(h.Sum(nil)), N: data.Len(), Dims: dims}
+}
+
+// ModuleVersion returns the version of the github.com/biogo/cluster module linked
+// into the running binary, or "(devel)" if it cannot be determined.
+func ModuleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	if info.Main.Path == modulePath && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	for _, m := range info.Deps {
+		if m.Path == modulePath {
+			return m.Version
+		}
+	}
+	return "(devel)"
+}
+
+const modulePath = "github.com/biogo/cluster"
@@ -0,0 +1,190 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"math"
+	"sort"
+)
+
+// Metric is a distance function between two points.
+type Metric interface {
+	Distance(x, y []float64) float64 // Return the distance between x and y.
+}
+
+// MetricFunc is an adapter to allow the use of an ordinary function as a Metric.
+type MetricFunc func(x, y []float64) float64
+
+// Distance returns f(x, y).
+func (f MetricFunc) Distance(x, y []float64) float64 { return f(x, y) }
+
+// Standard metrics.
+var (
+	// Euclidean is the Euclidean, L₂, distance.
+	Euclidean Metric = MetricFunc(euclidean)
+
+	// SquaredEuclidean is the squared Euclidean distance. It does not satisfy
+	// the triangle inequality and so is not suitable for use with spatial
+	// indexes that prune their search using it.
+	SquaredEuclidean Metric = MetricFunc(squaredEuclidean)
+
+	// Manhattan is the Manhattan, L₁, distance.
+	Manhattan Metric = MetricFunc(manhattan)
+
+	// Chebyshev is the Chebyshev, L∞, distance.
+	Chebyshev Metric = MetricFunc(chebyshev)
+
+	// Cosine is the cosine dissimilarity, 1-cos θ, where θ is the angle between
+	// x and y. The dissimilarity involving a zero vector is one. Cosine does not
+	// satisfy the triangle inequality and so is not suitable for use with
+	// spatial indexes that prune their search using it.
+	Cosine Metric = MetricFunc(cosine)
+
+	// Hamming is the number of dimensions in which x and y differ.
+	Hamming Metric = MetricFunc(hamming)
+
+	// Jaccard is the Jaccard, or Tanimoto, distance between presence/absence
+	// profiles, 1-|x∩y|/|x∪y|, where a feature is present in a profile if its
+	// value is not zero. The distance between two empty profiles is zero.
+	Jaccard Metric = MetricFunc(jaccard)
+
+	// Pearson is the Pearson correlation distance, 1-r, where r is the Pearson
+	// correlation coefficient of x and y. Profiles with the same shape have a
+	// distance of zero whatever their magnitude, and anticorrelated profiles
+	// have a distance of two. The correlation with a constant profile is
+	// undefined and the distance is one, as for uncorrelated profiles. Pearson
+	// does not satisfy the triangle inequality.
+	Pearson Metric = MetricFunc(pearson)
+
+	// Spearman is the Spearman rank correlation distance, 1-ρ, where ρ is the
+	// Pearson correlation of the ranks of x and y, with tied values given their
+	// mean rank. Constant profiles are handled as for Pearson. Spearman does
+	// not satisfy the triangle inequality.
+	Spearman Metric = MetricFunc(spearman)
+)
+
+func euclidean(x, y []float64) float64 { return math.Sqrt(squaredEuclidean(x, y)) }
+
+func squaredEuclidean(x, y []float64) float64 {
+	var sum float64
+	for i, v := range x {
+		d := v - y[i]
+		sum += d * d
+	}
+	return sum
+}
+
+func manhattan(x, y []float64) float64 {
+	var sum float64
+	for i, v := range x {
+		sum += math.Abs(v - y[i])
+	}
+	return sum
+}
+
+func chebyshev(x, y []float64) float64 {
+	var max float64
+	for i, v := range x {
+		max = math.Max(max, math.Abs(v-y[i]))
+	}
+	return max
+}
+
+func cosine(x, y []float64) float64 {
+	var dot, xx, yy float64
+	for i, v := range x {
+		dot += v * y[i]
+		xx += v * v
+		yy += y[i] * y[i]
+	}
+	if xx == 0 || yy == 0 {
+		return 1
+	}
+	return 1 - dot/math.Sqrt(xx*yy)
+}
+
+func hamming(x, y []float64) float64 {
+	var n int
+	for i, v := range x {
+		if v != y[i] {
+			n++
+		}
+	}
+	return float64(n)
+}
+
+func jaccard(x, y []float64) float64 {
+	var and, or int
+	for i, v := range x {
+		a, b := v != 0, y[i] != 0
+		if a && b {
+			and++
+		}
+		if a || b {
+			or++
+		}
+	}
+	if or == 0 {
+		return 0
+	}
+	return 1 - float64(and)/float64(or)
+}
+
+func pearson(x, y []float64) float64 {
+	n := float64(len(x))
+	var mx, my float64
+	for i, v := range x {
+		mx += v
+		my += y[i]
+	}
+	mx /= n
+	my /= n
+	var sxy, sxx, syy float64
+	for i, v := range x {
+		dx, dy := v-mx, y[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return 1
+	}
+	r := sxy / math.Sqrt(sxx*syy)
+	return 1 - math.Max(-1, math.Min(r, 1))
+}
+
+func spearman(x, y []float64) float64 { return pearson(ranks(x), ranks(y)) }
+
+// ranks returns the ranks of the elements of x, with ties given their mean rank.
+func ranks(x []float64) []float64 {
+	idx := make([]int, len(x))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Sort(byValue{idx: idx, x: x})
+	r := make([]float64, len(x))
+	for i := 0; i < len(idx); {
+		j := i + 1
+		for j < len(idx) && x[idx[j]] == x[idx[i]] {
+			j++
+		}
+		mean := float64(i+j-1) / 2
+		for _, k := range idx[i:j] {
+			r[k] = mean
+		}
+		i = j
+	}
+	return r
+}
+
+// byValue sorts indices into x by the value they refer to.
+type byValue struct {
+	idx []int
+	x   []float64
+}
+
+func (s byValue) Len() int           { return len(s.idx) }
+func (s byValue) Less(i, j int) bool { return s.x[s.idx[i]] < s.x[s.idx[j]] }
+func (s byValue) Swap(i, j int)      { s.idx[i], s.idx[j] = s.idx[j], s.idx[i] }
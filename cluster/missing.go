@@ -0,0 +1,172 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// NaNPolicy specifies how NaN data values, representing missing measurements, are
+// handled by Complete. Without a policy, NaN values propagate silently through
+// distance calculations and into cluster centers.
+type NaNPolicy int
+
+const (
+	// RejectNaN causes Complete to return an error if any data value is NaN.
+	RejectNaN NaNPolicy = iota
+
+	// ImputeMean replaces NaN values with the weighted mean of the values
+	// that are present in the same dimension.
+	ImputeMean
+
+	// ImputeMedian replaces NaN values with the weighted median of the values
+	// that are present in the same dimension.
+	ImputeMedian
+)
+
+// Complete returns a copy of data with NaN values handled according to policy. The
+// weights of data are retained if it satisfies Weighter. Complete returns an error
+// if data is empty, if the elements of data have differing dimensions, if policy
+// is RejectNaN and a value is NaN, or if a dimension has no values present to
+// impute from.
+//
+// Data with missing values may alternatively be clustered without imputation by
+// a clusterer that accepts a Metric, using PairwiseComplete.
+func Complete(data Interface, policy NaNPolicy) (*Dense, error) {
+	n := data.Len()
+	if n == 0 {
+		return nil, &Error{Pkg: "cluster", Err: ErrEmptyData}
+	}
+	d := NewDense(n, len(data.Values(0)))
+	if w, ok := data.(Weighter); ok {
+		d.Weights = make([]float64, n)
+		for i := range d.Weights {
+			d.Weights[i] = w.Weight(i)
+		}
+	}
+	var missing bool
+	for i := 0; i < n; i++ {
+		v := data.Values(i)
+		if len(v) != d.Cols {
+			return nil, &Error{Pkg: "cluster", Err: ErrDimensionMismatch}
+		}
+		for _, x := range v {
+			if math.IsNaN(x) {
+				missing = true
+			}
+		}
+		copy(d.Values(i), v)
+	}
+	if !missing {
+		return d, nil
+	}
+
+	var impute func(x, w []float64) float64
+	switch policy {
+	case RejectNaN:
+		return nil, errors.New("cluster: NaN value")
+	case ImputeMean:
+		impute = weightedMean
+	case ImputeMedian:
+		impute = weightedMedian
+	default:
+		panic("cluster: invalid NaN policy")
+	}
+	var x, w []float64
+	for j := 0; j < d.Cols; j++ {
+		x, w = x[:0], w[:0]
+		for i := 0; i < n; i++ {
+			if v := d.Values(i)[j]; !math.IsNaN(v) {
+				x = append(x, v)
+				w = append(w, d.Weight(i))
+			}
+		}
+		if len(x) == n {
+			continue
+		}
+		if len(x) == 0 {
+			return nil, errors.New("cluster: no values present in dimension")
+		}
+		m := impute(x, w)
+		for i := 0; i < n; i++ {
+			if v := d.Values(i); math.IsNaN(v[j]) {
+				v[j] = m
+			}
+		}
+	}
+	return d, nil
+}
+
+func weightedMean(x, w []float64) float64 {
+	var sum, sw float64
+	for i, v := range x {
+		sum += w[i] * v
+		sw += w[i]
+	}
+	return sum / sw
+}
+
+// weightedMedian returns the weighted median of x. The order of elements in x and
+// w is altered.
+func weightedMedian(x, w []float64) float64 {
+	sort.Sort(byValueWeight{x, w})
+	var sw float64
+	for _, v := range w {
+		sw += v
+	}
+	var cum float64
+	for i, v := range w {
+		cum += v
+		switch {
+		case cum > sw/2:
+			return x[i]
+		case cum == sw/2 && i+1 < len(x):
+			return (x[i] + x[i+1]) / 2
+		}
+	}
+	return x[len(x)-1]
+}
+
+type byValueWeight struct{ x, w []float64 }
+
+func (s byValueWeight) Len() int           { return len(s.x) }
+func (s byValueWeight) Less(i, j int) bool { return s.x[i] < s.x[j] }
+func (s byValueWeight) Swap(i, j int) {
+	s.x[i], s.x[j] = s.x[j], s.x[i]
+	s.w[i], s.w[j] = s.w[j], s.w[i]
+}
+
+// PairwiseComplete returns a Metric that evaluates m over only those dimensions in
+// which neither point is NaN. The distance is not rescaled for the number of
+// dimensions used, so metrics that sum over dimensions give smaller distances
+// between points with more missing values. Points with no dimensions present in
+// both are infinitely distant.
+func PairwiseComplete(m Metric) Metric {
+	return MetricFunc(func(x, y []float64) float64 {
+		complete := true
+		for i, v := range x {
+			if math.IsNaN(v) || math.IsNaN(y[i]) {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			return m.Distance(x, y)
+		}
+		var px, py []float64
+		for i, v := range x {
+			if !math.IsNaN(v) && !math.IsNaN(y[i]) {
+				px = append(px, v)
+				py = append(py, y[i])
+			}
+		}
+		if len(px) == 0 {
+			return math.Inf(1)
+		}
+		return m.Distance(px, py)
+	})
+}
@@ -0,0 +1,243 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+import "math"
+
+// Sparse is a collection of elements in ℝⁿ most of whose coordinates are zero,
+// such as k-mer counts or cell by gene expression matrices. Each element is
+// represented by the indices and values of its non-zero coordinates. A Sparse may
+// also satisfy Weighter.
+type Sparse interface {
+	Len() int  // Return the number of elements.
+	Dims() int // Return the dimensionality of the elements.
+
+	// Row returns the indices and values of the non-zero coordinates of
+	// element i. The indices must be in increasing order and less than Dims.
+	Row(i int) (idx []int, val []float64)
+}
+
+// CSR is a compressed sparse row matrix satisfying Sparse and Weighter. The
+// non-zero coordinates of element i have the indices Indices[Indptr[i]:Indptr[i+1]]
+// and the values Data[Indptr[i]:Indptr[i+1]].
+type CSR struct {
+	Rows, Cols int
+	Indptr     []int
+	Indices    []int
+	Data       []float64
+
+	// Weights holds the weights of the elements. If Weights
+	// is nil, all elements have a weight of 1.
+	Weights []float64
+}
+
+// NewCSR returns a CSR holding the non-zero values of data. If data is a
+// Weighter, the weights are retained.
+func NewCSR(data Interface) (*CSR, error) {
+	m := &CSR{Rows: data.Len(), Indptr: make([]int, 1, data.Len()+1)}
+	if m.Rows != 0 {
+		m.Cols = len(data.Values(0))
+	}
+	for i := 0; i < m.Rows; i++ {
+		v := data.Values(i)
+		if len(v) != m.Cols {
+			return nil, &Error{Pkg: "cluster", Err: ErrDimensionMismatch}
+		}
+		for j, x := range v {
+			if x != 0 {
+				m.Indices = append(m.Indices, j)
+				m.Data = append(m.Data, x)
+			}
+		}
+		m.Indptr = append(m.Indptr, len(m.Indices))
+	}
+	if w, ok := data.(Weighter); ok {
+		m.Weights = make([]float64, m.Rows)
+		for i := range m.Weights {
+			m.Weights[i] = w.Weight(i)
+		}
+	}
+	return m, nil
+}
+
+// Len returns the number of elements in the CSR.
+func (m *CSR) Len() int { return m.Rows }
+
+// Dims returns the dimensionality of the elements of the CSR.
+func (m *CSR) Dims() int { return m.Cols }
+
+// Row returns the indices and values of the non-zero coordinates of element i.
+// The returned slices share the backing store of the CSR.
+func (m *CSR) Row(i int) (idx []int, val []float64) {
+	from, to := m.Indptr[i], m.Indptr[i+1]
+	return m.Indices[from:to:to], m.Data[from:to:to]
+}
+
+// Weight returns the weight for element i.
+func (m *CSR) Weight(i int) float64 {
+	if m.Weights == nil {
+		return 1
+	}
+	return m.Weights[i]
+}
+
+// SparseMetric is a distance function between elements represented by the indices
+// and values of their non-zero coordinates, with indices in increasing order.
+type SparseMetric interface {
+	// Return the distance between x and y.
+	SparseDistance(xIdx []int, xVal []float64, yIdx []int, yVal []float64) float64
+}
+
+// SparseMetricFunc is a function satisfying SparseMetric.
+type SparseMetricFunc func(xIdx []int, xVal []float64, yIdx []int, yVal []float64) float64
+
+// SparseDistance returns f(xIdx, xVal, yIdx, yVal).
+func (f SparseMetricFunc) SparseDistance(xIdx []int, xVal []float64, yIdx []int, yVal []float64) float64 {
+	return f(xIdx, xVal, yIdx, yVal)
+}
+
+// Standard sparse metrics. Each gives the same distance as the corresponding
+// Metric applied to the dense representations of its arguments, in time
+// proportional to the number of non-zero coordinates.
+var (
+	// SparseEuclidean is the Euclidean, L₂, distance.
+	SparseEuclidean SparseMetric = SparseMetricFunc(sparseEuclidean)
+
+	// SparseSquaredEuclidean is the squared Euclidean distance.
+	SparseSquaredEuclidean SparseMetric = SparseMetricFunc(sparseSquaredEuclidean)
+
+	// SparseManhattan is the Manhattan, L₁, distance.
+	SparseManhattan SparseMetric = SparseMetricFunc(sparseManhattan)
+
+	// SparseCosine is the cosine dissimilarity, as for Cosine.
+	SparseCosine SparseMetric = SparseMetricFunc(sparseCosine)
+
+	// SparseJaccard is the Jaccard distance between presence/absence profiles,
+	// as for Jaccard.
+	SparseJaccard SparseMetric = SparseMetricFunc(sparseJaccard)
+)
+
+// merge calls fn with each pair of coordinates of x and y where either is not
+// zero.
+func merge(xIdx []int, xVal []float64, yIdx []int, yVal []float64, fn func(x, y float64)) {
+	i, j := 0, 0
+	for i < len(xIdx) && j < len(yIdx) {
+		switch {
+		case xIdx[i] == yIdx[j]:
+			fn(xVal[i], yVal[j])
+			i++
+			j++
+		case xIdx[i] < yIdx[j]:
+			fn(xVal[i], 0)
+			i++
+		default:
+			fn(0, yVal[j])
+			j++
+		}
+	}
+	for ; i < len(xIdx); i++ {
+		fn(xVal[i], 0)
+	}
+	for ; j < len(yIdx); j++ {
+		fn(0, yVal[j])
+	}
+}
+
+func sparseEuclidean(xIdx []int, xVal []float64, yIdx []int, yVal []float64) float64 {
+	return math.Sqrt(sparseSquaredEuclidean(xIdx, xVal, yIdx, yVal))
+}
+
+func sparseSquaredEuclidean(xIdx []int, xVal []float64, yIdx []int, yVal []float64) float64 {
+	var sum float64
+	merge(xIdx, xVal, yIdx, yVal, func(x, y float64) {
+		d := x - y
+		sum += d * d
+	})
+	return sum
+}
+
+func sparseManhattan(xIdx []int, xVal []float64, yIdx []int, yVal []float64) float64 {
+	var sum float64
+	merge(xIdx, xVal, yIdx, yVal, func(x, y float64) { sum += math.Abs(x - y) })
+	return sum
+}
+
+func sparseCosine(xIdx []int, xVal []float64, yIdx []int, yVal []float64) float64 {
+	var dot, xx, yy float64
+	merge(xIdx, xVal, yIdx, yVal, func(x, y float64) {
+		dot += x * y
+		xx += x * x
+		yy += y * y
+	})
+	if xx == 0 || yy == 0 {
+		return 1
+	}
+	return 1 - dot/math.Sqrt(xx*yy)
+}
+
+func sparseJaccard(xIdx []int, xVal []float64, yIdx []int, yVal []float64) float64 {
+	var union, inter int
+	merge(xIdx, xVal, yIdx, yVal, func(x, y float64) {
+		if x != 0 || y != 0 {
+			union++
+		}
+		if x != 0 && y != 0 {
+			inter++
+		}
+	})
+	if union == 0 {
+		return 0
+	}
+	return 1 - float64(inter)/float64(union)
+}
+
+// SparseDistances returns a DistanceMatrixer giving the distances between the
+// elements of data under m. If m is nil, SparseEuclidean is used. Distances are
+// calculated on demand and are not cached. The returned DistanceMatrixer is a
+// Weighter if data is a Weighter.
+func SparseDistances(data Sparse, m SparseMetric) DistanceMatrixer {
+	if m == nil {
+		m = SparseEuclidean
+	}
+	d := sparseDistances{data: data, metric: m}
+	if w, ok := data.(Weighter); ok {
+		return weightedSparseDistances{d, w}
+	}
+	return d
+}
+
+type sparseDistances struct {
+	data   Sparse
+	metric SparseMetric
+}
+
+func (d sparseDistances) Len() int { return d.data.Len() }
+func (d sparseDistances) Distance(i, j int) float64 {
+	xi, xv := d.data.Row(i)
+	yi, yv := d.data.Row(j)
+	return d.metric.SparseDistance(xi, xv, yi, yv)
+}
+
+type weightedSparseDistances struct {
+	sparseDistances
+	Weighter
+}
+
+// CheckSparse returns an error if the indices of any element of data are not in
+// increasing order within [0, data.Dims()) or do not match the number of values.
+func CheckSparse(data Sparse) error {
+	for i := 0; i < data.Len(); i++ {
+		idx, val := data.Row(i)
+		if len(idx) != len(val) {
+			return &Error{Pkg: "cluster", Err: ErrInvalidSparse}
+		}
+		for k, j := range idx {
+			if j < 0 || j >= data.Dims() || (k != 0 && j <= idx[k-1]) {
+				return &Error{Pkg: "cluster", Err: ErrInvalidSparse}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cluster
+
+// Iterator is a Stream of the elements of an Interface, allowing batch data to be
+// presented to streaming clusterers.
+type Iterator struct {
+	data   Interface
+	weight Weighter
+	i      int
+}
+
+// NewIterator returns an Iterator over the elements of data in order. If data is a
+// Weighter, its weights are used, otherwise every element has a weight of 1.
+func NewIterator(data Interface) *Iterator {
+	w, _ := data.(Weighter)
+	return &Iterator{data: data, weight: w}
+}
+
+// Next returns the next element of the data and its weight. The returned slice is
+// the slice returned by the Values method of the data.
+func (it *Iterator) Next() (v []float64, w float64, ok bool) {
+	if it.i >= it.data.Len() {
+		return nil, 0, false
+	}
+	v, w = it.data.Values(it.i), 1
+	if it.weight != nil {
+		w = it.weight.Weight(it.i)
+	}
+	it.i++
+	return v, w, true
+}
+
+// Reset returns the Iterator to the first element of the data.
+func (it *Iterator) Reset() { it.i = 0 }
+
+// Collect reads s until it is exhausted and returns its values as a Dense holding
+// their weights, allowing streamed data to be clustered by batch clusterers. If s
+// has an Err() error method, as stream.Reader does, a non-nil error it returns
+// after the stream is exhausted is returned by Collect.
+func Collect(s Stream) (*Dense, error) {
+	d := &Dense{}
+	for {
+		v, w, ok := s.Next()
+		if !ok {
+			break
+		}
+		if d.Rows == 0 {
+			d.Cols = len(v)
+			d.Stride = len(v)
+		} else if len(v) != d.Cols {
+			return nil, &Error{Pkg: "cluster", Err: ErrDimensionMismatch}
+		}
+		d.Data = append(d.Data, v...)
+		d.Weights = append(d.Weights, w)
+		d.Rows++
+	}
+	if e, ok := s.(interface{ Err() error }); ok {
+		if err := e.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if d.Rows == 0 {
+		return nil, &Error{Pkg: "cluster", Err: ErrEmptyData}
+	}
+	return d, nil
+}
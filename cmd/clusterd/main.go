@@ -0,0 +1,59 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// clusterd is an HTTP JSON service for fitting clusterings and labelling new data.
+//
+// Endpoints:
+//
+//	POST   /fit          fit a model; returns the model id, labels and centers
+//	POST   /predict      assign new values to the centers of a fitted model
+//	GET    /models/{id}  return the labels and centers of a fitted model
+//	DELETE /models/{id}  remove a fitted model
+//
+// A fit request is a JSON object:
+//
+//	{
+//		"algorithm": "kmeans" | "meanshift",
+//		"kernel": "uniform" | "truncgauss" | "gauss" | "epanechnikov",
+//		"params": {"k": 3, "bandwidth": 50, ...},
+//		"data": [[x, y, ...], ...],
+//		"weights": [w, ...],
+//		"file": "relative/path.tsv"
+//	}
+//
+// The kernel field is only used by meanshift. The kmeans algorithm takes the k
+// parameter and an optional seed parameter that makes its seeding reproducible,
+// and meanshift takes the bandwidth, oversample, tol and maxiter parameters.
+// Exactly one of data or file must be provided. Files are read relative to the
+// directory given by the -root flag; files with a .parquet suffix are read as
+// Parquet and all others as white space or comma delimited text.
+//
+// A predict request is a JSON object:
+//
+//	{"model": "id", "data": [[x, y, ...], ...]}
+//
+// Request bodies larger than the -maxbody flag are rejected, and fit requests are
+// rejected while the server holds the number of models given by the -maxmodels
+// flag. Models that are no longer needed should be deleted.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	root := flag.String("root", "", "directory holding data files (file references are disabled if empty)")
+	maxBody := flag.Int64("maxbody", defaultMaxBody, "maximum request body size in bytes")
+	maxModels := flag.Int("maxmodels", defaultMaxModels, "maximum number of stored models")
+	flag.Parse()
+
+	srv := newServer(*root)
+	srv.maxBody = *maxBody
+	srv.maxModels = *maxModels
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, srv))
+}
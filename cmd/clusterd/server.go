@@ -0,0 +1,360 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/columnar"
+	"github.com/biogo/cluster/kmeans"
+	"github.com/biogo/cluster/meanshift"
+	"github.com/biogo/cluster/stream"
+
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fitRequest is the body of a /fit request.
+type fitRequest struct {
+	Algorithm string             `json:"algorithm"`
+	Kernel    string             `json:"kernel"`
+	Params    map[string]float64 `json:"params"`
+	Data      [][]float64        `json:"data"`
+	Weights   []float64          `json:"weights"`
+	File      string             `json:"file"`
+}
+
+// predictRequest is the body of a /predict request.
+type predictRequest struct {
+	Model string      `json:"model"`
+	Data  [][]float64 `json:"data"`
+}
+
+// model is a fitted clustering.
+type model struct {
+	ID      string      `json:"id"`
+	Labels  []int       `json:"labels"`
+	Centers [][]float64 `json:"centers"`
+	Error   string      `json:"error,omitempty"`
+}
+
+const (
+	defaultMaxBody   = 64 << 20 // defaultMaxBody is the default request body limit in bytes.
+	defaultMaxModels = 1000     // defaultMaxModels is the default number of stored models.
+)
+
+// server holds the fitted models.
+type server struct {
+	root string
+
+	// maxBody is the largest request body accepted in bytes and maxModels
+	// is the largest number of models held by the server.
+	maxBody   int64
+	maxModels int
+
+	mu     sync.Mutex
+	next   int
+	models map[string]*model
+
+	mux *http.ServeMux
+}
+
+func newServer(root string) *server {
+	s := &server{
+		root:      root,
+		maxBody:   defaultMaxBody,
+		maxModels: defaultMaxModels,
+		models:    make(map[string]*model),
+		mux:       http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/fit", s.fit)
+	s.mux.HandleFunc("/predict", s.predict)
+	s.mux.HandleFunc("/models/", s.model)
+	return s
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) { s.mux.ServeHTTP(w, r) }
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// decode decodes the JSON body of r into v, reading no more than s.maxBody bytes.
+// It returns the HTTP status to report if decoding fails.
+func (s *server) decode(w http.ResponseWriter, r *http.Request, v interface{}) (int, error) {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.maxBody)).Decode(v)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return http.StatusRequestEntityTooLarge, err
+		}
+		return http.StatusBadRequest, err
+	}
+	return http.StatusOK, nil
+}
+
+func (s *server) fit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	var req fitRequest
+	if status, err := s.decode(w, r, &req); err != nil {
+		writeError(w, status, err)
+		return
+	}
+	data, err := s.data(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	c, err := newClusterer(req.Algorithm, req.Kernel, req.Params, data)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	m := &model{}
+	err = cluster.ClusterContext(r.Context(), c)
+	if err != nil {
+		if r.Context().Err() != nil {
+			// The request was cancelled, so the model is not stored.
+			writeError(w, http.StatusServiceUnavailable, err)
+			return
+		}
+		if len(c.Centers()) == 0 {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		// Non-convergence still provides a usable partition.
+		m.Error = err.Error()
+	}
+	for _, cen := range c.Centers() {
+		m.Centers = append(m.Centers, cen.V())
+	}
+	for _, v := range c.Values() {
+		m.Labels = append(m.Labels, v.Cluster())
+	}
+
+	s.mu.Lock()
+	if len(s.models) >= s.maxModels {
+		s.mu.Unlock()
+		writeError(w, http.StatusInsufficientStorage, errors.New("model store full"))
+		return
+	}
+	m.ID = strconv.Itoa(s.next)
+	s.next++
+	s.models[m.ID] = m
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, m)
+}
+
+// data returns the data described by req.
+func (s *server) data(req fitRequest) (cluster.Interface, error) {
+	switch {
+	case req.File != "" && req.Data != nil:
+		return nil, errors.New("both data and file specified")
+	case req.File != "":
+		return s.readFile(req.File)
+	case len(req.Data) == 0:
+		return nil, &cluster.Error{Pkg: "clusterd", Err: cluster.ErrEmptyData}
+	}
+	if req.Weights != nil && len(req.Weights) != len(req.Data) {
+		return nil, errors.New("mismatched weights length")
+	}
+	dim := len(req.Data[0])
+	d := cluster.NewDense(len(req.Data), dim)
+	for i, v := range req.Data {
+		if len(v) != dim {
+			return nil, &cluster.Error{Pkg: "clusterd", Err: cluster.ErrDimensionMismatch}
+		}
+		copy(d.Values(i), v)
+	}
+	d.Weights = req.Weights
+	return d, nil
+}
+
+// readFile reads data from the named file relative to the server root.
+func (s *server) readFile(name string) (cluster.Interface, error) {
+	if s.root == "" {
+		return nil, errors.New("file references are disabled")
+	}
+	if !filepath.IsLocal(name) {
+		return nil, fmt.Errorf("invalid file name %q", name)
+	}
+	root, err := os.OpenRoot(s.root)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+	f, err := root.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(name, ".parquet") {
+		return columnar.ReadParquet(f, nil, "")
+	}
+	var (
+		vals [][]float64
+		wts  []float64
+		rd   = stream.NewReader(f)
+	)
+	for {
+		v, w, ok := rd.Next()
+		if !ok {
+			break
+		}
+		vals = append(vals, v)
+		wts = append(wts, w)
+	}
+	if err := rd.Err(); err != nil {
+		return nil, err
+	}
+	if len(vals) == 0 {
+		return nil, &cluster.Error{Pkg: "clusterd", Err: cluster.ErrEmptyData}
+	}
+	d := cluster.NewDense(len(vals), len(vals[0]))
+	for i, v := range vals {
+		copy(d.Values(i), v)
+	}
+	d.Weights = wts
+	return d, nil
+}
+
+// newClusterer returns a Clusterer for the named algorithm, kernel and parameters.
+func newClusterer(alg, kernel string, params map[string]float64, data cluster.Interface) (cluster.Clusterer, error) {
+	param := func(name string, def float64) float64 {
+		if v, ok := params[name]; ok {
+			return v
+		}
+		return def
+	}
+	switch alg {
+	case "kmeans":
+		k := int(param("k", 0))
+		if k < 1 || k > data.Len() {
+			return nil, errors.New("kmeans: k out of range")
+		}
+		var opts []kmeans.Option
+		if seed, ok := params["seed"]; ok {
+			opts = append(opts, kmeans.WithSeed(int64(seed)))
+		}
+		km, err := kmeans.New(data, opts...)
+		if err != nil {
+			return nil, err
+		}
+		km.Seed(k)
+		return km, nil
+	case "meanshift":
+		h := param("bandwidth", 0)
+		if h <= 0 {
+			return nil, errors.New("meanshift: bandwidth must be positive")
+		}
+		var k meanshift.Shifter
+		switch kernel {
+		case "", "uniform":
+			k = meanshift.NewUniform(h)
+		case "truncgauss":
+			k = meanshift.NewTruncGauss(h, param("oversample", 3))
+		case "gauss":
+			k = meanshift.NewGauss(h)
+		case "epanechnikov":
+			k = meanshift.NewEpanechnikov(h)
+		default:
+			return nil, fmt.Errorf("meanshift: unknown kernel %q", kernel)
+		}
+		return meanshift.New(data, k, param("tol", 0.1), int(param("maxiter", 100))), nil
+	}
+	return nil, fmt.Errorf("unknown algorithm %q", alg)
+}
+
+func (s *server) predict(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	var req predictRequest
+	if status, err := s.decode(w, r, &req); err != nil {
+		writeError(w, status, err)
+		return
+	}
+	s.mu.Lock()
+	m, ok := s.models[req.Model]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no model %q", req.Model))
+		return
+	}
+	labels := make([]int, len(req.Data))
+	for i, v := range req.Data {
+		var err error
+		labels[i], err = nearest(m.Centers, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string][]int{"labels": labels})
+}
+
+// nearest returns the index of the center nearest to v.
+func nearest(centers [][]float64, v []float64) (int, error) {
+	c, min := -1, math.Inf(1)
+	for i, cen := range centers {
+		if len(cen) != len(v) {
+			return -1, &cluster.Error{Pkg: "clusterd", Err: cluster.ErrDimensionMismatch}
+		}
+		var d float64
+		for j := range v {
+			x := v[j] - cen[j]
+			d += x * x
+		}
+		if d < min {
+			c, min = i, d
+		}
+	}
+	return c, nil
+}
+
+// model returns the model named by the request path for a GET request and
+// removes it from the server for a DELETE request.
+func (s *server) model(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/models/")
+	s.mu.Lock()
+	m, ok := s.models[id]
+	if ok && r.Method == http.MethodDelete {
+		delete(s.models, id)
+	}
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no model %q", id))
+		return
+	}
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
@@ -0,0 +1,136 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+func do(c *check.C, s http.Handler, method, path string, body interface{}, v interface{}) int {
+	var buf bytes.Buffer
+	if body != nil {
+		c.Assert(json.NewEncoder(&buf).Encode(body), check.Equals, nil)
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(method, path, &buf))
+	if v != nil {
+		c.Assert(json.Unmarshal(w.Body.Bytes(), v), check.Equals, nil)
+	}
+	return w.Code
+}
+
+var data = [][]float64{{0, 0}, {0, 1}, {1, 0}, {10, 10}, {10, 11}, {11, 10}}
+
+func (s *S) TestFitPredict(c *check.C) {
+	srv := newServer("")
+	var m model
+	code := do(c, srv, "POST", "/fit", fitRequest{
+		Algorithm: "kmeans",
+		Params:    map[string]float64{"k": 2, "seed": 1},
+		Data:      data,
+	}, &m)
+	c.Assert(code, check.Equals, http.StatusOK)
+	c.Check(m.ID, check.Equals, "0")
+	c.Check(len(m.Centers), check.Equals, 2)
+	c.Check(m.Labels[0], check.Equals, m.Labels[2])
+	c.Check(m.Labels[3], check.Equals, m.Labels[5])
+	c.Check(m.Labels[0] != m.Labels[3], check.Equals, true)
+
+	var pred map[string][]int
+	code = do(c, srv, "POST", "/predict", predictRequest{Model: m.ID, Data: [][]float64{{12, 12}, {-1, 0}}}, &pred)
+	c.Assert(code, check.Equals, http.StatusOK)
+	c.Check(pred["labels"], check.DeepEquals, []int{m.Labels[3], m.Labels[0]})
+
+	var got model
+	code = do(c, srv, "GET", "/models/0", nil, &got)
+	c.Check(code, check.Equals, http.StatusOK)
+	c.Check(got, check.DeepEquals, m)
+
+	code = do(c, srv, "POST", "/fit", fitRequest{
+		Algorithm: "meanshift",
+		Kernel:    "uniform",
+		Params:    map[string]float64{"bandwidth": 3},
+		Data:      data,
+	}, &m)
+	c.Assert(code, check.Equals, http.StatusOK)
+	c.Check(m.ID, check.Equals, "1")
+	c.Check(len(m.Centers), check.Equals, 2)
+}
+
+func (s *S) TestErrors(c *check.C) {
+	srv := newServer("")
+	var e map[string]string
+	for _, t := range []struct {
+		req  fitRequest
+		code int
+		err  string
+	}{
+		{fitRequest{Algorithm: "kmeans", Params: map[string]float64{"k": 2}}, http.StatusBadRequest, "clusterd: no data"},
+		{fitRequest{Algorithm: "kmeans", Data: data}, http.StatusBadRequest, "kmeans: k out of range"},
+		{fitRequest{Algorithm: "spectral", Data: data}, http.StatusBadRequest, `unknown algorithm "spectral"`},
+		{fitRequest{Algorithm: "kmeans", File: "data.tsv"}, http.StatusBadRequest, "file references are disabled"},
+	} {
+		c.Check(do(c, srv, "POST", "/fit", t.req, &e), check.Equals, t.code)
+		c.Check(e["error"], check.Equals, t.err)
+	}
+	c.Check(do(c, srv, "GET", "/models/7", nil, &e), check.Equals, http.StatusNotFound)
+	c.Check(do(c, srv, "GET", "/fit", nil, &e), check.Equals, http.StatusMethodNotAllowed)
+}
+
+func (s *S) TestFile(c *check.C) {
+	dir := c.MkDir()
+	err := os.WriteFile(filepath.Join(dir, "data.tsv"), []byte("0 0\n0 1\n10 10\n10 11\n"), 0o644)
+	c.Assert(err, check.Equals, nil)
+	srv := newServer(dir)
+	var m model
+	code := do(c, srv, "POST", "/fit", fitRequest{
+		Algorithm: "kmeans",
+		Params:    map[string]float64{"k": 2, "seed": 1},
+		File:      "data.tsv",
+	}, &m)
+	c.Assert(code, check.Equals, http.StatusOK)
+	c.Check(len(m.Labels), check.Equals, 4)
+
+	var e map[string]string
+	code = do(c, srv, "POST", "/fit", fitRequest{Algorithm: "kmeans", File: "../data.tsv"}, &e)
+	c.Check(code, check.Equals, http.StatusBadRequest)
+	c.Check(e["error"], check.Equals, `invalid file name "../data.tsv"`)
+}
+
+func (s *S) TestLimits(c *check.C) {
+	srv := newServer("")
+	srv.maxBody = 64
+	srv.maxModels = 1
+	var e map[string]string
+	code := do(c, srv, "POST", "/fit", fitRequest{Algorithm: "kmeans", Params: map[string]float64{"k": 2}, Data: data}, &e)
+	c.Check(code, check.Equals, http.StatusRequestEntityTooLarge)
+
+	srv.maxBody = defaultMaxBody
+	req := fitRequest{Algorithm: "kmeans", Params: map[string]float64{"k": 2, "seed": 1}, Data: data}
+	var m model
+	c.Assert(do(c, srv, "POST", "/fit", req, &m), check.Equals, http.StatusOK)
+	c.Check(do(c, srv, "POST", "/fit", req, &e), check.Equals, http.StatusInsufficientStorage)
+	c.Check(e["error"], check.Equals, "model store full")
+
+	c.Check(do(c, srv, "DELETE", "/models/"+m.ID, nil, nil), check.Equals, http.StatusNoContent)
+	c.Check(do(c, srv, "GET", "/models/"+m.ID, nil, &e), check.Equals, http.StatusNotFound)
+	c.Check(do(c, srv, "DELETE", "/models/"+m.ID, nil, &e), check.Equals, http.StatusNotFound)
+	c.Check(do(c, srv, "POST", "/fit", req, &m), check.Equals, http.StatusOK)
+	c.Check(m.ID, check.Equals, "1")
+}
@@ -0,0 +1,206 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package columnar loads clustering data from Apache Arrow record batches and
+// Parquet files into cluster.Dense values.
+//
+// Numeric columns are converted to float64 and written directly into the packed
+// Dense backing store one column at a time. Null entries are represented as NaN.
+// A table with a single fixed size list column of float64, as is produced when
+// exporting vector columns, is mapped to a Dense without copying when it is held
+// in a single chunk without nulls.
+package columnar
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// FromRecords returns a cluster.Dense holding the data in the provided records,
+// which must share a schema. The value columns and an optional weight column are
+// selected as described for FromTable.
+func FromRecords(recs []arrow.RecordBatch, cols []string, weight string) (*cluster.Dense, error) {
+	if len(recs) == 0 {
+		return nil, &cluster.Error{Pkg: "columnar", Err: cluster.ErrEmptyData}
+	}
+	tbl := array.NewTableFromRecords(recs[0].Schema(), recs)
+	defer tbl.Release()
+	return FromTable(tbl, cols, weight)
+}
+
+// ReadParquet returns a cluster.Dense holding the data in the Parquet file read from
+// r. The value columns and an optional weight column are selected as described for
+// FromTable.
+func ReadParquet(r parquet.ReaderAtSeeker, cols []string, weight string) (*cluster.Dense, error) {
+	tbl, err := pqarrow.ReadTable(context.Background(), r, nil, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		return nil, fmt.Errorf("columnar: %w", err)
+	}
+	defer tbl.Release()
+	return FromTable(tbl, cols, weight)
+}
+
+// FromTable returns a cluster.Dense holding the data in tbl. The named columns in
+// cols are used as the dimensions of the data, in order. If cols is nil, all
+// numeric columns other than the weight column are used. If weight is not empty,
+// the named column is used for the element weights. The returned Dense may share
+// memory with tbl.
+func FromTable(tbl arrow.Table, cols []string, weight string) (*cluster.Dense, error) {
+	schema := tbl.Schema()
+	var idx []int
+	if cols == nil {
+		for i, f := range schema.Fields() {
+			if f.Name != weight && (isNumeric(f.Type) || isVector(f.Type)) {
+				idx = append(idx, i)
+			}
+		}
+	} else {
+		for _, name := range cols {
+			i := schema.FieldIndices(name)
+			if len(i) == 0 {
+				return nil, fmt.Errorf("columnar: no column %q", name)
+			}
+			idx = append(idx, i[0])
+		}
+	}
+	if len(idx) == 0 {
+		return nil, errors.New("columnar: no value columns")
+	}
+
+	rows := int(tbl.NumRows())
+	var d *cluster.Dense
+	if len(idx) == 1 && isVector(schema.Field(idx[0]).Type) {
+		d = fromVectors(tbl.Column(idx[0]), rows)
+	} else {
+		d = cluster.NewDense(rows, len(idx))
+		for c, i := range idx {
+			if !isNumeric(schema.Field(i).Type) {
+				return nil, fmt.Errorf("columnar: column %q is not numeric", schema.Field(i).Name)
+			}
+			fill(d.Data[c:], d.Stride, tbl.Column(i))
+		}
+	}
+
+	if weight != "" {
+		i := schema.FieldIndices(weight)
+		if len(i) == 0 {
+			return nil, fmt.Errorf("columnar: no column %q", weight)
+		}
+		if !isNumeric(schema.Field(i[0]).Type) {
+			return nil, fmt.Errorf("columnar: column %q is not numeric", weight)
+		}
+		d.Weights = make([]float64, rows)
+		fill(d.Weights, 1, tbl.Column(i[0]))
+	}
+
+	return d, nil
+}
+
+func isNumeric(t arrow.DataType) bool {
+	switch t.ID() {
+	case arrow.FLOAT64, arrow.FLOAT32,
+		arrow.INT64, arrow.INT32, arrow.INT16, arrow.INT8,
+		arrow.UINT64, arrow.UINT32, arrow.UINT16, arrow.UINT8:
+		return true
+	}
+	return false
+}
+
+func isVector(t arrow.DataType) bool {
+	l, ok := t.(*arrow.FixedSizeListType)
+	return ok && isNumeric(l.Elem())
+}
+
+// fill writes the values of col into dst at the given stride.
+func fill(dst []float64, stride int, col *arrow.Column) {
+	row := 0
+	for _, chunk := range col.Data().Chunks() {
+		for i := 0; i < chunk.Len(); i++ {
+			dst[row*stride] = value(chunk, i)
+			row++
+		}
+	}
+}
+
+// value returns element i of the numeric array a as a float64.
+func value(a arrow.Array, i int) float64 {
+	if a.IsNull(i) {
+		return math.NaN()
+	}
+	switch a := a.(type) {
+	case *array.Float64:
+		return a.Value(i)
+	case *array.Float32:
+		return float64(a.Value(i))
+	case *array.Int64:
+		return float64(a.Value(i))
+	case *array.Int32:
+		return float64(a.Value(i))
+	case *array.Int16:
+		return float64(a.Value(i))
+	case *array.Int8:
+		return float64(a.Value(i))
+	case *array.Uint64:
+		return float64(a.Value(i))
+	case *array.Uint32:
+		return float64(a.Value(i))
+	case *array.Uint16:
+		return float64(a.Value(i))
+	case *array.Uint8:
+		return float64(a.Value(i))
+	}
+	panic("columnar: unexpected array type")
+}
+
+// fromVectors returns a Dense holding the fixed size list values in col. If col is
+// a single chunk of float64 lists without nulls, the Dense shares the list values
+// buffer.
+func fromVectors(col *arrow.Column, rows int) *cluster.Dense {
+	n := int(col.DataType().(*arrow.FixedSizeListType).Len())
+	chunks := col.Data().Chunks()
+	if len(chunks) == 1 && chunks[0].NullN() == 0 {
+		l := chunks[0].(*array.FixedSizeList)
+		if v, ok := l.ListValues().(*array.Float64); ok && v.NullN() == 0 {
+			off := l.Offset() * n
+			return &cluster.Dense{
+				Rows:   rows,
+				Cols:   n,
+				Stride: n,
+				Data:   v.Float64Values()[off : off+rows*n],
+			}
+		}
+	}
+
+	d := cluster.NewDense(rows, n)
+	row := 0
+	for _, chunk := range chunks {
+		l := chunk.(*array.FixedSizeList)
+		v := l.ListValues()
+		for i := 0; i < l.Len(); i++ {
+			dst := d.Values(row)
+			if l.IsNull(i) {
+				for j := range dst {
+					dst[j] = math.NaN()
+				}
+			} else {
+				off := (l.Offset() + i) * n
+				for j := range dst {
+					dst[j] = value(v, off+j)
+				}
+			}
+			row++
+		}
+	}
+	return d
+}
@@ -0,0 +1,116 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package columnar_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/columnar"
+
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+var schema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.BinaryTypes.String},
+	{Name: "x", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "y", Type: arrow.PrimitiveTypes.Int32},
+	{Name: "w", Type: arrow.PrimitiveTypes.Float32},
+}, nil)
+
+func record(ids []string, x []float64, valid []bool, y []int32, w []float32) arrow.RecordBatch {
+	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer b.Release()
+	b.Field(0).(*array.StringBuilder).AppendValues(ids, nil)
+	b.Field(1).(*array.Float64Builder).AppendValues(x, valid)
+	b.Field(2).(*array.Int32Builder).AppendValues(y, nil)
+	b.Field(3).(*array.Float32Builder).AppendValues(w, nil)
+	return b.NewRecordBatch()
+}
+
+func rows(d *cluster.Dense) [][]float64 {
+	r := make([][]float64, d.Len())
+	for i := range r {
+		r[i] = d.Values(i)
+	}
+	return r
+}
+
+func (s *S) TestFromRecords(c *check.C) {
+	recs := []arrow.RecordBatch{
+		record([]string{"a", "b"}, []float64{1, 2}, nil, []int32{10, 20}, []float32{1, 0.5}),
+		record([]string{"c"}, []float64{0}, []bool{false}, []int32{30}, []float32{2}),
+	}
+	d, err := columnar.FromRecords(recs, nil, "w")
+	c.Assert(err, check.Equals, nil)
+	c.Check(d.Len(), check.Equals, 3)
+	r := rows(d)
+	c.Check(r[:2], check.DeepEquals, [][]float64{{1, 10}, {2, 20}})
+	c.Check(math.IsNaN(r[2][0]), check.Equals, true)
+	c.Check(r[2][1], check.Equals, 30.)
+	c.Check(d.Weights, check.DeepEquals, []float64{1, 0.5, 2})
+
+	d, err = columnar.FromRecords(recs, []string{"y"}, "")
+	c.Assert(err, check.Equals, nil)
+	c.Check(rows(d), check.DeepEquals, [][]float64{{10}, {20}, {30}})
+	c.Check(d.Weight(1), check.Equals, 1.)
+
+	_, err = columnar.FromRecords(recs, []string{"id"}, "")
+	c.Check(err, check.ErrorMatches, `columnar: column "id" is not numeric`)
+	_, err = columnar.FromRecords(recs, []string{"z"}, "")
+	c.Check(err, check.ErrorMatches, `columnar: no column "z"`)
+	_, err = columnar.FromRecords(nil, nil, "")
+	c.Check(err, check.ErrorMatches, "columnar: no data")
+}
+
+func (s *S) TestVectors(c *check.C) {
+	vs := arrow.NewSchema([]arrow.Field{
+		{Name: "v", Type: arrow.FixedSizeListOf(3, arrow.PrimitiveTypes.Float64)},
+	}, nil)
+	b := array.NewRecordBuilder(memory.DefaultAllocator, vs)
+	defer b.Release()
+	lb := b.Field(0).(*array.FixedSizeListBuilder)
+	vb := lb.ValueBuilder().(*array.Float64Builder)
+	for i := 0; i < 4; i++ {
+		lb.Append(true)
+		vb.AppendValues([]float64{float64(i), float64(i * i), -1}, nil)
+	}
+	rec := b.NewRecordBatch()
+	full := rec.Column(0).(*array.FixedSizeList).ListValues().(*array.Float64).Float64Values()
+
+	d, err := columnar.FromRecords([]arrow.RecordBatch{rec.NewSlice(1, 3)}, nil, "")
+	c.Assert(err, check.Equals, nil)
+	c.Check(rows(d), check.DeepEquals, [][]float64{{1, 1, -1}, {2, 4, -1}})
+	c.Check(&d.Data[0], check.Equals, &full[3], check.Commentf("expected shared backing memory"))
+
+	d, err = columnar.FromRecords([]arrow.RecordBatch{rec.NewSlice(0, 1), rec.NewSlice(3, 4)}, nil, "")
+	c.Assert(err, check.Equals, nil)
+	c.Check(rows(d), check.DeepEquals, [][]float64{{0, 0, -1}, {3, 9, -1}})
+}
+
+func (s *S) TestReadParquet(c *check.C) {
+	rec := record([]string{"a", "b", "c"}, []float64{1, 2, 3}, nil, []int32{4, 5, 6}, []float32{1, 2, 3})
+	tbl := array.NewTableFromRecords(schema, []arrow.RecordBatch{rec})
+	var buf bytes.Buffer
+	err := pqarrow.WriteTable(tbl, &buf, 2, nil, pqarrow.DefaultWriterProps())
+	c.Assert(err, check.Equals, nil)
+
+	d, err := columnar.ReadParquet(bytes.NewReader(buf.Bytes()), []string{"y", "x"}, "w")
+	c.Assert(err, check.Equals, nil)
+	c.Check(rows(d), check.DeepEquals, [][]float64{{4, 1}, {5, 2}, {6, 3}})
+	c.Check(d.Weights, check.DeepEquals, []float64{1, 2, 3})
+}
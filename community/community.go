@@ -0,0 +1,504 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package community provides modularity-based community detection in weighted graphs
+// using the Louvain and Leiden algorithms.
+//
+// References:
+//
+//	Blondel VD, Guillaume J-L, Lambiotte R, Lefebvre E. Fast unfolding of communities
+//	in large networks. Journal of Statistical Mechanics P10008 (2008).
+//
+//	Traag VA, Waltman L, van Eck NJ. From Louvain to Leiden: guaranteeing well-connected
+//	communities. Scientific Reports 9:5233 (2019).
+package community
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/knn"
+
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// Edge is a weighted edge to a neighboring node. Weights are affinities; larger
+// weights indicate stronger connections.
+type Edge struct {
+	To     int
+	Weight float64
+}
+
+// Graph is an undirected weighted graph. Element i of a Graph holds the edges from
+// node i. Each undirected edge must be present in both directions.
+type Graph [][]Edge
+
+// FromKNN returns an unweighted Graph with the edges of the undirected form of g.
+func FromKNN(g knn.Graph) Graph {
+	u := g.Undirected()
+	c := make(Graph, len(u))
+	for i, edges := range u {
+		c[i] = make([]Edge, len(edges))
+		for j, e := range edges {
+			c[i][j] = Edge{To: e.To, Weight: 1}
+		}
+	}
+	return c
+}
+
+// Partition is a division of the nodes of a graph into communities.
+type Partition struct {
+	// Labels holds the community of each node. Communities are numbered
+	// from zero in order of their lowest numbered node.
+	Labels []int
+
+	// Modularity is the modularity of the partition at the resolution
+	// used to find it.
+	Modularity float64
+}
+
+// Communities returns the nodes in each community of p.
+func (p Partition) Communities() []cluster.Indices {
+	var c []cluster.Indices
+	for i, l := range p.Labels {
+		for l >= len(c) {
+			c = append(c, nil)
+		}
+		c[l] = append(c[l], i)
+	}
+	return c
+}
+
+// Method is a community detection algorithm. Louvain and Leiden are Methods.
+type Method func(g Graph, resolution float64) (Partition, error)
+
+var (
+	_ Method = Louvain
+	_ Method = Leiden
+)
+
+// graph is the internal representation of a possibly aggregated graph. Self loops
+// hold the total weight of edges within the aggregated node counted in both
+// directions so that the degree of an aggregated node is the sum of the degrees
+// of its constituent nodes.
+type graph struct {
+	adj []map[int]float64
+	deg []float64
+	m2  float64
+}
+
+func newGraph(g Graph) (*graph, error) {
+	if len(g) == 0 {
+		return nil, errors.New("community: no nodes")
+	}
+	h := &graph{adj: make([]map[int]float64, len(g)), deg: make([]float64, len(g))}
+	for i := range g {
+		h.adj[i] = make(map[int]float64)
+	}
+	for i, edges := range g {
+		for _, e := range edges {
+			if e.To < 0 || e.To >= len(g) {
+				return nil, errors.New("community: edge out of range")
+			}
+			if e.Weight < 0 {
+				return nil, errors.New("community: negative weight")
+			}
+			h.adj[i][e.To] += e.Weight
+			h.deg[i] += e.Weight
+			h.m2 += e.Weight
+		}
+	}
+	return h, nil
+}
+
+// aggregate returns the graph obtained by collapsing the nodes of g with the same
+// label into a single node. Labels must be in [0, n).
+func (g *graph) aggregate(labels []int, n int) *graph {
+	h := &graph{adj: make([]map[int]float64, n), deg: make([]float64, n), m2: g.m2}
+	for i := range h.adj {
+		h.adj[i] = make(map[int]float64)
+	}
+	for u, nbrs := range g.adj {
+		for v, w := range nbrs {
+			h.adj[labels[u]][labels[v]] += w
+		}
+		h.deg[labels[u]] += g.deg[u]
+	}
+	return h
+}
+
+// links returns the total weight of edges from v to each community, excluding
+// self loops, and a sorted list of the communities.
+func (g *graph) links(v int, comm []int, dst map[int]float64, keys []int) (map[int]float64, []int) {
+	for c := range dst {
+		delete(dst, c)
+	}
+	keys = keys[:0]
+	for u, w := range g.adj[v] {
+		if u == v {
+			continue
+		}
+		c := comm[u]
+		if _, ok := dst[c]; !ok {
+			keys = append(keys, c)
+		}
+		dst[c] += w
+	}
+	sort.Ints(keys)
+	return dst, keys
+}
+
+// state is a partition of the nodes of a graph.
+type state struct {
+	g     *graph
+	gamma float64
+	comm  []int
+	tot   []float64
+	size  []int
+}
+
+func newState(g *graph, gamma float64, comm []int) *state {
+	s := &state{g: g, gamma: gamma, comm: comm, tot: make([]float64, len(comm)), size: make([]int, len(comm))}
+	for v, c := range comm {
+		s.tot[c] += g.deg[v]
+		s.size[c]++
+	}
+	return s
+}
+
+// gain returns the modularity gain, scaled by m, of moving the isolated node v into
+// community c to which it has edges of total weight kin.
+func (s *state) gain(v, c int, kin float64) float64 {
+	return kin - s.gamma*s.tot[c]*s.g.deg[v]/s.g.m2
+}
+
+func (s *state) remove(v int) {
+	c := s.comm[v]
+	s.tot[c] -= s.g.deg[v]
+	s.size[c]--
+}
+
+func (s *state) insert(v, c int) {
+	s.comm[v] = c
+	s.tot[c] += s.g.deg[v]
+	s.size[c]++
+}
+
+// best returns the community of those in keys, or the current community of v,
+// giving the greatest modularity gain for v, which must have been removed from
+// its community. Ties are broken in favour of the current community and then
+// the lowest numbered community.
+func (s *state) best(v int, links map[int]float64, keys []int) (int, float64) {
+	c0 := s.comm[v]
+	best, max := c0, s.gain(v, c0, links[c0])
+	for _, c := range keys {
+		if g := s.gain(v, c, links[c]); g > max {
+			best, max = c, g
+		}
+	}
+	return best, max
+}
+
+// identity returns the labels of a partition of n nodes into singletons.
+func identity(n int) []int {
+	l := make([]int, n)
+	for i := range l {
+		l[i] = i
+	}
+	return l
+}
+
+// relabel renumbers the labels in l from zero in order of first appearance,
+// returning the number of distinct labels.
+func relabel(l []int) int {
+	id := make(map[int]int)
+	for i, c := range l {
+		n, ok := id[c]
+		if !ok {
+			n = len(id)
+			id[c] = n
+		}
+		l[i] = n
+	}
+	return len(id)
+}
+
+// modularity returns the modularity of the partition labels of g at resolution
+// gamma.
+func modularity(g *graph, labels []int, gamma float64) float64 {
+	if g.m2 == 0 {
+		return 0
+	}
+	n := relabel(append([]int(nil), labels...))
+	in := make(map[int]float64, n)
+	tot := make(map[int]float64, n)
+	for u, nbrs := range g.adj {
+		for v, w := range nbrs {
+			if labels[u] == labels[v] {
+				in[labels[u]] += w
+			}
+		}
+		tot[labels[u]] += g.deg[u]
+	}
+	var q float64
+	for c, t := range tot {
+		q += in[c]/g.m2 - gamma*(t/g.m2)*(t/g.m2)
+	}
+	return q
+}
+
+func partition(g *graph, labels []int, gamma float64) Partition {
+	relabel(labels)
+	return Partition{Labels: labels, Modularity: modularity(g, labels, gamma)}
+}
+
+// Louvain returns the partition of g found by the Louvain algorithm, maximising
+// modularity at the given resolution. Higher resolutions give more, smaller
+// communities. Communities found by Louvain may be internally disconnected.
+func Louvain(g Graph, resolution float64) (Partition, error) {
+	if resolution <= 0 {
+		return Partition{}, errors.New("community: invalid resolution")
+	}
+	orig, err := newGraph(g)
+	if err != nil {
+		return Partition{}, err
+	}
+
+	h := orig
+	node := identity(len(g)) // node holds the aggregate node of each original node.
+	var (
+		links = make(map[int]float64)
+		keys  []int
+	)
+	for {
+		s := newState(h, resolution, identity(len(h.adj)))
+		var changed bool
+		for {
+			var moved int
+			for v := range h.adj {
+				links, keys = h.links(v, s.comm, links, keys)
+				c0 := s.comm[v]
+				s.remove(v)
+				c, _ := s.best(v, links, keys)
+				s.insert(v, c)
+				if c != c0 {
+					moved++
+				}
+			}
+			if moved == 0 {
+				break
+			}
+			changed = true
+		}
+		if !changed {
+			break
+		}
+		n := relabel(s.comm)
+		for i, v := range node {
+			node[i] = s.comm[v]
+		}
+		h = h.aggregate(s.comm, n)
+	}
+	return partition(orig, node, resolution), nil
+}
+
+// Leiden returns the partition of g found by the Leiden algorithm, maximising
+// modularity at the given resolution. Unlike Louvain, the communities found are
+// guaranteed to be connected. Leiden uses the global math/rand source to order
+// node visits.
+func Leiden(g Graph, resolution float64) (Partition, error) {
+	return leiden(g, resolution, rand.Perm)
+}
+
+// LeidenWith returns a Method that runs the Leiden algorithm as described for
+// Leiden, ordering node visits using rnd. If rnd is nil, the global math/rand
+// source is used.
+func LeidenWith(rnd *rand.Rand) Method {
+	perm := rand.Perm
+	if rnd != nil {
+		perm = rnd.Perm
+	}
+	return func(g Graph, resolution float64) (Partition, error) {
+		return leiden(g, resolution, perm)
+	}
+}
+
+// leiden runs the Leiden algorithm, ordering node visits by permutations
+// returned by perm.
+func leiden(g Graph, resolution float64, perm func(n int) []int) (Partition, error) {
+	if resolution <= 0 {
+		return Partition{}, errors.New("community: invalid resolution")
+	}
+	orig, err := newGraph(g)
+	if err != nil {
+		return Partition{}, err
+	}
+
+	h := orig
+	node := identity(len(g))
+	s := newState(h, resolution, identity(len(h.adj)))
+	for {
+		moveFast(s, perm)
+		n := relabel(s.comm)
+		if n == len(h.adj) {
+			break
+		}
+
+		refined := refine(s, n, perm)
+		r := relabel(refined)
+		if r == len(h.adj) {
+			// The refinement merged no nodes, so aggregating by it
+			// would not change the graph. Aggregate by the unrefined
+			// partition to ensure progress.
+			copy(refined, s.comm)
+			r = n
+		}
+		for i, v := range node {
+			node[i] = refined[v]
+		}
+		comm := make([]int, r)
+		for v, c := range refined {
+			comm[c] = s.comm[v]
+		}
+		h = h.aggregate(refined, r)
+		s = newState(h, resolution, comm)
+	}
+	labels := make([]int, len(node))
+	for i, v := range node {
+		labels[i] = s.comm[v]
+	}
+	return partition(orig, labels, resolution), nil
+}
+
+// moveFast performs the fast local moving phase of the Leiden algorithm, visiting
+// nodes from a queue, initially in the order given by perm, that is replenished
+// with the neighbors of moved nodes. Nodes may be moved into an empty community.
+func moveFast(s *state, perm func(n int) []int) {
+	n := len(s.comm)
+	var (
+		queue   = perm(n)
+		inQueue = make([]bool, n)
+		empty   []int
+		links   = make(map[int]float64)
+		keys    []int
+	)
+	for _, v := range queue {
+		inQueue[v] = true
+	}
+	for c, sz := range s.size {
+		if sz == 0 {
+			empty = append(empty, c)
+		}
+	}
+	for len(queue) != 0 {
+		v := queue[0]
+		queue = queue[1:]
+		inQueue[v] = false
+
+		links, keys = s.g.links(v, s.comm, links, keys)
+		c0 := s.comm[v]
+		s.remove(v)
+		c, max := s.best(v, links, keys)
+		if max < 0 {
+			// Moving v into an empty community has zero gain. If c0
+			// is now empty it will have been chosen by best, so an
+			// empty community is always available here.
+			c = empty[len(empty)-1]
+			empty = empty[:len(empty)-1]
+		}
+		s.insert(v, c)
+		if s.size[c0] == 0 {
+			empty = append(empty, c0)
+		}
+		if c == c0 {
+			continue
+		}
+		for u := range s.g.adj[v] {
+			if u != v && !inQueue[u] && s.comm[u] != c {
+				inQueue[u] = true
+				queue = append(queue, u)
+			}
+		}
+	}
+}
+
+// refine returns a refinement of the n communities of s in which each refined
+// community is a well-connected subset of a community of s. Nodes are merged only
+// into refined communities that are themselves well connected to the rest of
+// their community, and only if doing so does not decrease modularity. The nodes of
+// each community are visited in the order given by perm.
+func refine(s *state, n int, perm func(n int) []int) []int {
+	g := s.g
+	members := make([][]int, n)
+	for v, c := range s.comm {
+		members[c] = append(members[c], v)
+	}
+
+	refined := identity(len(s.comm))
+	tot := append([]float64(nil), g.deg...)
+	size := make([]int, len(s.comm))
+	ext := make([]float64, len(s.comm)) // Weight of edges from a refined community to the rest of its community.
+	for i := range size {
+		size[i] = 1
+	}
+	for v, c := range s.comm {
+		for u, w := range g.adj[v] {
+			if u != v && s.comm[u] == c {
+				ext[v] += w
+			}
+		}
+	}
+
+	links := make(map[int]float64)
+	var keys []int
+	for c, m := range members {
+		K := s.tot[c]
+		for _, i := range perm(len(m)) {
+			v := m[i]
+			if size[refined[v]] != 1 {
+				continue
+			}
+			if ext[v] < s.gamma*g.deg[v]*(K-g.deg[v])/g.m2 {
+				continue
+			}
+			for k := range links {
+				delete(links, k)
+			}
+			keys = keys[:0]
+			for u, w := range g.adj[v] {
+				if u == v || s.comm[u] != c {
+					continue
+				}
+				r := refined[u]
+				if _, ok := links[r]; !ok {
+					keys = append(keys, r)
+				}
+				links[r] += w
+			}
+			sort.Ints(keys)
+
+			best, max := -1, 0.
+			for _, r := range keys {
+				if r == refined[v] || ext[r] < s.gamma*tot[r]*(K-tot[r])/g.m2 {
+					continue
+				}
+				if d := links[r] - s.gamma*g.deg[v]*tot[r]/g.m2; d >= max {
+					if best < 0 || d > max {
+						best, max = r, d
+					}
+				}
+			}
+			if best < 0 {
+				continue
+			}
+			r0 := refined[v]
+			ext[best] += ext[r0] - 2*links[best]
+			tot[best] += tot[r0]
+			size[best] += size[r0]
+			tot[r0], size[r0], ext[r0] = 0, 0, 0
+			refined[v] = best
+		}
+	}
+	return refined
+}
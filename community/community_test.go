@@ -0,0 +1,122 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package community_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/community"
+	"github.com/biogo/cluster/knn"
+
+	"math"
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+// cliques returns a graph of n cliques of size m joined in a ring by single edges.
+func cliques(n, m int) community.Graph {
+	g := make(community.Graph, n*m)
+	add := func(i, j int) {
+		g[i] = append(g[i], community.Edge{To: j, Weight: 1})
+		g[j] = append(g[j], community.Edge{To: i, Weight: 1})
+	}
+	for c := 0; c < n; c++ {
+		for i := 0; i < m; i++ {
+			for j := 0; j < i; j++ {
+				add(c*m+i, c*m+j)
+			}
+		}
+		add(c*m, ((c+1)%n)*m+1)
+	}
+	return g
+}
+
+func (s *S) TestMethods(c *check.C) {
+	for _, method := range []community.Method{community.Louvain, community.Leiden, community.LeidenWith(rand.New(rand.NewSource(1)))} {
+		p, err := method(cliques(6, 5), 1)
+		c.Assert(err, check.Equals, nil)
+		var want []cluster.Indices
+		for i := 0; i < 6; i++ {
+			want = append(want, cluster.Indices{i * 5, i*5 + 1, i*5 + 2, i*5 + 3, i*5 + 4})
+		}
+		c.Check(p.Communities(), check.DeepEquals, want)
+		// Each clique has 10 internal edges, the graph has 66 edges.
+		q := 6 * (10./66 - math.Pow(22./132, 2))
+		c.Check(math.Abs(p.Modularity-q) < 1e-12, check.Equals, true)
+
+		// At a low resolution cliques are merged.
+		p, err = method(cliques(6, 5), 0.05)
+		c.Assert(err, check.Equals, nil)
+		c.Check(len(p.Communities()) < 6, check.Equals, true)
+	}
+}
+
+// isConnected returns whether the nodes in m induce a connected subgraph of g.
+func isConnected(g community.Graph, m cluster.Indices) bool {
+	in := make(map[int]bool)
+	for _, v := range m {
+		in[v] = true
+	}
+	seen := map[int]bool{m[0]: true}
+	stack := []int{m[0]}
+	for len(stack) != 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, e := range g[v] {
+			if in[e.To] && !seen[e.To] {
+				seen[e.To] = true
+				stack = append(stack, e.To)
+			}
+		}
+	}
+	return len(seen) == len(m)
+}
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+func (s *S) TestLeidenConnected(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var data Points
+	for _, m := range []float64{0, 10, 20} {
+		for i := 0; i < 100; i++ {
+			data = append(data, []float64{m + rnd.NormFloat64(), rnd.NormFloat64()})
+		}
+	}
+	kg, err := knn.Exact(data, 10)
+	c.Assert(err, check.Equals, nil)
+	g := community.FromKNN(kg)
+	for _, res := range []float64{0.5, 1, 2} {
+		p, err := community.LeidenWith(rand.New(rand.NewSource(1)))(g, res)
+		c.Assert(err, check.Equals, nil)
+		for _, m := range p.Communities() {
+			c.Check(isConnected(g, m), check.Equals, true)
+		}
+		again, err := community.LeidenWith(rand.New(rand.NewSource(1)))(g, res)
+		c.Assert(err, check.Equals, nil)
+		c.Check(again.Communities(), check.DeepEquals, p.Communities())
+		lp, err := community.Louvain(g, res)
+		c.Assert(err, check.Equals, nil)
+		c.Check(p.Modularity > 0 && lp.Modularity > 0, check.Equals, true)
+	}
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := community.Louvain(nil, 1)
+	c.Check(err, check.ErrorMatches, "community: no nodes")
+	_, err = community.Leiden(community.Graph{{{To: 1, Weight: 1}}}, 1)
+	c.Check(err, check.ErrorMatches, "community: edge out of range")
+	_, err = community.Leiden(cliques(2, 2), 0)
+	c.Check(err, check.ErrorMatches, "community: invalid resolution")
+}
@@ -0,0 +1,168 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package consensus provides consensus clustering of a set of partitions by
+// evidence accumulation in a co-association matrix.
+//
+// Reference:
+//
+//	Fred ALN, Jain AK. Combining multiple clusterings using evidence accumulation.
+//	IEEE Transactions on Pattern Analysis and Machine Intelligence 27(6):835-850
+//	(2005).
+package consensus
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+)
+
+// Labels returns the cluster label of each value held by c.
+func Labels(c cluster.Clusterer) []int {
+	vals := c.Values()
+	l := make([]int, len(vals))
+	for i, v := range vals {
+		l[i] = v.Cluster()
+	}
+	return l
+}
+
+// CoAssociation is a co-association matrix holding, for each pair of elements, the
+// fraction of a set of partitions that place the pair in the same cluster.
+type CoAssociation struct {
+	n int
+
+	// d holds the lower triangle of the matrix.
+	d []float64
+}
+
+// NewCoAssociation returns the co-association matrix of the partitions described by
+// the label vectors in labels. All label vectors must have the same length.
+// Elements with a negative label in a partition are considered to not be
+// co-clustered with any other element by that partition.
+func NewCoAssociation(labels ...[]int) (*CoAssociation, error) {
+	if len(labels) == 0 {
+		return nil, errors.New("consensus: no partitions")
+	}
+	n := len(labels[0])
+	for _, l := range labels[1:] {
+		if len(l) != n {
+			return nil, errors.New("consensus: mismatched partition lengths")
+		}
+	}
+	c := &CoAssociation{n: n, d: make([]float64, n*(n-1)/2)}
+	inv := 1 / float64(len(labels))
+	for _, l := range labels {
+		for i := 1; i < n; i++ {
+			if l[i] < 0 {
+				continue
+			}
+			row := c.d[i*(i-1)/2:]
+			for j, lj := range l[:i] {
+				if lj == l[i] {
+					row[j] += inv
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+// Len returns the number of elements in the partitions.
+func (c *CoAssociation) Len() int { return c.n }
+
+// At returns the fraction of partitions that placed elements i and j in the same
+// cluster.
+func (c *CoAssociation) At(i, j int) float64 {
+	if i == j {
+		return 1
+	}
+	if i < j {
+		i, j = j, i
+	}
+	return c.d[i*(i-1)/2+j]
+}
+
+// Distance returns the co-association distance, 1-c.At(i, j), between elements i
+// and j, allowing a CoAssociation to be used as a set of pairwise distances by
+// hierarchical clustering methods.
+func (c *CoAssociation) Distance(i, j int) float64 { return 1 - c.At(i, j) }
+
+// Partition returns the consensus partition of the elements as a label vector.
+// Elements i and j are placed in the same consensus cluster when they are connected
+// by a chain of pairs with co-association greater than threshold. Labels are
+// numbered from zero in order of the lowest index element of each cluster.
+func (c *CoAssociation) Partition(threshold float64) []int {
+	parent := make([]int, c.n)
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	for i := 1; i < c.n; i++ {
+		row := c.d[i*(i-1)/2:]
+		for j := 0; j < i; j++ {
+			if row[j] > threshold {
+				ri, rj := find(i), find(j)
+				if ri != rj {
+					parent[ri] = rj
+				}
+			}
+		}
+	}
+
+	l := make([]int, c.n)
+	id := make(map[int]int)
+	for i := range l {
+		r := find(i)
+		lab, ok := id[r]
+		if !ok {
+			lab = len(id)
+			id[r] = lab
+		}
+		l[i] = lab
+	}
+	return l
+}
+
+// Agreement returns a score for each element describing how consistently the
+// partitions agree with its placement in the partition described by labels. The
+// score of an element is its mean co-association with the other members of its
+// cluster in labels, and is one for elements in singleton clusters. Elements with
+// a negative label have a score of zero.
+func (c *CoAssociation) Agreement(labels []int) ([]float64, error) {
+	if len(labels) != c.n {
+		return nil, errors.New("consensus: mismatched partition lengths")
+	}
+	members := make(map[int][]int)
+	for i, l := range labels {
+		if l >= 0 {
+			members[l] = append(members[l], i)
+		}
+	}
+	a := make([]float64, c.n)
+	for i, l := range labels {
+		if l < 0 {
+			continue
+		}
+		m := members[l]
+		if len(m) == 1 {
+			a[i] = 1
+			continue
+		}
+		var sum float64
+		for _, j := range m {
+			if j != i {
+				sum += c.At(i, j)
+			}
+		}
+		a[i] = sum / float64(len(m)-1)
+	}
+	return a, nil
+}
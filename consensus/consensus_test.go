@@ -0,0 +1,50 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package consensus_test
+
+import (
+	"github.com/biogo/cluster/consensus"
+
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+func (s *S) TestCoAssociation(c *check.C) {
+	runs := [][]int{
+		{0, 0, 0, 1, 1, 2},
+		{1, 1, 0, 0, 0, 2},
+		{0, 0, 0, 1, 1, -1},
+		{2, 2, 2, 1, 1, 0},
+	}
+	co, err := consensus.NewCoAssociation(runs...)
+	c.Assert(err, check.Equals, nil)
+	c.Check(co.Len(), check.Equals, 6)
+	c.Check(co.At(0, 1), check.Equals, 1.)
+	c.Check(co.At(1, 2), check.Equals, 0.75)
+	c.Check(co.At(2, 3), check.Equals, 0.25)
+	c.Check(co.At(5, 5), check.Equals, 1.)
+	c.Check(co.Distance(2, 1), check.Equals, 0.25)
+
+	l := co.Partition(0.5)
+	c.Check(l, check.DeepEquals, []int{0, 0, 0, 1, 1, 2})
+	a, err := co.Agreement(l)
+	c.Assert(err, check.Equals, nil)
+	c.Check(a, check.DeepEquals, []float64{0.875, 0.875, 0.75, 1, 1, 1})
+	c.Check(co.Partition(0.1), check.DeepEquals, []int{0, 0, 0, 0, 0, 1})
+
+	_, err = consensus.NewCoAssociation()
+	c.Check(err, check.ErrorMatches, "consensus: no partitions")
+	_, err = consensus.NewCoAssociation([]int{0, 1}, []int{0})
+	c.Check(err, check.ErrorMatches, "consensus: mismatched partition lengths")
+	_, err = co.Agreement([]int{0})
+	c.Check(err, check.ErrorMatches, "consensus: mismatched partition lengths")
+}
@@ -0,0 +1,250 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dbscan implements density-based spatial clustering of applications with
+// noise (DBSCAN) for ℝⁿ data.
+//
+// Reference:
+//
+//	Ester M, Kriegel H-P, Sander J, Xu X. A density-based algorithm for discovering
+//	clusters in large spatial databases with noise. Proceedings of the Second
+//	International Conference on Knowledge Discovery and Data Mining 226-231 (1996).
+package dbscan
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/spatial"
+
+	"errors"
+)
+
+// Noise is the cluster label of values that are not assigned to any cluster.
+const Noise = -1
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	w       float64
+	cluster int
+	core    bool
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+type center struct {
+	point
+	w       float64
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// values is a collection of value that satisfies cluster.Interface.
+type values []value
+
+func (v values) Len() int               { return len(v) }
+func (v values) Values(i int) []float64 { return v[i].point }
+
+// DBSCAN implements data clustering using the DBSCAN algorithm.
+type DBSCAN struct {
+	eps    float64
+	minPts int
+	index  spatial.Index
+
+	dims    int
+	values  values
+	centers []center
+}
+
+// New creates a new DBSCAN Clusterer object populated with data from an Interface
+// value, data. A value is a core value if the total weight of values within a
+// distance eps of it, including itself, is at least minPts. Values that do not
+// satisfy cluster.Weighter are given a weight of 1.
+func New(data cluster.Interface, eps float64, minPts int) (*DBSCAN, error) {
+	if eps <= 0 {
+		return nil, errors.New("dbscan: invalid eps")
+	}
+	if minPts < 1 {
+		return nil, errors.New("dbscan: invalid minPts")
+	}
+	v, d, err := convert(data)
+	if err != nil {
+		return nil, err
+	}
+	return &DBSCAN{
+		eps:    eps,
+		minPts: minPts,
+		dims:   d,
+		values: v,
+	}, nil
+}
+
+// convert renders data to the internal float64 representation for a DBSCAN.
+func convert(data cluster.Interface) (values, int, error) {
+	if data.Len() == 0 {
+		return nil, 0, &cluster.Error{Pkg: "dbscan", Err: cluster.ErrEmptyData}
+	}
+	va := make(values, data.Len())
+	dim := len(data.Values(0))
+	w, isWeighter := data.(cluster.Weighter)
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dim {
+			return nil, 0, &cluster.Error{Pkg: "dbscan", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...), w: 1, cluster: Noise}
+		if isWeighter {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return va, dim, nil
+}
+
+// SetIndex sets the spatial index used for region queries. If no index is set,
+// a kd-tree is used.
+func (db *DBSCAN) SetIndex(idx spatial.Index) { db.index = idx }
+
+// SetMetric sets the metric used for region queries. It is equivalent to calling
+// SetIndex with a VPTree using m, so m must satisfy the triangle inequality. Cluster
+// centers are located at the weighted mean of their members whatever the metric.
+func (db *DBSCAN) SetMetric(m cluster.Metric) { db.index = spatial.NewVPTree(m) }
+
+// Cluster runs a clustering of the data using the DBSCAN algorithm.
+func (db *DBSCAN) Cluster() error {
+	if db.index == nil {
+		db.index = spatial.NewKDTree()
+	}
+	db.index.Build(db.values)
+	for i := range db.values {
+		db.values[i].cluster = Noise
+		db.values[i].core = false
+	}
+	db.centers = db.centers[:0]
+
+	var (
+		hits    []spatial.Neighbor
+		visited = make([]bool, len(db.values))
+		queue   []int
+	)
+	for i := range db.values {
+		if visited[i] {
+			continue
+		}
+		visited[i] = true
+		hits = db.region(hits[:0], i)
+		if !db.values[i].core {
+			continue
+		}
+
+		c := len(db.centers)
+		db.centers = append(db.centers, center{})
+		db.values[i].cluster = c
+		queue = queue[:0]
+		for _, h := range hits {
+			queue = append(queue, h.Index)
+		}
+		for len(queue) != 0 {
+			j := queue[0]
+			queue = queue[1:]
+			if db.values[j].cluster == Noise {
+				db.values[j].cluster = c
+			}
+			if visited[j] {
+				continue
+			}
+			visited[j] = true
+			hits = db.region(hits[:0], j)
+			if !db.values[j].core {
+				continue
+			}
+			for _, h := range hits {
+				if !visited[h.Index] || db.values[h.Index].cluster == Noise {
+					queue = append(queue, h.Index)
+				}
+			}
+		}
+	}
+
+	for i := range db.centers {
+		db.centers[i].point = make(point, db.dims)
+	}
+	for i, v := range db.values {
+		if v.cluster == Noise {
+			continue
+		}
+		c := &db.centers[v.cluster]
+		c.indices = append(c.indices, i)
+		for j, x := range v.point {
+			c.point[j] += x * v.w
+		}
+		c.w += v.w
+	}
+	for i := range db.centers {
+		c := &db.centers[i]
+		if c.w == 0 {
+			continue
+		}
+		inv := 1 / c.w
+		for j := range c.point {
+			c.point[j] *= inv
+		}
+	}
+
+	return nil
+}
+
+// region appends the eps-neighborhood of value i to dst and marks i as a core
+// value if the weight of the neighborhood is at least minPts.
+func (db *DBSCAN) region(dst []spatial.Neighbor, i int) []spatial.Neighbor {
+	dst = db.index.RangeSet(dst, db.values[i].point, db.eps)
+	var w float64
+	for _, h := range dst {
+		w += db.values[h.Index].w
+	}
+	db.values[i].core = w >= float64(db.minPts)
+	return dst
+}
+
+// Assign returns the cluster of the nearest core value within eps of x, or Noise if
+// there is none or no clusters were found by a previous call to Cluster.
+func (db *DBSCAN) Assign(x []float64) int {
+	if len(db.centers) == 0 {
+		return Noise
+	}
+	for _, h := range db.index.RangeSet(nil, x, db.eps) {
+		if v := db.values[h.Index]; v.core {
+			return v.cluster
+		}
+	}
+	return Noise
+}
+
+// IsCore returns whether value i was found to be a core value by a previous call
+// to Cluster.
+func (db *DBSCAN) IsCore(i int) bool { return db.values[i].core }
+
+// Centers returns the centers of the clusters determined by a previous call to
+// Cluster. The location of each center is the weighted mean of its members. Noise
+// values are not members of any center.
+func (db *DBSCAN) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(db.centers))
+	for i := range db.centers {
+		cs[i] = &db.centers[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the DBSCAN. Values labelled as noise
+// have a Cluster of Noise.
+func (db *DBSCAN) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(db.values))
+	for i := range db.values {
+		vs[i] = &db.values[i]
+	}
+	return vs
+}
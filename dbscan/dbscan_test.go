@@ -0,0 +1,114 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbscan_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/dbscan"
+	"github.com/biogo/cluster/spatial"
+
+	"math"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+// rings returns two concentric rings of n points each with radii 1 and 3,
+// followed by a single outlier.
+func rings(n int) Points {
+	var p Points
+	for _, r := range []float64{1, 3} {
+		for i := 0; i < n; i++ {
+			a := 2 * math.Pi * float64(i) / float64(n)
+			p = append(p, []float64{r * math.Cos(a), r * math.Sin(a)})
+		}
+	}
+	return append(p, []float64{10, 10})
+}
+
+func (s *S) TestDBSCAN(c *check.C) {
+	for _, idx := range []spatial.Index{nil, spatial.NewBallTree(4), spatial.NewVPTree(nil)} {
+		data := rings(40)
+		db, err := dbscan.New(data, 0.5, 3)
+		c.Assert(err, check.Equals, nil)
+		if idx != nil {
+			db.SetIndex(idx)
+		}
+		c.Assert(db.Cluster(), check.Equals, nil)
+
+		cens := db.Centers()
+		c.Assert(len(cens), check.Equals, 2)
+		var want [2]cluster.Indices
+		for i := 0; i < 40; i++ {
+			want[0] = append(want[0], i)
+			want[1] = append(want[1], i+40)
+		}
+		c.Check(cens[0].Members(), check.DeepEquals, want[0])
+		c.Check(cens[1].Members(), check.DeepEquals, want[1])
+		for _, cen := range cens {
+			for _, x := range cen.V() {
+				c.Check(math.Abs(x) < 1e-12, check.Equals, true)
+			}
+		}
+		c.Check(db.Values()[80].Cluster(), check.Equals, dbscan.Noise)
+		c.Check(db.IsCore(0), check.Equals, true)
+		c.Check(db.IsCore(80), check.Equals, false)
+		var p cluster.Predictor = db
+		c.Check(p.Assign([]float64{1.1, 0}), check.Equals, 0)
+		c.Check(p.Assign([]float64{0, -2.8}), check.Equals, 1)
+		c.Check(p.Assign([]float64{2, 0}), check.Equals, dbscan.Noise)
+		c.Check(p.Assign([]float64{10, 10}), check.Equals, dbscan.Noise)
+	}
+}
+
+func (s *S) TestMetric(c *check.C) {
+	data := rings(40)
+	want, err := dbscan.New(data, 0.5, 3)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(want.Cluster(), check.Equals, nil)
+
+	db, err := dbscan.New(data, 0.5, 3)
+	c.Assert(err, check.Equals, nil)
+	db.SetMetric(cluster.Chebyshev)
+	c.Assert(db.Cluster(), check.Equals, nil)
+	c.Check(len(db.Centers()), check.Equals, len(want.Centers()))
+	for i, v := range db.Values() {
+		c.Check(v.Cluster(), check.Equals, want.Values()[i].Cluster())
+	}
+}
+
+func (s *S) TestBorder(c *check.C) {
+	// Value 3 is within eps of the core value 2 but is not itself a core value.
+	data := Points{{0}, {0.1}, {0.2}, {0.6}, {5}}
+	db, err := dbscan.New(data, 0.45, 3)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(db.Cluster(), check.Equals, nil)
+	c.Assert(len(db.Centers()), check.Equals, 1)
+	c.Check(db.Centers()[0].Members(), check.DeepEquals, cluster.Indices{0, 1, 2, 3})
+	c.Check(db.IsCore(3), check.Equals, false)
+	c.Check(db.Values()[4].Cluster(), check.Equals, dbscan.Noise)
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := dbscan.New(Points{}, 1, 1)
+	c.Check(err, check.ErrorMatches, "dbscan: no data")
+	_, err = dbscan.New(Points{{1}}, 0, 1)
+	c.Check(err, check.ErrorMatches, "dbscan: invalid eps")
+	_, err = dbscan.New(Points{{1}}, 1, 0)
+	c.Check(err, check.ErrorMatches, "dbscan: invalid minPts")
+	_, err = dbscan.New(Points{{1}, {1, 2}}, 1, 1)
+	c.Check(err, check.ErrorMatches, "dbscan: mismatched dimensions")
+}
@@ -0,0 +1,355 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package denstream implements the DenStream density-based clustering algorithm for
+// evolving streams of ℝⁿ data.
+//
+// Reference:
+//
+//	Cao F, Ester M, Qian W, Zhou A. Density-based clustering over an evolving data
+//	stream with noise. Proceedings of the SIAM International Conference on Data
+//	Mining 328-339 (2006).
+package denstream
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+)
+
+// Noise is the cluster label of values that are not assigned to any cluster.
+const Noise = -1
+
+// micro is a micro-cluster: a clustering feature with exponentially decaying
+// weight.
+type micro struct {
+	w       float64   // w is the decayed weight.
+	ls      []float64 // ls is the decayed weighted linear sum.
+	ss      float64   // ss is the decayed weighted sum of squared norms.
+	t       float64   // t is the time of the last update.
+	created float64   // created is the time of creation.
+}
+
+// decay applies the decay from the time of the last update to t.
+func (m *micro) decay(t, lambda float64) {
+	if t <= m.t {
+		return
+	}
+	f := math.Exp2(-lambda * (t - m.t))
+	m.w *= f
+	for i := range m.ls {
+		m.ls[i] *= f
+	}
+	m.ss *= f
+	m.t = t
+}
+
+func (m *micro) add(v []float64, w float64) {
+	m.w += w
+	for i, x := range v {
+		m.ls[i] += w * x
+		m.ss += w * x * x
+	}
+}
+
+func (m *micro) center() []float64 {
+	c := make([]float64, len(m.ls))
+	for i, v := range m.ls {
+		c[i] = v / m.w
+	}
+	return c
+}
+
+// radiusWith returns the radius of m after the addition of v with weight w.
+func (m *micro) radiusWith(v []float64, w float64) float64 {
+	tw := m.w + w
+	ss := m.ss
+	var mm float64
+	for i, x := range v {
+		ss += w * x * x
+		c := (m.ls[i] + w*x) / tw
+		mm += c * c
+	}
+	r := ss/tw - mm
+	if r < 0 {
+		return 0
+	}
+	return math.Sqrt(r)
+}
+
+func sqDist(a, b []float64) float64 {
+	var ss float64
+	for i, v := range a {
+		d := v - b[i]
+		ss += d * d
+	}
+	return ss
+}
+
+// DenStream maintains potential and outlier micro-clusters over a stream of
+// weighted, timestamped points. Micro-cluster weights decay by a factor of 2^-λ
+// per unit time.
+type DenStream struct {
+	eps, mu, beta, lambda float64
+
+	dims    int
+	now     float64
+	started bool
+	next    float64 // next is the time of the next pruning.
+	period  float64
+
+	potential []*micro
+	outlier   []*micro
+}
+
+// New returns a new DenStream. Micro-clusters absorb points while their radius is
+// no greater than eps. A potential micro-cluster must have a weight of at least
+// beta·mu, and a macro cluster is formed from potential micro-clusters whose
+// centers are within 2·eps of each other. Weights decay with rate lambda. The
+// value of beta·mu must be greater than 1.
+func New(eps, mu, beta, lambda float64) (*DenStream, error) {
+	if eps <= 0 {
+		return nil, errors.New("denstream: invalid eps")
+	}
+	if beta <= 0 || beta > 1 || beta*mu <= 1 {
+		return nil, errors.New("denstream: invalid density thresholds")
+	}
+	if lambda <= 0 {
+		return nil, errors.New("denstream: invalid decay")
+	}
+	bm := beta * mu
+	return &DenStream{
+		eps:    eps,
+		mu:     mu,
+		beta:   beta,
+		lambda: lambda,
+		period: math.Ceil(math.Log2(bm/(bm-1)) / lambda),
+	}, nil
+}
+
+// Insert adds the point v with weight w observed at time t to the stream. Times
+// must not decrease between calls to Insert.
+func (d *DenStream) Insert(v []float64, w, t float64) error {
+	if d.dims == 0 {
+		d.dims = len(v)
+	} else if len(v) != d.dims {
+		return &cluster.Error{Pkg: "denstream", Err: cluster.ErrDimensionMismatch}
+	}
+	if d.started && t < d.now {
+		return errors.New("denstream: time decreased")
+	}
+	if !d.started {
+		d.started = true
+		d.next = t + d.period
+	}
+	d.now = t
+	if w > 0 {
+		d.merge(v, w, t)
+	}
+	if t >= d.next {
+		d.prune(t)
+		d.next = t + d.period
+	}
+	return nil
+}
+
+// nearest returns the index of the micro-cluster in ms with the center nearest v.
+func nearest(ms []*micro, v []float64) int {
+	best, min := -1, math.Inf(1)
+	for i, m := range ms {
+		if d := sqDist(m.center(), v); d < min {
+			best, min = i, d
+		}
+	}
+	return best
+}
+
+func (d *DenStream) merge(v []float64, w, t float64) {
+	if i := nearest(d.potential, v); i >= 0 {
+		m := d.potential[i]
+		m.decay(t, d.lambda)
+		if m.radiusWith(v, w) <= d.eps {
+			m.add(v, w)
+			return
+		}
+	}
+	if i := nearest(d.outlier, v); i >= 0 {
+		m := d.outlier[i]
+		m.decay(t, d.lambda)
+		if m.radiusWith(v, w) <= d.eps {
+			m.add(v, w)
+			if m.w > d.beta*d.mu {
+				d.outlier = append(d.outlier[:i], d.outlier[i+1:]...)
+				d.potential = append(d.potential, m)
+			}
+			return
+		}
+	}
+	m := &micro{ls: make([]float64, len(v)), t: t, created: t}
+	m.add(v, w)
+	if m.w > d.beta*d.mu {
+		d.potential = append(d.potential, m)
+	} else {
+		d.outlier = append(d.outlier, m)
+	}
+}
+
+// prune removes potential micro-clusters whose weight has decayed below beta·mu
+// and outlier micro-clusters whose weight is below that expected of a micro-cluster
+// that could grow into a potential micro-cluster.
+func (d *DenStream) prune(t float64) {
+	p := d.potential[:0]
+	for _, m := range d.potential {
+		m.decay(t, d.lambda)
+		if m.w >= d.beta*d.mu {
+			p = append(p, m)
+		}
+	}
+	d.potential = p
+
+	o := d.outlier[:0]
+	for _, m := range d.outlier {
+		m.decay(t, d.lambda)
+		xi := (math.Exp2(-d.lambda*(t-m.created+d.period)) - 1) / (math.Exp2(-d.lambda*d.period) - 1)
+		if m.w >= xi {
+			o = append(o, m)
+		}
+	}
+	d.outlier = o
+}
+
+// Len returns the number of potential and outlier micro-clusters held.
+func (d *DenStream) Len() (potential, outlier int) { return len(d.potential), len(d.outlier) }
+
+// Snapshot returns a Clusterer over the potential micro-clusters as they are at the
+// time of the most recent insertion. The snapshot is independent of subsequent
+// insertions.
+func (d *DenStream) Snapshot() *Snapshot {
+	s := &Snapshot{eps: d.eps, mu: d.mu}
+	for _, m := range d.potential {
+		m.decay(d.now, d.lambda)
+		s.values = append(s.values, value{point: m.center(), w: m.w, cluster: Noise})
+	}
+	return s
+}
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	w       float64
+	cluster int
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+type center struct {
+	point
+	w       float64
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// Snapshot is the offline macro clustering of a set of potential micro-clusters.
+// The Values of a Snapshot are the centers of the potential micro-clusters weighted
+// by their decayed weights.
+type Snapshot struct {
+	eps, mu float64
+	values  []value
+	centers []center
+}
+
+// Cluster runs the offline DBSCAN-style clustering of the micro-clusters. Micro-
+// clusters with weight of at least mu are core micro-clusters, and micro-clusters
+// within 2·eps of a core micro-cluster are placed in its macro cluster. Micro-
+// clusters not reachable from a core micro-cluster are labelled Noise.
+func (s *Snapshot) Cluster() error {
+	s.centers = s.centers[:0]
+	for i := range s.values {
+		s.values[i].cluster = Noise
+	}
+	r := 4 * s.eps * s.eps
+	for i := range s.values {
+		if s.values[i].cluster != Noise || s.values[i].w < s.mu {
+			continue
+		}
+		c := len(s.centers)
+		s.centers = append(s.centers, center{})
+		s.values[i].cluster = c
+		queue := []int{i}
+		for len(queue) != 0 {
+			j := queue[0]
+			queue = queue[1:]
+			if s.values[j].w < s.mu {
+				continue
+			}
+			for k := range s.values {
+				if s.values[k].cluster == Noise && sqDist(s.values[j].point, s.values[k].point) <= r {
+					s.values[k].cluster = c
+					queue = append(queue, k)
+				}
+			}
+		}
+	}
+	for i, v := range s.values {
+		if v.cluster == Noise {
+			continue
+		}
+		c := &s.centers[v.cluster]
+		if c.point == nil {
+			c.point = make(point, len(v.point))
+		}
+		c.indices = append(c.indices, i)
+		for j, x := range v.point {
+			c.point[j] += x * v.w
+		}
+		c.w += v.w
+	}
+	for i := range s.centers {
+		c := &s.centers[i]
+		for j := range c.point {
+			c.point[j] /= c.w
+		}
+	}
+	return nil
+}
+
+// Assign returns the macro cluster of the micro-cluster nearest to v if v is within
+// eps of its center, and Noise otherwise.
+func (s *Snapshot) Assign(v []float64) int {
+	best, min := Noise, math.Inf(1)
+	for _, m := range s.values {
+		if d := sqDist(m.point, v); d < min {
+			best, min = m.cluster, d
+		}
+	}
+	if min > s.eps*s.eps {
+		return Noise
+	}
+	return best
+}
+
+// Centers returns the macro clusters determined by a previous call to Cluster. The
+// location of each center is the weighted mean of its micro-cluster centers.
+func (s *Snapshot) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(s.centers))
+	for i := range s.centers {
+		cs[i] = &s.centers[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the micro-clusters in the Snapshot.
+func (s *Snapshot) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(s.values))
+	for i := range s.values {
+		vs[i] = &s.values[i]
+	}
+	return vs
+}
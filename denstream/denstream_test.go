@@ -0,0 +1,73 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package denstream_test
+
+import (
+	"github.com/biogo/cluster/denstream"
+
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+func (s *S) TestDenStream(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	d, err := denstream.New(1, 10, 0.5, 0.01)
+	c.Assert(err, check.Equals, nil)
+
+	// Two stable groups and a scattering of outliers.
+	var t float64
+	for i := 0; i < 3000; i++ {
+		t += 0.1
+		var v []float64
+		switch i % 10 {
+		case 0:
+			v = []float64{rnd.Float64()*100 - 50, rnd.Float64()*100 - 50}
+		case 1, 2, 3, 4, 5:
+			v = []float64{rnd.NormFloat64() * 0.3, rnd.NormFloat64() * 0.3}
+		default:
+			v = []float64{20 + rnd.NormFloat64()*0.3, rnd.NormFloat64() * 0.3}
+		}
+		c.Assert(d.Insert(v, 1, t), check.Equals, nil)
+	}
+
+	snap := d.Snapshot()
+	c.Assert(snap.Cluster(), check.Equals, nil)
+	c.Check(len(snap.Centers()), check.Equals, 2)
+	a, b := snap.Assign([]float64{0, 0}), snap.Assign([]float64{20, 0})
+	c.Check(a, check.Not(check.Equals), denstream.Noise)
+	c.Check(b, check.Not(check.Equals), denstream.Noise)
+	c.Check(a, check.Not(check.Equals), b)
+	c.Check(snap.Assign([]float64{-40, 40}), check.Equals, denstream.Noise)
+
+	// The first group disappears from the stream and decays away.
+	for i := 0; i < 30000; i++ {
+		t += 0.1
+		c.Assert(d.Insert([]float64{20 + rnd.NormFloat64()*0.3, rnd.NormFloat64() * 0.3}, 1, t), check.Equals, nil)
+	}
+	snap = d.Snapshot()
+	c.Assert(snap.Cluster(), check.Equals, nil)
+	c.Check(len(snap.Centers()), check.Equals, 1)
+	c.Check(snap.Assign([]float64{0, 0}), check.Equals, denstream.Noise)
+
+	c.Check(d.Insert([]float64{0, 0}, 1, 0), check.ErrorMatches, "denstream: time decreased")
+	c.Check(d.Insert([]float64{0}, 1, t), check.ErrorMatches, "denstream: mismatched dimensions")
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := denstream.New(0, 10, 0.5, 0.01)
+	c.Check(err, check.ErrorMatches, "denstream: invalid eps")
+	_, err = denstream.New(1, 1, 0.5, 0.01)
+	c.Check(err, check.ErrorMatches, "denstream: invalid density thresholds")
+	_, err = denstream.New(1, 10, 0.5, 0)
+	c.Check(err, check.ErrorMatches, "denstream: invalid decay")
+}
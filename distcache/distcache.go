@@ -0,0 +1,119 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package distcache provides memoizing stores of pairwise distances so that distances
+// between elements of a cluster.Interface can be shared between the phases of an
+// analysis without being recomputed.
+//
+// The types in this package are not safe for concurrent use.
+package distcache
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"container/list"
+	"math"
+)
+
+// Matrix is a lazily filled triangular matrix of the distances between all pairs
+// of elements of a cluster.Interface. A Matrix holds n(n-1)/2 distances for n
+// elements once full.
+type Matrix struct {
+	data   cluster.Interface
+	metric cluster.Metric
+	d      []float64
+}
+
+// NewMatrix returns a Matrix that caches the distances between elements of data
+// calculated using the metric m.
+func NewMatrix(data cluster.Interface, m cluster.Metric) *Matrix {
+	n := data.Len()
+	d := make([]float64, n*(n-1)/2)
+	for i := range d {
+		d[i] = math.NaN()
+	}
+	return &Matrix{data: data, metric: m, d: d}
+}
+
+// Len returns the number of elements in the underlying data.
+func (c *Matrix) Len() int { return c.data.Len() }
+
+// Distance returns the distance between elements i and j, calculating it if it has
+// not already been stored.
+func (c *Matrix) Distance(i, j int) float64 {
+	if i == j {
+		return 0
+	}
+	if i < j {
+		i, j = j, i
+	}
+	k := i*(i-1)/2 + j
+	d := c.d[k]
+	if math.IsNaN(d) {
+		d = c.metric.Distance(c.data.Values(i), c.data.Values(j))
+		c.d[k] = d
+	}
+	return d
+}
+
+// pair is an unordered pair of element indices with i > j.
+type pair struct{ i, j int }
+
+type item struct {
+	pair
+	d float64
+}
+
+// LRU is a bounded cache of the distances between pairs of elements of a
+// cluster.Interface. When the cache is full, the least recently used distance is
+// evicted.
+type LRU struct {
+	data   cluster.Interface
+	metric cluster.Metric
+	cap    int
+	order  *list.List
+	items  map[pair]*list.Element
+}
+
+// NewLRU returns an LRU that holds at most size distances between elements of data
+// calculated using the metric m.
+func NewLRU(data cluster.Interface, m cluster.Metric, size int) *LRU {
+	return &LRU{
+		data:   data,
+		metric: m,
+		cap:    size,
+		order:  list.New(),
+		items:  make(map[pair]*list.Element, size),
+	}
+}
+
+// Len returns the number of elements in the underlying data.
+func (c *LRU) Len() int { return c.data.Len() }
+
+// Distance returns the distance between elements i and j, calculating it if it is
+// not held by the cache.
+func (c *LRU) Distance(i, j int) float64 {
+	if i == j {
+		return 0
+	}
+	if i < j {
+		i, j = j, i
+	}
+	p := pair{i, j}
+	if e, ok := c.items[p]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*item).d
+	}
+	d := c.metric.Distance(c.data.Values(i), c.data.Values(j))
+	if c.cap <= 0 {
+		return d
+	}
+	if c.order.Len() >= c.cap {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.items, e.Value.(*item).pair)
+	}
+	c.items[p] = c.order.PushFront(&item{pair: p, d: d})
+	return d
+}
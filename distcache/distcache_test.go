@@ -0,0 +1,77 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distcache_test
+
+import (
+	"github.com/biogo/cluster/distcache"
+
+	"math"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type line []float64
+
+func (l line) Len() int               { return len(l) }
+func (l line) Values(i int) []float64 { return []float64{l[i]} }
+
+// counter is a Metric that counts the number of distance evaluations.
+type counter struct{ n int }
+
+func (c *counter) Distance(x, y []float64) float64 {
+	c.n++
+	return math.Abs(x[0] - y[0])
+}
+
+var data = line{0, 1, 3, 6, 10}
+
+type cache interface {
+	Len() int
+	Distance(i, j int) float64
+}
+
+func checkDistances(c *check.C, dc cache) {
+	c.Check(dc.Len(), check.Equals, len(data))
+	for i := range data {
+		for j := range data {
+			c.Check(dc.Distance(i, j), check.Equals, math.Abs(data[i]-data[j]))
+		}
+	}
+}
+
+func (s *S) TestMatrix(c *check.C) {
+	m := &counter{}
+	dc := distcache.NewMatrix(data, m)
+	checkDistances(c, dc)
+	c.Check(m.n, check.Equals, len(data)*(len(data)-1)/2)
+	checkDistances(c, dc)
+	c.Check(m.n, check.Equals, len(data)*(len(data)-1)/2)
+}
+
+func (s *S) TestLRU(c *check.C) {
+	m := &counter{}
+	dc := distcache.NewLRU(data, m, 3)
+	dc.Distance(0, 1)
+	dc.Distance(1, 0)
+	c.Check(m.n, check.Equals, 1)
+	dc.Distance(0, 2)
+	dc.Distance(0, 3)
+	dc.Distance(1, 0) // Refresh 1,0 so that 2,0 is evicted.
+	dc.Distance(0, 4)
+	c.Check(m.n, check.Equals, 4)
+	dc.Distance(0, 1)
+	c.Check(m.n, check.Equals, 4)
+	dc.Distance(2, 0)
+	c.Check(m.n, check.Equals, 5)
+	checkDistances(c, dc)
+
+}
@@ -0,0 +1,159 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ensemble implements consensus clustering over the results of a set of
+// base clusterers using evidence accumulation.
+package ensemble
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/consensus"
+
+	"errors"
+	"fmt"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	cluster int
+}
+
+func (v *value) Cluster() int { return v.cluster }
+
+type center struct {
+	point
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// Ensemble implements a meta-clusterer that runs a set of base Clusterers on the same
+// data, accumulates the evidence of co-clustering for each pair of values in a
+// co-association matrix and extracts a consensus partition from that matrix.
+type Ensemble struct {
+	members   []cluster.Clusterer
+	threshold float64
+
+	coassoc *consensus.CoAssociation
+
+	values  []value
+	centers []center
+}
+
+// New returns a new Ensemble that will combine the partitions produced by the
+// Clusterers in members. Each member must be ready to have its Cluster method
+// called; for example, a kmeans.Kmeans must have already been seeded. Values i
+// and j are placed in the same consensus cluster when they are connected by a chain
+// of pairs that are co-clustered by more than the fraction threshold of the members.
+// Values assigned a negative cluster label by a member are considered to not be
+// co-clustered with any other value by that member.
+func New(threshold float64, members ...cluster.Clusterer) (*Ensemble, error) {
+	if len(members) == 0 {
+		return nil, errors.New("ensemble: no members")
+	}
+	if threshold < 0 || threshold >= 1 {
+		return nil, errors.New("ensemble: threshold out of range")
+	}
+	return &Ensemble{
+		members:   members,
+		threshold: threshold,
+	}, nil
+}
+
+// Cluster runs each of the base Clusterers and then determines the consensus
+// partition of the data.
+func (e *Ensemble) Cluster() error {
+	var labels [][]int
+	for i, m := range e.members {
+		err := m.Cluster()
+		if err != nil {
+			return fmt.Errorf("ensemble: member %d: %w", i, err)
+		}
+		vals := m.Values()
+		if i == 0 {
+			e.values = make([]value, len(vals))
+			for j, v := range vals {
+				e.values[j] = value{point: append(point(nil), v.V()...)}
+			}
+		} else if len(vals) != len(e.values) {
+			return errors.New("ensemble: mismatched data lengths")
+		}
+		labels = append(labels, consensus.Labels(m))
+	}
+
+	var err error
+	e.coassoc, err = consensus.NewCoAssociation(labels...)
+	if err != nil {
+		return err
+	}
+	e.partition()
+
+	return nil
+}
+
+// partition extracts the consensus partition from the co-association matrix by
+// finding the connected components of the graph of values linked by co-association
+// greater than the threshold.
+func (e *Ensemble) partition() {
+	e.centers = e.centers[:0]
+	for i, c := range e.coassoc.Partition(e.threshold) {
+		if c == len(e.centers) {
+			e.centers = append(e.centers, center{point: make(point, len(e.values[i].point))})
+		}
+		e.values[i].cluster = c
+		e.centers[c].indices = append(e.centers[c].indices, i)
+	}
+	for i := range e.centers {
+		c := &e.centers[i]
+		for _, j := range c.indices {
+			for k, x := range e.values[j].point {
+				c.point[k] += x
+			}
+		}
+		inv := 1 / float64(len(c.indices))
+		for k := range c.point {
+			c.point[k] *= inv
+		}
+	}
+}
+
+// CoAssociation returns the fraction of members that placed values i and j in the
+// same cluster during the last call to Cluster.
+func (e *Ensemble) CoAssociation(i, j int) float64 { return e.coassoc.At(i, j) }
+
+// Agreement returns the agreement score of each value with its consensus cluster,
+// the mean co-association of the value with the other members of its cluster.
+// Values in singleton clusters have an agreement of one.
+func (e *Ensemble) Agreement() []float64 {
+	l := make([]int, len(e.values))
+	for i, v := range e.values {
+		l[i] = v.cluster
+	}
+	a, _ := e.coassoc.Agreement(l)
+	return a
+}
+
+// Centers returns the centers of the consensus clusters determined by a previous
+// call to Cluster. The location of each center is the mean of its members.
+func (e *Ensemble) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(e.centers))
+	for i := range e.centers {
+		cs[i] = &e.centers[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Ensemble. The values are taken from
+// the first member of the Ensemble.
+func (e *Ensemble) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(e.values))
+	for i := range e.values {
+		vs[i] = &e.values[i]
+	}
+	return vs
+}
@@ -0,0 +1,101 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ensemble_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/ensemble"
+	"github.com/biogo/cluster/kmeans"
+	"github.com/biogo/cluster/meanshift"
+
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Feature struct {
+	ID    string
+	Start int
+	End   int
+}
+
+type Features []*Feature
+
+func (f Features) Len() int               { return len(f) }
+func (f Features) Values(i int) []float64 { return []float64{float64(f[i].Start), float64(f[i].End)} }
+
+var feats = Features{
+	{ID: "0", Start: 1, End: 1700},
+	{ID: "1", Start: 2, End: 1700},
+	{ID: "2", Start: 3, End: 610},
+	{ID: "3", Start: 2, End: 605},
+	{ID: "4", Start: 1, End: 600},
+	{ID: "5", Start: 2, End: 750},
+	{ID: "6", Start: 650, End: 900},
+	{ID: "7", Start: 700, End: 950},
+	{ID: "8", Start: 1000, End: 1700},
+	{ID: "9", Start: 950, End: 1712},
+	{ID: "10", Start: 1000, End: 1650},
+}
+
+func (s *S) TestEnsemble(c *check.C) {
+	var members []cluster.Clusterer
+	for _, k := range []int{4, 5} {
+		km, err := kmeans.NewSeeded(feats, int64(k))
+		c.Assert(err, check.Equals, nil)
+		km.Seed(k)
+		members = append(members, km)
+	}
+	members = append(members, meanshift.New(feats, meanshift.NewTruncGauss(60, 3), 0.1, 10))
+
+	e, err := ensemble.New(0.5, members...)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(e.Cluster(), check.Equals, nil)
+
+	var got []cluster.Indices
+	for _, cen := range e.Centers() {
+		got = append(got, cen.Members())
+	}
+	c.Check(got, check.DeepEquals, []cluster.Indices{{0, 1}, {2, 3, 4}, {5}, {6, 7}, {8, 9, 10}})
+	// Values in a consensus cluster are connected by a chain of co-associations
+	// above the threshold, so each value of a cluster with more than one member
+	// is linked to at least one other, and no value is linked to a value in
+	// another cluster.
+	for i, v := range e.Values() {
+		linked := len(e.Centers()[v.Cluster()].Members()) == 1
+		for j, w := range e.Values() {
+			if i == j {
+				continue
+			}
+			if w.Cluster() == v.Cluster() {
+				linked = linked || e.CoAssociation(i, j) > 0.5
+			} else {
+				c.Check(e.CoAssociation(i, j) <= 0.5, check.Equals, true, check.Commentf("values %d and %d", i, j))
+			}
+		}
+		c.Check(linked, check.Equals, true, check.Commentf("value %d", i))
+	}
+	c.Check(e.CoAssociation(0, 1), check.Equals, 1.)
+	c.Check(e.CoAssociation(0, 8), check.Equals, 0.)
+	for _, a := range e.Agreement() {
+		c.Check(a > 0 && a <= 1, check.Equals, true)
+	}
+}
+
+func (s *S) TestEnsembleErrors(c *check.C) {
+	_, err := ensemble.New(0.5)
+	c.Check(err, check.ErrorMatches, "ensemble: no members")
+	km, _ := kmeans.New(feats)
+	_, err = ensemble.New(1, km)
+	c.Check(err, check.ErrorMatches, "ensemble: threshold out of range")
+	e, _ := ensemble.New(0.5, km)
+	c.Check(e.Cluster(), check.ErrorMatches, "ensemble: member 0: kmeans: no centers")
+}
@@ -0,0 +1,240 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gmeans implements G-means clustering, which chooses the number of
+// k-means clusters by testing each cluster for Gaussianity.
+//
+// Reference:
+//
+//	Hamerly G, Elkan C. Learning the k in k-means. Advances in Neural Information
+//	Processing Systems 16:281-288 (2004).
+package gmeans
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/kmeans"
+
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+// center is a cluster.Center used to set the centers of a kmeans.Kmeans.
+type center struct {
+	point
+}
+
+func (c center) Members() cluster.Indices { return nil }
+
+// subset is a cluster.Interface over a subset of the elements of an Interface.
+type subset struct {
+	data cluster.Interface
+	w    cluster.Weighter
+	idx  cluster.Indices
+}
+
+func (s subset) Len() int               { return len(s.idx) }
+func (s subset) Values(i int) []float64 { return s.data.Values(s.idx[i]) }
+func (s subset) Weight(i int) float64 {
+	if s.w == nil {
+		return 1
+	}
+	return s.w.Weight(s.idx[i])
+}
+
+// Gmeans implements clustering of ℝⁿ data using the G-means algorithm. Starting
+// from a single cluster, each cluster is split in two by k-means and the data of
+// the cluster are projected onto the line joining the two child centers. If the
+// projected data fail an Anderson-Darling test for normality, the cluster is
+// replaced by its children. Splitting continues until all clusters pass.
+type Gmeans struct {
+	data    cluster.Interface
+	alpha   float64
+	maxK    int
+	minSize int
+	km      *kmeans.Kmeans
+	rnd     *rand.Rand // rnd is the source of random choices; nil uses math/rand.
+}
+
+// New creates a new G-means object populated with data from an Interface value,
+// data. Clusters are split when the Anderson-Darling test rejects normality at the
+// significance level alpha. No more than maxK clusters are produced, and clusters
+// with fewer than 8 members are not split.
+func New(data cluster.Interface, alpha float64, maxK int) (*Gmeans, error) {
+	if alpha <= 0 || alpha >= 1 {
+		return nil, errors.New("gmeans: invalid alpha")
+	}
+	if maxK < 1 {
+		return nil, errors.New("gmeans: invalid maximum k")
+	}
+	km, err := kmeans.New(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Gmeans{data: data, alpha: alpha, maxK: maxK, minSize: 8, km: km}, nil
+}
+
+// SetRand sets the source of the random choices made by the k-means clusterings
+// run by Cluster to rnd. If rnd is nil, the global math/rand source is used.
+func (g *Gmeans) SetRand(rnd *rand.Rand) {
+	g.rnd = rnd
+	g.km.SetRand(rnd)
+}
+
+// Cluster runs a clustering of the data using the G-means algorithm.
+func (g *Gmeans) Cluster() error {
+	w, _ := g.data.(cluster.Weighter)
+	g.km.Seed(1)
+	err := g.km.Cluster()
+	if err != nil {
+		return err
+	}
+	for {
+		cens := g.km.Centers()
+		var (
+			next  []cluster.Center
+			split bool
+		)
+		for i, c := range cens {
+			m := c.Members()
+			if len(m) == 0 {
+				continue
+			}
+			if len(m) < g.minSize || len(next)+len(cens)-i >= g.maxK {
+				next = append(next, center{point: c.V()})
+				continue
+			}
+			children, ok, err := g.test(subset{data: g.data, w: w, idx: m})
+			if err != nil {
+				return err
+			}
+			if ok {
+				next = append(next, center{point: c.V()})
+				continue
+			}
+			next = append(next, children...)
+			split = true
+		}
+		if !split {
+			return nil
+		}
+		g.km.SetCenters(next)
+		err = g.km.Cluster()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// test splits the data in s into two clusters and returns the child centers and
+// whether the data projected onto the line joining them is consistent with a
+// normal distribution.
+func (g *Gmeans) test(s subset) (children []cluster.Center, normal bool, err error) {
+	km, err := kmeans.New(s, kmeans.WithRand(g.rnd))
+	if err != nil {
+		return nil, false, err
+	}
+	km.Seed(2)
+	err = km.Cluster()
+	if err != nil {
+		return nil, false, err
+	}
+	cens := km.Centers()
+	a, b := cens[0].V(), cens[1].V()
+	if len(cens[0].Members()) == 0 || len(cens[1].Members()) == 0 {
+		return nil, true, nil
+	}
+	v := make([]float64, len(a))
+	var vv float64
+	for i := range v {
+		v[i] = a[i] - b[i]
+		vv += v[i] * v[i]
+	}
+	if vv == 0 {
+		return nil, true, nil
+	}
+	x := make([]float64, s.Len())
+	for i := range x {
+		var p float64
+		for j, y := range s.Values(i) {
+			p += y * v[j]
+		}
+		x[i] = p / vv
+	}
+	if adPValue(andersonDarling(x)) >= g.alpha {
+		return nil, true, nil
+	}
+	return []cluster.Center{center{point: a}, center{point: b}}, false, nil
+}
+
+// andersonDarling returns the Anderson-Darling statistic for x with the mean and
+// variance estimated from x, corrected for sample size. The order of elements in
+// x is altered.
+func andersonDarling(x []float64) float64 {
+	n := float64(len(x))
+	var mean float64
+	for _, v := range x {
+		mean += v
+	}
+	mean /= n
+	var ss float64
+	for _, v := range x {
+		d := v - mean
+		ss += d * d
+	}
+	sd := math.Sqrt(ss / (n - 1))
+	if sd == 0 {
+		return 0
+	}
+	for i, v := range x {
+		x[i] = (v - mean) / sd
+	}
+	sort.Float64s(x)
+
+	var sum float64
+	for i, v := range x {
+		f0 := phi(v)
+		f1 := phi(x[len(x)-1-i])
+		// Clamp to avoid infinite logarithms for extreme values.
+		f0 = math.Min(math.Max(f0, 1e-300), 1-1e-16)
+		f1 = math.Min(math.Max(f1, 1e-300), 1-1e-16)
+		sum += float64(2*i+1) * (math.Log(f0) + math.Log(1-f1))
+	}
+	a := -n - sum/n
+	return a * (1 + 0.75/n + 2.25/(n*n))
+}
+
+// phi is the standard normal cumulative distribution function.
+func phi(x float64) float64 { return 0.5 * math.Erfc(-x/math.Sqrt2) }
+
+// adPValue returns the approximate p-value of the corrected Anderson-Darling
+// statistic a for a normal distribution with estimated parameters, according
+// to D'Agostino and Stephens (1986).
+func adPValue(a float64) float64 {
+	switch {
+	case a >= 0.6:
+		return math.Exp(1.2937 - 5.709*a + 0.0186*a*a)
+	case a >= 0.34:
+		return math.Exp(0.9177 - 4.279*a - 1.38*a*a)
+	case a >= 0.2:
+		return 1 - math.Exp(-8.318+42.796*a-59.938*a*a)
+	default:
+		return 1 - math.Exp(-13.436+101.14*a-223.73*a*a)
+	}
+}
+
+// Within calculates the sum of squares within each cluster.
+// Returns nil if Cluster has not been called.
+func (g *Gmeans) Within() []float64 { return g.km.Within() }
+
+// Centers returns the centers determined by a previous call to Cluster.
+func (g *Gmeans) Centers() []cluster.Center { return g.km.Centers() }
+
+// Values returns a slice of the values in the Gmeans.
+func (g *Gmeans) Values() []cluster.Value { return g.km.Values() }
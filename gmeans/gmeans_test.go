@@ -0,0 +1,80 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gmeans_test
+
+import (
+	"github.com/biogo/cluster/gmeans"
+
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+func blobs(rnd *rand.Rand, n int, centers ...[]float64) Points {
+	var p Points
+	for _, c := range centers {
+		for i := 0; i < n; i++ {
+			v := make([]float64, len(c))
+			for j, x := range c {
+				v[j] = x + rnd.NormFloat64()
+			}
+			p = append(p, v)
+		}
+	}
+	return p
+}
+
+func (s *S) TestGmeans(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, t := range []struct {
+		centers [][]float64
+	}{
+		{centers: [][]float64{{0, 0}}},
+		{centers: [][]float64{{0, 0}, {10, 0}}},
+		{centers: [][]float64{{0, 0}, {10, 0}, {0, 10}, {10, 10}}},
+	} {
+		data := blobs(rnd, 200, t.centers...)
+		g, err := gmeans.New(data, 0.0001, 20)
+		c.Assert(err, check.Equals, nil)
+		g.SetRand(rnd)
+		c.Assert(g.Cluster(), check.Equals, nil)
+		c.Check(len(g.Centers()), check.Equals, len(t.centers))
+		vals := g.Values()
+		for i, v := range vals {
+			c.Check(v.Cluster(), check.Equals, vals[i/200*200].Cluster())
+		}
+	}
+}
+
+func (s *S) TestMaxK(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	data := blobs(rnd, 200, []float64{0, 0}, []float64{10, 0}, []float64{0, 10}, []float64{10, 10})
+	g, err := gmeans.New(data, 0.0001, 2)
+	c.Assert(err, check.Equals, nil)
+	g.SetRand(rnd)
+	c.Assert(g.Cluster(), check.Equals, nil)
+	c.Check(len(g.Centers()), check.Equals, 2)
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := gmeans.New(Points{{1}}, 0, 1)
+	c.Check(err, check.ErrorMatches, "gmeans: invalid alpha")
+	_, err = gmeans.New(Points{{1}}, 0.01, 0)
+	c.Check(err, check.ErrorMatches, "gmeans: invalid maximum k")
+	_, err = gmeans.New(Points{}, 0.01, 1)
+	c.Check(err, check.ErrorMatches, "kmeans: no data")
+}
@@ -0,0 +1,366 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hdbscan implements hierarchical density-based clustering (HDBSCAN) for
+// ℝⁿ data.
+//
+// Reference:
+//
+//	Campello RJGB, Moulavi D, Sander J. Density-based clustering based on hierarchical
+//	density estimates. Advances in Knowledge Discovery and Data Mining, Lecture Notes
+//	in Computer Science 7819:160-172 (2013).
+package hdbscan
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/mst"
+	"github.com/biogo/cluster/spatial"
+
+	"errors"
+	"math"
+	"sort"
+)
+
+// Noise is the cluster label of values that are not assigned to any cluster.
+const Noise = -1
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	w       float64
+	cluster int
+	prob    float64
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+type center struct {
+	point
+	w         float64
+	stability float64
+	indices   cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// values is a collection of value that satisfies cluster.Interface.
+type values []value
+
+func (v values) Len() int               { return len(v) }
+func (v values) Values(i int) []float64 { return v[i].point }
+
+// HDBSCAN implements data clustering using the HDBSCAN algorithm.
+type HDBSCAN struct {
+	minPts  int
+	minSize int
+	metric  cluster.Metric
+
+	dims    int
+	values  values
+	tree    mst.Tree
+	centers []center
+}
+
+// New creates a new HDBSCAN Clusterer object populated with data from an Interface
+// value, data. The core distance of a value is the distance to its minPts-th nearest
+// neighbor, counting itself, and clusters in the condensed cluster tree must have at
+// least minSize members. Weights are used only to locate cluster centers.
+func New(data cluster.Interface, minPts, minSize int) (*HDBSCAN, error) {
+	if minPts < 1 {
+		return nil, errors.New("hdbscan: invalid minPts")
+	}
+	if minSize < 2 {
+		return nil, errors.New("hdbscan: invalid minimum cluster size")
+	}
+	if data.Len() < 2 {
+		return nil, errors.New("hdbscan: too few data")
+	}
+	va := make(values, data.Len())
+	dim := len(data.Values(0))
+	w, isWeighter := data.(cluster.Weighter)
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dim {
+			return nil, &cluster.Error{Pkg: "hdbscan", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...), w: 1, cluster: Noise}
+		if isWeighter {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return &HDBSCAN{
+		minPts:  minPts,
+		minSize: minSize,
+		dims:    dim,
+		values:  va,
+	}, nil
+}
+
+// link is a node in the single linkage dendrogram of the mutual reachability
+// minimum spanning tree. Nodes 0 to n-1 are the leaves.
+type link struct {
+	left, right int
+	lambda      float64
+	size        int
+}
+
+// condensed is a cluster in the condensed cluster tree.
+type condensed struct {
+	parent    int
+	birth     float64
+	size      int
+	children  []int
+	stability float64
+	selected  bool
+}
+
+// lambda returns the density level corresponding to distance d.
+func lambda(d float64) float64 {
+	if d == 0 {
+		return math.MaxFloat64
+	}
+	return 1 / d
+}
+
+// SetMetric sets the dissimilarity used to calculate core and mutual reachability
+// distances. If m is nil, the default, Euclidean distance is used with kd-tree
+// accelerated queries. Otherwise all pairwise dissimilarities are calculated, so m
+// need not satisfy the triangle inequality, but Cluster takes O(n²) time.
+func (h *HDBSCAN) SetMetric(m cluster.Metric) { h.metric = m }
+
+// Cluster runs a clustering of the data using the HDBSCAN algorithm.
+func (h *HDBSCAN) Cluster() error {
+	n := len(h.values)
+
+	core := make([]float64, n)
+	if h.metric == nil {
+		idx := spatial.NewKDTree()
+		idx.Build(h.values)
+		var hits []spatial.Neighbor
+		for i, v := range h.values {
+			hits = idx.NearestSet(hits[:0], v.point, h.minPts)
+			core[i] = hits[len(hits)-1].Dist
+		}
+		var err error
+		h.tree, err = mst.Boruvka(h.values, core)
+		if err != nil {
+			return err
+		}
+	} else {
+		k := h.minPts
+		if k > n {
+			k = n
+		}
+		d := make([]float64, n)
+		for i, v := range h.values {
+			for j, u := range h.values {
+				d[j] = h.metric.Distance(v.point, u.point)
+			}
+			sort.Float64s(d)
+			core[i] = d[k-1]
+		}
+		h.tree = mst.Prim(n, func(i, j int) float64 {
+			return math.Max(h.metric.Distance(h.values[i].point, h.values[j].point), math.Max(core[i], core[j]))
+		})
+	}
+
+	// Build the single linkage dendrogram.
+	links := make([]link, n, 2*n-1)
+	for i := range links {
+		links[i] = link{left: -1, right: -1, size: 1}
+	}
+	parent := make([]int, 2*n-1)
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+	for _, e := range h.tree {
+		a, b := find(e.U), find(e.V)
+		id := len(links)
+		links = append(links, link{left: a, right: b, lambda: lambda(e.Weight), size: links[a].size + links[b].size})
+		parent[a], parent[b] = id, id
+	}
+
+	// Condense the dendrogram, recording for each value the cluster it falls
+	// out of and the density level at which it does so.
+	var (
+		clusters = []condensed{{parent: -1, size: n}}
+		leaf     = make([]int, n)
+		leave    = make([]float64, n)
+	)
+	var fall func(node, c int, l float64)
+	fall = func(node, c int, l float64) {
+		if node < n {
+			leaf[node] = c
+			leave[node] = l
+			return
+		}
+		fall(links[node].left, c, l)
+		fall(links[node].right, c, l)
+	}
+	type task struct{ node, c int }
+	stack := []task{{node: len(links) - 1, c: 0}}
+	for len(stack) != 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		lk := links[t.node]
+		l, r := lk.left, lk.right
+		ls, rs := links[l].size >= h.minSize, links[r].size >= h.minSize
+		switch {
+		case ls && rs:
+			for _, child := range []int{l, r} {
+				id := len(clusters)
+				clusters = append(clusters, condensed{parent: t.c, birth: lk.lambda, size: links[child].size})
+				clusters[t.c].children = append(clusters[t.c].children, id)
+				stack = append(stack, task{node: child, c: id})
+			}
+		case ls:
+			fall(r, t.c, lk.lambda)
+			stack = append(stack, task{node: l, c: t.c})
+		case rs:
+			fall(l, t.c, lk.lambda)
+			stack = append(stack, task{node: r, c: t.c})
+		default:
+			fall(l, t.c, lk.lambda)
+			fall(r, t.c, lk.lambda)
+		}
+	}
+
+	// Calculate stabilities and select clusters by excess of mass. The root
+	// cluster is never selected.
+	for i, c := range leaf {
+		clusters[c].stability += leave[i] - clusters[c].birth
+	}
+	for i := 1; i < len(clusters); i++ {
+		c := &clusters[i]
+		p := &clusters[c.parent]
+		p.stability += float64(c.size) * (c.birth - p.birth)
+	}
+	total := make([]float64, len(clusters))
+	for i := len(clusters) - 1; i > 0; i-- {
+		c := &clusters[i]
+		var sum float64
+		for _, ch := range c.children {
+			sum += total[ch]
+		}
+		if len(c.children) == 0 || c.stability >= sum {
+			c.selected = true
+			total[i] = c.stability
+			var deselect func(int)
+			deselect = func(j int) {
+				for _, ch := range clusters[j].children {
+					clusters[ch].selected = false
+					deselect(ch)
+				}
+			}
+			deselect(i)
+		} else {
+			total[i] = sum
+		}
+	}
+
+	// Label values by their selected ancestor cluster.
+	label := make([]int, len(clusters))
+	h.centers = h.centers[:0]
+	for i := range clusters {
+		label[i] = Noise
+		if clusters[i].selected {
+			label[i] = len(h.centers)
+			h.centers = append(h.centers, center{point: make(point, h.dims), stability: clusters[i].stability})
+		}
+	}
+	maxLambda := make([]float64, len(h.centers))
+	for i := range h.values {
+		c := leaf[i]
+		for c >= 0 && !clusters[c].selected {
+			c = clusters[c].parent
+		}
+		v := &h.values[i]
+		v.cluster = Noise
+		v.prob = 0
+		if c < 0 {
+			continue
+		}
+		v.cluster = label[c]
+		if leave[i] > maxLambda[v.cluster] {
+			maxLambda[v.cluster] = leave[i]
+		}
+	}
+	for i := range h.values {
+		v := &h.values[i]
+		if v.cluster == Noise {
+			continue
+		}
+		v.prob = leave[i] / maxLambda[v.cluster]
+		c := &h.centers[v.cluster]
+		c.indices = append(c.indices, i)
+		for j, x := range v.point {
+			c.point[j] += x * v.w
+		}
+		c.w += v.w
+	}
+	for i := range h.centers {
+		c := &h.centers[i]
+		if c.w == 0 {
+			continue
+		}
+		inv := 1 / c.w
+		for j := range c.point {
+			c.point[j] *= inv
+		}
+	}
+
+	return nil
+}
+
+// Probability returns the strength of the membership of value i in its cluster as
+// determined by a previous call to Cluster. The probability is the ratio of the
+// density level at which the value leaves its cluster to the maximum such level of
+// any member of the cluster. Noise values have a probability of zero.
+func (h *HDBSCAN) Probability(i int) float64 { return h.values[i].prob }
+
+// Stability returns the stabilities of the clusters determined by a previous call
+// to Cluster. The ith element corresponds to the ith element of Centers.
+func (h *HDBSCAN) Stability() []float64 {
+	s := make([]float64, len(h.centers))
+	for i, c := range h.centers {
+		s[i] = c.stability
+	}
+	return s
+}
+
+// Tree returns the mutual reachability minimum spanning tree constructed by a
+// previous call to Cluster.
+func (h *HDBSCAN) Tree() mst.Tree { return h.tree }
+
+// Centers returns the centers of the clusters determined by a previous call to
+// Cluster. The location of each center is the weighted mean of its members. Noise
+// values are not members of any center.
+func (h *HDBSCAN) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(h.centers))
+	for i := range h.centers {
+		cs[i] = &h.centers[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the HDBSCAN. Values labelled as noise
+// have a Cluster of Noise.
+func (h *HDBSCAN) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(h.values))
+	for i := range h.values {
+		vs[i] = &h.values[i]
+	}
+	return vs
+}
@@ -0,0 +1,132 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hdbscan_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/hdbscan"
+
+	"math"
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+// blob appends n points normally distributed about c with standard deviation sd,
+// drawn using rnd.
+func blob(rnd *rand.Rand, p Points, n int, sd float64, c ...float64) Points {
+	for i := 0; i < n; i++ {
+		v := make([]float64, len(c))
+		for j, x := range c {
+			v[j] = x + rnd.NormFloat64()*sd
+		}
+		p = append(p, v)
+	}
+	return p
+}
+
+func (s *S) TestHDBSCAN(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	// Groups of differing density that no single DBSCAN radius separates
+	// cleanly, and a distant outlier.
+	var data Points
+	data = blob(rnd, data, 50, 0.1, 0, 0)
+	data = blob(rnd, data, 50, 1, 10, 0)
+	data = blob(rnd, data, 50, 3, 0, 30)
+	data = append(data, []float64{100, 100})
+
+	h, err := hdbscan.New(data, 5, 10)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(h.Cluster(), check.Equals, nil)
+	c.Check(len(h.Tree()), check.Equals, len(data)-1)
+
+	cens := h.Centers()
+	c.Assert(len(cens), check.Equals, 3)
+	c.Check(len(h.Stability()), check.Equals, 3)
+	for _, st := range h.Stability() {
+		c.Check(st > 0, check.Equals, true)
+	}
+
+	vals := h.Values()
+	for g := 0; g < 3; g++ {
+		counts := make(map[int]int)
+		for i := g * 50; i < (g+1)*50; i++ {
+			counts[vals[i].Cluster()]++
+		}
+		var best, n int
+		for l, k := range counts {
+			if k > n {
+				best, n = l, k
+			}
+		}
+		c.Check(best, check.Not(check.Equals), hdbscan.Noise)
+		c.Check(n >= 45, check.Equals, true, check.Commentf("group %d: %v", g, counts))
+	}
+	c.Check(vals[150].Cluster(), check.Equals, hdbscan.Noise)
+	c.Check(h.Probability(150), check.Equals, 0.)
+	for i := range vals[:150] {
+		if vals[i].Cluster() != hdbscan.Noise {
+			p := h.Probability(i)
+			c.Check(p > 0 && p <= 1, check.Equals, true)
+		}
+	}
+}
+
+func (s *S) TestMetric(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var data Points
+	data = blob(rnd, data, 30, 0.1, 0, 0)
+	data = blob(rnd, data, 30, 1, 10, 0)
+	data = append(data, []float64{100, 100})
+
+	want, err := hdbscan.New(data, 5, 10)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(want.Cluster(), check.Equals, nil)
+
+	h, err := hdbscan.New(data, 5, 10)
+	c.Assert(err, check.Equals, nil)
+	h.SetMetric(cluster.Euclidean)
+	c.Assert(h.Cluster(), check.Equals, nil)
+	c.Check(len(h.Centers()), check.Equals, len(want.Centers()))
+	// Ties in mutual reachability may order the clusters differently.
+	label := make(map[int]int)
+	for i, v := range h.Values() {
+		l, ok := label[v.Cluster()]
+		if !ok {
+			l = want.Values()[i].Cluster()
+			label[v.Cluster()] = l
+		}
+		c.Check(l, check.Equals, want.Values()[i].Cluster())
+	}
+	var got, sum float64
+	for i, e := range h.Tree() {
+		got += e.Weight
+		sum += want.Tree()[i].Weight
+	}
+	c.Check(math.Abs(got-sum) < 1e-9, check.Equals, true)
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := hdbscan.New(Points{{1}}, 1, 2)
+	c.Check(err, check.ErrorMatches, "hdbscan: too few data")
+	_, err = hdbscan.New(Points{{1}, {2}}, 0, 2)
+	c.Check(err, check.ErrorMatches, "hdbscan: invalid minPts")
+	_, err = hdbscan.New(Points{{1}, {2}}, 1, 1)
+	c.Check(err, check.ErrorMatches, "hdbscan: invalid minimum cluster size")
+	_, err = hdbscan.New(Points{{1}, {1, 2}}, 1, 2)
+	c.Check(err, check.ErrorMatches, "hdbscan: mismatched dimensions")
+}
@@ -0,0 +1,91 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"math"
+	"reflect"
+)
+
+// Divergence is a Bregman divergence of x from y. For every Bregman divergence, the
+// point minimizing the weighted sum of divergences of a set of values from it is
+// the weighted mean of the values, so Lloyd's algorithm minimizes the total
+// divergence of the values from their centers.
+//
+// Reference:
+//
+//	Banerjee A, Merugu S, Dhillon IS, Ghosh J. Clustering with Bregman
+//	divergences. Journal of Machine Learning Research 6:1705-1749 (2005).
+type Divergence func(x, y []float64) float64
+
+var (
+	// SquaredEuclidean is the squared Euclidean distance, the divergence of
+	// standard k-means.
+	SquaredEuclidean Divergence = sqDist
+
+	// KL is the generalized Kullback-Leibler, or I-, divergence,
+	// Σ x log(x/y) - x + y, for non-negative vectors. For probability vectors it
+	// is the Kullback-Leibler divergence. The divergence is infinite when y is
+	// zero at an element where x is not.
+	KL Divergence = func(x, y []float64) float64 {
+		var d float64
+		for i, v := range x {
+			u := y[i]
+			switch {
+			case v == 0:
+				d += u
+			case u == 0:
+				return math.Inf(1)
+			default:
+				d += v*math.Log(v/u) - v + u
+			}
+		}
+		return d
+	}
+
+	// ItakuraSaito is the Itakura-Saito divergence, Σ x/y - log(x/y) - 1, for
+	// positive vectors such as power spectra.
+	ItakuraSaito Divergence = func(x, y []float64) float64 {
+		var d float64
+		for i, v := range x {
+			r := v / y[i]
+			d += r - math.Log(r) - 1
+		}
+		return d
+	}
+)
+
+// SetDivergence sets the Bregman divergence minimized by Cluster. Seeding, Total and
+// Within also use the divergence. If d is nil, the squared Euclidean distance is
+// used. The Elkan and Filtering accelerations require the squared Euclidean
+// distance; assignment is made by exhaustive search when any other divergence is
+// set.
+func (km *Kmeans) SetDivergence(d Divergence) { km.div = d }
+
+// divergenceName returns the name of the divergence used by km for recording in a
+// manifest. Divergences other than those provided by the package are named
+// "custom".
+func (km *Kmeans) divergenceName() string {
+	if km.div == nil {
+		return "squaredEuclidean"
+	}
+	switch reflect.ValueOf(km.div).Pointer() {
+	case reflect.ValueOf(SquaredEuclidean).Pointer():
+		return "squaredEuclidean"
+	case reflect.ValueOf(KL).Pointer():
+		return "kl"
+	case reflect.ValueOf(ItakuraSaito).Pointer():
+		return "itakuraSaito"
+	}
+	return "custom"
+}
+
+// divergence returns the divergence of x from y used by km.
+func (km *Kmeans) divergence(x, y []float64) float64 {
+	if km.div == nil {
+		return sqDist(x, y)
+	}
+	return km.div(x, y)
+}
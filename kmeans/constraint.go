@@ -0,0 +1,139 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"errors"
+	"math"
+)
+
+// Link is a pairwise constraint between the elements of the data with indices A and B.
+type Link struct {
+	A, B int
+}
+
+// constraints holds must-link and cannot-link constraints with must-linked values
+// collapsed into groups.
+type constraints struct {
+	group  []int   // group is the group index of each value.
+	groups [][]int // groups holds the value indices of each group.
+	cannot [][]int // cannot holds the groups cannot-linked with each group.
+
+	mustLink, cannotLink []Link // mustLink and cannotLink are the constraints as set.
+}
+
+// SetConstraints sets the must-link and cannot-link constraints used by subsequent
+// calls to Cluster, in the manner of COP-k-means. Values that are must-linked,
+// directly or transitively, are always assigned to the same center and values that
+// are cannot-linked are never assigned to the same center. Must-linked values are
+// assigned as a group to the center minimizing the weighted sum of squared
+// distances of the group's members. SetConstraints returns an error if a constraint
+// refers to a value outside the data or if a cannot-link joins two must-linked
+// values. Calling SetConstraints with no constraints removes any constraints held.
+//
+// Reference:
+//
+//	Wagstaff K, Cardie C, Rogers S, Schrödl S. Constrained k-means clustering with
+//	background knowledge. Proceedings of the Eighteenth International Conference
+//	on Machine Learning 577-584 (2001).
+func (km *Kmeans) SetConstraints(mustLink, cannotLink []Link) error {
+	if len(mustLink) == 0 && len(cannotLink) == 0 {
+		km.cons = nil
+		return nil
+	}
+	n := len(km.values)
+	valid := func(l Link) bool { return l.A >= 0 && l.A < n && l.B >= 0 && l.B < n }
+
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for _, l := range mustLink {
+		if !valid(l) {
+			return errors.New("kmeans: constraint index out of range")
+		}
+		parent[find(l.A)] = find(l.B)
+	}
+
+	c := &constraints{
+		group:      make([]int, n),
+		mustLink:   append([]Link(nil), mustLink...),
+		cannotLink: append([]Link(nil), cannotLink...),
+	}
+	id := make(map[int]int)
+	for i := range c.group {
+		r := find(i)
+		g, ok := id[r]
+		if !ok {
+			g = len(c.groups)
+			id[r] = g
+			c.groups = append(c.groups, nil)
+		}
+		c.group[i] = g
+		c.groups[g] = append(c.groups[g], i)
+	}
+	c.cannot = make([][]int, len(c.groups))
+	for _, l := range cannotLink {
+		if !valid(l) {
+			return errors.New("kmeans: constraint index out of range")
+		}
+		a, b := c.group[l.A], c.group[l.B]
+		if a == b {
+			return errors.New("kmeans: infeasible constraints")
+		}
+		c.cannot[a] = append(c.cannot[a], b)
+		c.cannot[b] = append(c.cannot[b], a)
+	}
+	km.cons = c
+	return nil
+}
+
+// assignConstrained assigns each group of must-linked values to the nearest center
+// not already taken by a cannot-linked group. Groups are assigned in order of
+// their lowest index member.
+func (km *Kmeans) assignConstrained() (deltas int, err error) {
+	c := km.cons
+	label := make([]int, len(c.groups))
+	for i := range label {
+		label[i] = -1
+	}
+	for g, members := range c.groups {
+		best, min := -1, math.Inf(1)
+	centers:
+		for j, m := range km.means {
+			for _, o := range c.cannot[g] {
+				if label[o] == j {
+					continue centers
+				}
+			}
+			var d float64
+			for _, i := range members {
+				v := km.values[i]
+				d += v.w * km.divergence(v.point, m.point)
+			}
+			if best < 0 || d < min {
+				best, min = j, d
+			}
+		}
+		if best < 0 {
+			return 0, errors.New("kmeans: infeasible constraints")
+		}
+		label[g] = best
+		for _, i := range members {
+			if km.values[i].cluster != best {
+				deltas++
+				km.values[i].cluster = best
+			}
+		}
+	}
+	return deltas, nil
+}
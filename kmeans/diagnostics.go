@@ -0,0 +1,32 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+// Diagnostic holds the statistics of a single iteration of Cluster.
+type Diagnostic struct {
+	Iteration  int     // Iteration is the index of the iteration.
+	Reassigned int     // Reassigned is the number of values that changed cluster.
+	Movement   float64 // Movement is the total distance moved by the non-empty centers.
+	Inertia    float64 // Inertia is the objective after reassignment.
+}
+
+// Diagnostics returns the statistics of each iteration of the last call to Cluster.
+// The inertia is the weighted sum of squared distances from the values to their
+// assigned centers, or the weighted sum of divergences if a divergence has been set.
+func (km *Kmeans) Diagnostics() []Diagnostic { return km.diag }
+
+// inertia returns the weighted sum of the divergences of the values from their
+// assigned centers. If the values were last assigned by filtering, the inertia
+// accumulated by the filtering pass is returned.
+func (km *Kmeans) inertia() float64 {
+	if km.sums != nil {
+		return km.sums.inertia
+	}
+	var sum float64
+	for _, v := range km.values {
+		sum += v.w * km.divergence(v.point, km.means[v.cluster].point)
+	}
+	return sum
+}
@@ -0,0 +1,169 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import "math"
+
+// Acceleration specifies the method used to assign values to their nearest center.
+type Acceleration int
+
+const (
+	// Naive compares each value with every center in each iteration.
+	Naive Acceleration = iota
+
+	// Elkan uses the triangle inequality to avoid distance calculations. Elkan
+	// maintains an upper bound on the distance from each value to its assigned
+	// center, lower bounds on the distance to every center and the distances
+	// between centers, requiring O(nk) additional storage.
+	//
+	// Reference:
+	//
+	//	Elkan C. Using the triangle inequality to accelerate k-means. Proceedings
+	//	of the Twentieth International Conference on Machine Learning 147-153
+	//	(2003).
+	Elkan
+
+	// Filtering stores the values in a kd-tree and filters the set of candidate
+	// centers for each cell of the tree, assigning whole cells to a center once
+	// only a single candidate remains. The weighted sums held by each cell are
+	// added to the assigned center, so the values in a cell assigned as a whole
+	// are visited only when their assignments are read.
+	//
+	// Reference:
+	//
+	//	Kanungo T, Mount DM, Netanyahu NS, Piatko CD, Silverman R, Wu AY. An
+	//	efficient k-means clustering algorithm: analysis and implementation. IEEE
+	//	Transactions on Pattern Analysis and Machine Intelligence 24(7):881-892
+	//	(2002).
+	Filtering
+)
+
+// accelerations holds the names of the accelerations recorded in a manifest.
+var accelerations = [...]string{Naive: "naive", Elkan: "elkan", Filtering: "filtering"}
+
+// SetAcceleration sets the method used to assign values to their nearest centers.
+// The clustering found does not depend on the method, except where a value is
+// equidistant from two or more centers.
+func (km *Kmeans) SetAcceleration(a Acceleration) { km.accel = a }
+
+// bounds holds the state of Elkan's algorithm between iterations.
+type bounds struct {
+	upper []float64   // upper is the upper bound on the distance to the assigned center.
+	lower []float64   // lower holds the lower bounds on the distance to each center.
+	prev  [][]float64 // prev holds the center locations at the last assignment.
+
+	cc []float64 // cc holds the inter-center distances.
+	s  []float64 // s holds half the distance from each center to its nearest neighbor.
+}
+
+func dist(a, b []float64) float64 { return math.Sqrt(sqDist(a, b)) }
+
+// assignElkan assigns each value to its nearest center using Elkan's algorithm and
+// returns the number of values whose assignment changed.
+func (km *Kmeans) assignElkan() int {
+	k := len(km.means)
+	b := km.bounds
+	if b == nil || len(b.prev) != k {
+		return km.initElkan()
+	}
+
+	// Update the bounds for the movement of the centers.
+	for j, m := range km.means {
+		drift := dist(m.point, b.prev[j])
+		if drift == 0 {
+			continue
+		}
+		copy(b.prev[j], m.point)
+		for i := range km.values {
+			l := &b.lower[i*k+j]
+			*l = math.Max(*l-drift, 0)
+			if km.values[i].cluster == j {
+				b.upper[i] += drift
+			}
+		}
+	}
+
+	for j := range km.means {
+		b.s[j] = math.Inf(1)
+	}
+	for j := 0; j < k; j++ {
+		for l := j + 1; l < k; l++ {
+			d := dist(km.means[j].point, km.means[l].point)
+			b.cc[j*k+l], b.cc[l*k+j] = d, d
+			b.s[j] = math.Min(b.s[j], d/2)
+			b.s[l] = math.Min(b.s[l], d/2)
+		}
+	}
+
+	var deltas int
+	for i := range km.values {
+		v := &km.values[i]
+		c := v.cluster
+		if b.upper[i] <= b.s[c] {
+			continue
+		}
+		tight := false
+		lower := b.lower[i*k : (i+1)*k]
+		for j := range km.means {
+			if j == c || b.upper[i] <= lower[j] || b.upper[i] <= b.cc[c*k+j]/2 {
+				continue
+			}
+			if !tight {
+				b.upper[i] = dist(v.point, km.means[c].point)
+				lower[c] = b.upper[i]
+				tight = true
+				if b.upper[i] <= lower[j] || b.upper[i] <= b.cc[c*k+j]/2 {
+					continue
+				}
+			}
+			d := dist(v.point, km.means[j].point)
+			lower[j] = d
+			if d < b.upper[i] {
+				c = j
+				b.upper[i] = d
+			}
+		}
+		if c != v.cluster {
+			v.cluster = c
+			deltas++
+		}
+	}
+	return deltas
+}
+
+// initElkan assigns each value to its nearest center by exhaustive search and
+// initializes the bounds.
+func (km *Kmeans) initElkan() int {
+	k := len(km.means)
+	b := &bounds{
+		upper: make([]float64, len(km.values)),
+		lower: make([]float64, len(km.values)*k),
+		prev:  make([][]float64, k),
+		cc:    make([]float64, k*k),
+		s:     make([]float64, k),
+	}
+	for j, m := range km.means {
+		b.prev[j] = append([]float64(nil), m.point...)
+	}
+	var deltas int
+	for i := range km.values {
+		v := &km.values[i]
+		c, min := -1, math.Inf(1)
+		for j, m := range km.means {
+			d := dist(v.point, m.point)
+			b.lower[i*k+j] = d
+			if d < min {
+				c, min = j, d
+			}
+		}
+		b.upper[i] = min
+		if c != v.cluster {
+			v.cluster = c
+			deltas++
+		}
+	}
+	km.bounds = b
+	return deltas
+}
@@ -0,0 +1,102 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+// EmptyPolicy specifies how Cluster handles a center that is left with no members.
+type EmptyPolicy int
+
+const (
+	// Reseed moves the empty center to the value that is farthest from its
+	// assigned center.
+	Reseed EmptyPolicy = iota
+
+	// Drop removes the empty center, reducing the number of clusters.
+	Drop
+
+	// Split moves the empty center to the member of the cluster with the
+	// greatest total weight that is farthest from its center, splitting that
+	// cluster.
+	Split
+)
+
+// emptyPolicies holds the names of the empty center policies recorded in a manifest.
+var emptyPolicies = [...]string{Reseed: "reseed", Drop: "drop", Split: "split"}
+
+// Empty records the handling of a center that was left with no members.
+type Empty struct {
+	Iteration int         // Iteration is the iteration in which the center became empty.
+	Center    int         // Center is the index of the center when it became empty.
+	Policy    EmptyPolicy // Policy is the policy applied.
+}
+
+// SetEmptyPolicy sets the policy used to handle centers that are left with no
+// members during Cluster. The default policy is Reseed.
+func (km *Kmeans) SetEmptyPolicy(p EmptyPolicy) { km.empty = p }
+
+// Empties returns the empty centers handled during the last call to Cluster.
+func (km *Kmeans) Empties() []Empty { return km.empties }
+
+// fixEmpty applies the empty center policy to each unpinned center with no
+// weight. The locations of all other centers must have been calculated.
+func (km *Kmeans) fixEmpty() {
+	for i := 0; i < len(km.means); i++ {
+		if km.means[i].w != 0 || km.means[i].pinned {
+			continue
+		}
+		km.settle()
+		km.empties = append(km.empties, Empty{Iteration: km.iter, Center: i, Policy: km.empty})
+		switch km.empty {
+		case Drop:
+			if len(km.means) == 1 {
+				return
+			}
+			km.means = append(km.means[:i], km.means[i+1:]...)
+			for j, v := range km.values {
+				if v.cluster > i {
+					km.values[j].cluster--
+				}
+			}
+			i--
+		case Split:
+			largest := -1
+			for j, m := range km.means {
+				if largest < 0 || m.w > km.means[largest].w {
+					largest = j
+				}
+			}
+			if km.means[largest].w == 0 {
+				return
+			}
+			km.reseed(i, largest)
+		default:
+			km.reseed(i, -1)
+		}
+	}
+}
+
+// reseed moves center i to the member of cluster c that is farthest from its
+// center, or to the value farthest from its assigned center if c is negative.
+func (km *Kmeans) reseed(i, c int) {
+	far, max := -1, -1.
+	for j, v := range km.values {
+		if (c >= 0 && v.cluster != c) || km.means[v.cluster].w == 0 {
+			continue
+		}
+		if d := km.divergence(v.point, km.means[v.cluster].point); d > max {
+			far, max = j, d
+		}
+	}
+	if far < 0 {
+		return
+	}
+	v := &km.values[far]
+	old := &km.means[v.cluster]
+	old.w -= v.w
+	old.count--
+	copy(km.means[i].point, v.point)
+	km.means[i].w = v.w
+	km.means[i].count = 1
+	v.cluster = i
+}
@@ -42,7 +42,7 @@ var feats = []*Feature{
 //  epsilon is allowable error, and
 //  effort is number of attempts to achieve error < epsilon for any k.
 func ClusterFeatures(f []*Feature, epsilon float64, effort int) (*kmeans.Kmeans, error) {
-	km, err := kmeans.New(Features(f))
+	km, err := kmeans.NewSeeded(Features(f), 1)
 	if err != nil {
 		return nil, err
 	}
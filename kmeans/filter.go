@@ -0,0 +1,325 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"github.com/biogo/store/kdtree"
+)
+
+// node is an indexed point satisfying the kdtree.Comparable interface.
+type node struct {
+	Point []float64
+	ID    int
+}
+
+func (p *node) Clone() kdtree.Comparable {
+	return &node{Point: append([]float64(nil), p.Point...), ID: p.ID}
+}
+func (p *node) Compare(c kdtree.Comparable, d kdtree.Dim) float64 {
+	q := c.(*node)
+	return p.Point[d] - q.Point[d]
+}
+func (p *node) Dims() int { return len(p.Point) }
+func (p *node) Distance(c kdtree.Comparable) float64 {
+	q := c.(*node)
+	return sqDist(p.Point, q.Point)
+}
+
+// nodes is a collection of node values that satisfies the kdtree.Interface.
+type nodes []*node
+
+func (p nodes) Index(i int) kdtree.Comparable         { return p[i] }
+func (p nodes) Len() int                              { return len(p) }
+func (p nodes) Pivot(d kdtree.Dim) int                { return plane{nodes: p, Dim: d}.Pivot() }
+func (p nodes) Slice(start, end int) kdtree.Interface { return p[start:end] }
+
+// plane wraps a nodes type allowing it to be pivoted on a dimension.
+type plane struct {
+	kdtree.Dim
+	nodes
+}
+
+func (p plane) Less(i, j int) bool { return p.nodes[i].Point[p.Dim] < p.nodes[j].Point[p.Dim] }
+func (p plane) Pivot() int         { return kdtree.Partition(p, kdtree.MedianOfRandoms(p, kdtree.Randoms)) }
+func (p plane) Slice(start, end int) kdtree.SortSlicer {
+	p.nodes = p.nodes[start:end]
+	return p
+}
+func (p plane) Swap(i, j int) { p.nodes[i], p.nodes[j] = p.nodes[j], p.nodes[i] }
+
+// cell is a kd-tree node holding the index of its value, the bounding box of the
+// values in its subtree and the statistics used to assign the whole subtree to a
+// center without visiting its values.
+type cell struct {
+	id          int
+	min, max    []float64
+	left, right *cell
+
+	n   int       // n is the number of values in the subtree.
+	w   float64   // w is the total weight of the values in the subtree.
+	sum []float64 // sum is the weighted sum of the values in the subtree.
+	ss  float64   // ss is the weighted sum of squares about the weighted mean of the subtree.
+
+	// z is the center that the subtree was assigned to as a whole by the
+	// last filtering pass, or -1 if its values were assigned separately.
+	// z is meaningful only if no ancestor of the cell has been assigned
+	// as a whole.
+	z int
+}
+
+// sums holds the weighted sums of the values assigned to each center by a
+// filtering pass and the resulting inertia.
+type sums struct {
+	point   [][]float64
+	w       []float64
+	count   []int
+	inertia float64
+}
+
+// buildCells returns the root of a tree of cells over the values of km.
+func (km *Kmeans) buildCells() *cell {
+	nds := make(nodes, len(km.values))
+	for i, v := range km.values {
+		nds[i] = &node{Point: v.point, ID: i}
+	}
+	return km.newCell(kdtree.New(nds, false).Root)
+}
+
+func (km *Kmeans) newCell(n *kdtree.Node) *cell {
+	if n == nil {
+		return nil
+	}
+	p := n.Point.(*node)
+	v := km.values[p.ID]
+	c := &cell{
+		id:    p.ID,
+		min:   append([]float64(nil), p.Point...),
+		max:   append([]float64(nil), p.Point...),
+		left:  km.newCell(n.Left),
+		right: km.newCell(n.Right),
+		n:     1,
+		w:     v.w,
+		sum:   make([]float64, len(p.Point)),
+		z:     -1,
+	}
+	for d, x := range p.Point {
+		c.sum[d] = v.w * x
+	}
+	for _, s := range []*cell{c.left, c.right} {
+		if s == nil {
+			continue
+		}
+		for d := range c.min {
+			if s.min[d] < c.min[d] {
+				c.min[d] = s.min[d]
+			}
+			if s.max[d] > c.max[d] {
+				c.max[d] = s.max[d]
+			}
+		}
+		// Combine the sums of squares about the two means.
+		c.ss += s.ss
+		if c.w > 0 && s.w > 0 {
+			var d2 float64
+			for d := range c.sum {
+				diff := c.sum[d]/c.w - s.sum[d]/s.w
+				d2 += diff * diff
+			}
+			c.ss += c.w * s.w / (c.w + s.w) * d2
+		}
+		c.n += s.n
+		c.w += s.w
+		for d := range c.sum {
+			c.sum[d] += s.sum[d]
+		}
+	}
+	return c
+}
+
+// assignFiltering assigns each value to its nearest center using the filtering
+// algorithm and returns the number of values whose assignment changed. The
+// weighted sums of the values assigned to each center are accumulated in km.sums.
+// Values in cells assigned as a whole are not labeled until settle is called.
+func (km *Kmeans) assignFiltering() int {
+	if km.cells == nil {
+		km.cells = km.buildCells()
+	}
+	km.sums = &sums{
+		point: make([][]float64, len(km.means)),
+		w:     make([]float64, len(km.means)),
+		count: make([]int, len(km.means)),
+	}
+	cand := make([]int, len(km.means))
+	for i := range cand {
+		cand[i] = i
+		km.sums.point[i] = make([]float64, km.dims)
+	}
+	mid := make([]float64, km.dims)
+	km.unsettled = true
+	return km.filter(km.cells, cand, -1, mid)
+}
+
+// filter assigns the values in the subtree of c to their nearest centers among
+// cand and returns the number of values whose assignment changed. If prev is not
+// negative, an ancestor of c was assigned as a whole to center prev by the last
+// filtering pass.
+func (km *Kmeans) filter(c *cell, cand []int, prev int, mid []float64) int {
+	if c == nil {
+		return 0
+	}
+	if prev < 0 {
+		prev = c.z
+	}
+
+	// Find the candidate nearest the center of the cell and remove the
+	// candidates that are farther than it from every point in the cell.
+	for d := range mid {
+		mid[d] = (c.min[d] + c.max[d]) / 2
+	}
+	best, min := cand[0], sqDist(mid, km.means[cand[0]].point)
+	for _, z := range cand[1:] {
+		if d := sqDist(mid, km.means[z].point); d < min {
+			best, min = z, d
+		}
+	}
+	kept := make([]int, 0, len(cand))
+	for _, z := range cand {
+		if z == best || !km.farther(z, best, c) {
+			kept = append(kept, z)
+		}
+	}
+
+	if len(kept) == 1 {
+		z := kept[0]
+		var deltas int
+		switch {
+		case prev < 0:
+			deltas = km.moved(c, z)
+		case prev != z:
+			deltas = c.n
+		}
+		c.z = z
+		km.addCell(c, z)
+		return deltas
+	}
+
+	var deltas int
+	v := &km.values[c.id]
+	n, min := kept[0], sqDist(v.point, km.means[kept[0]].point)
+	for _, z := range kept[1:] {
+		if d := sqDist(v.point, km.means[z].point); d < min {
+			n, min = z, d
+		}
+	}
+	old := v.cluster
+	if prev >= 0 {
+		old = prev
+	}
+	if n != old {
+		deltas++
+	}
+	v.cluster = n
+	c.z = -1
+	km.addValue(v, n, min)
+	return deltas + km.filter(c.left, kept, prev, mid) + km.filter(c.right, kept, prev, mid)
+}
+
+// farther returns whether center z is no closer than center best to every point
+// in the bounding box of c.
+func (km *Kmeans) farther(z, best int, c *cell) bool {
+	zp, bp := km.means[z].point, km.means[best].point
+	var dz, db float64
+	for d := range zp {
+		// Take the vertex of the box furthest in the direction of z from best.
+		x := c.min[d]
+		if zp[d] > bp[d] {
+			x = c.max[d]
+		}
+		dz += (zp[d] - x) * (zp[d] - x)
+		db += (bp[d] - x) * (bp[d] - x)
+	}
+	return dz >= db
+}
+
+// moved returns the number of values in the subtree of c that were not assigned
+// to center z by the last filtering pass. No ancestor of c may have been assigned
+// as a whole by that pass.
+func (km *Kmeans) moved(c *cell, z int) int {
+	if c == nil {
+		return 0
+	}
+	if c.z >= 0 {
+		if c.z != z {
+			return c.n
+		}
+		return 0
+	}
+	var n int
+	if km.values[c.id].cluster != z {
+		n++
+	}
+	return n + km.moved(c.left, z) + km.moved(c.right, z)
+}
+
+// addCell adds the values in the subtree of c to the sums of center z.
+func (km *Kmeans) addCell(c *cell, z int) {
+	s := km.sums
+	m := km.means[z].point
+	for d, x := range c.sum {
+		s.point[z][d] += x
+	}
+	s.w[z] += c.w
+	s.count[z] += c.n
+	s.inertia += c.ss
+	if c.w > 0 {
+		var d2 float64
+		for d, x := range c.sum {
+			diff := x/c.w - m[d]
+			d2 += diff * diff
+		}
+		s.inertia += c.w * d2
+	}
+}
+
+// addValue adds v, at squared distance d from center z, to the sums of center z.
+func (km *Kmeans) addValue(v *value, z int, d float64) {
+	s := km.sums
+	for j, x := range v.point {
+		s.point[z][j] += v.w * x
+	}
+	s.w[z] += v.w
+	s.count[z]++
+	s.inertia += v.w * d
+}
+
+// settle labels the values in the cells that were assigned as a whole by the
+// last filtering pass, so that the assignment of every value is current. settle
+// must be called before the assignments of the values are read or altered other
+// than by a filtering pass.
+func (km *Kmeans) settle() {
+	if !km.unsettled {
+		return
+	}
+	km.label(km.cells, -1)
+	km.unsettled = false
+}
+
+// label assigns the values in the subtree of c to the center the subtree was
+// assigned to by the last filtering pass, or to z if z is not negative, and
+// clears the assignments of the cells.
+func (km *Kmeans) label(c *cell, z int) {
+	if c == nil {
+		return
+	}
+	if z < 0 {
+		z = c.z
+	}
+	c.z = -1
+	if z >= 0 {
+		km.values[c.id].cluster = z
+	}
+	km.label(c.left, z)
+	km.label(c.right, z)
+}
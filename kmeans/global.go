@@ -0,0 +1,106 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import "math"
+
+// SeedGlobal generates the means for the k-means algorithm according to the global
+// k-means algorithm. Starting from the data mean, centers are added one at a time.
+// For each added center, every value is tried as the initial location of the new
+// center and Lloyd's algorithm is run from the previous solution plus the
+// candidate; the solution with the lowest weighted sum of squares is retained. If
+// fast is true, only the value giving the greatest guaranteed reduction in the sum
+// of squares is tried for each added center. SeedGlobal makes no random choices.
+//
+// The search runs O(nk) clusterings of the data when fast is false, and O(k)
+// clusterings with O(kn²) distance calculations when fast is true, so it is suited
+// to small data sets. The means found are a converged clustering, and the number
+// of iterations and termination limits set by SetLimits and the divergence set by
+// SetDivergence apply to each clustering in the search.
+//
+// Reference:
+//
+//	Likas A, Vlassis N, Verbeek JJ. The global k-means clustering algorithm.
+//	Pattern Recognition 36(2):451-461 (2003).
+func (km *Kmeans) SeedGlobal(k int, fast bool) {
+	km.settle()
+	mean := make(point, km.dims)
+	var w float64
+	for _, v := range km.values {
+		for j := range mean {
+			mean[j] += v.point[j] * v.w
+		}
+		w += v.w
+	}
+	for j := range mean {
+		mean[j] /= w
+	}
+	best := []center{{point: mean}}
+
+	d := make([]float64, len(km.values))
+	for n := 1; n < k; n++ {
+		prev := best
+		km.search(prev).assign()
+		for i, v := range km.values {
+			d[i] = km.divergence(v.point, prev[v.cluster].point)
+		}
+
+		cand := make([]int, 0, len(km.values))
+		if fast {
+			c, max := 0, math.Inf(-1)
+			for i, v := range km.values {
+				var b float64
+				for j, u := range km.values {
+					b += u.w * math.Max(d[j]-km.divergence(u.point, v.point), 0)
+				}
+				if b > max {
+					c, max = i, b
+				}
+			}
+			cand = append(cand, c)
+		} else {
+			for i := range km.values {
+				cand = append(cand, i)
+			}
+		}
+
+		min := math.Inf(1)
+		for _, c := range cand {
+			means := make([]center, n+1)
+			for i, m := range prev {
+				means[i] = center{point: append(point(nil), m.point...)}
+			}
+			means[n] = center{point: append(point(nil), km.values[c].point...)}
+			s := km.search(means)
+			s.Cluster()
+			if e := s.inertia(); e < min {
+				best, min = s.means, e
+			}
+		}
+	}
+
+	km.iter = 0
+	km.run = nil
+	km.seeding = "global"
+	km.means = make([]center, len(best))
+	for i, m := range best {
+		km.means[i] = center{point: append(point(nil), m.point...)}
+	}
+}
+
+// search returns a Kmeans sharing the values and parameters of km with the given
+// means, for use in the global k-means search.
+func (km *Kmeans) search(means []center) *Kmeans {
+	return &Kmeans{
+		dims:    km.dims,
+		values:  km.values,
+		means:   means,
+		source:  km.source,
+		div:     km.div,
+		maxIter: km.maxIter,
+		tol:     km.tol,
+		empty:   km.empty,
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// Harmonic implements clustering of ℝⁿ data according to the k-harmonic means
+// algorithm. The objective minimized is the sum over the data of the harmonic
+// average of the distances from each value to all the centers raised to the power
+// p. Since every value influences every center, the algorithm is much less
+// sensitive to the initial centers than Lloyd's algorithm. After convergence, each
+// value is assigned to its nearest center.
+//
+// Reference:
+//
+//	Zhang B, Hsu M, Dayal U. K-harmonic means - a data clustering algorithm.
+//	Technical Report HPL-1999-124, Hewlett-Packard Laboratories (1999).
+//
+//	Hamerly G, Elkan C. Alternatives to the k-means algorithm that find better
+//	clusterings. Proceedings of the Eleventh International Conference on
+//	Information and Knowledge Management 600-607 (2002).
+type Harmonic struct {
+	p       float64
+	tol     float64
+	maxIter int
+
+	dims   int
+	values []value
+	means  []center
+	source
+}
+
+// NewHarmonic creates a new k-harmonic means object populated with data from an
+// Interface value, data. The distance exponent p must be finite and at least 2; a
+// value of about 3.5 is typically used. Cluster iterates until no center moves by
+// more than tol or until maxIter iterations have been made.
+func NewHarmonic(data cluster.Interface, p, tol float64, maxIter int) (*Harmonic, error) {
+	if !(p >= 2) || math.IsInf(p, 1) {
+		return nil, errors.New("kmeans: invalid harmonic exponent")
+	}
+	v, d, err := convert(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Harmonic{p: p, tol: tol, maxIter: maxIter, dims: d, values: v}, nil
+}
+
+// SetRand sets the source of the random choices made by Seed to rnd. If rnd is nil,
+// the global math/rand source is used.
+func (km *Harmonic) SetRand(rnd *rand.Rand) { km.rnd = rnd }
+
+// Seed generates the initial means for the k-harmonic means algorithm according to
+// the k-means++ algorithm.
+func (km *Harmonic) Seed(k int) {
+	s := Kmeans{dims: km.dims, values: km.values, source: km.source}
+	s.Seed(k)
+	km.means = s.means
+}
+
+// SetCenters sets the locations of the centers to c.
+func (km *Harmonic) SetCenters(c []cluster.Center) {
+	km.means = make([]center, len(c))
+	for i, cv := range c {
+		km.means[i] = center{point: append(point(nil), cv.V()...)}
+	}
+}
+
+// Cluster runs a clustering of the data using the k-harmonic means algorithm.
+func (km *Harmonic) Cluster() error {
+	return km.ClusterContext(context.Background())
+}
+
+// ClusterContext runs a clustering of the data using the k-harmonic means
+// algorithm, checking ctx before each iteration. If ctx is done, ClusterContext
+// returns ctx.Err() and values are assigned to the centers of the last completed
+// iteration.
+func (km *Harmonic) ClusterContext(ctx context.Context) error {
+	if len(km.means) == 0 {
+		return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrNoCenters}
+	}
+	k := len(km.means)
+	d := make([]float64, k)
+	q := make([]float64, k)
+	sum := make([][]float64, k)
+	for j := range sum {
+		sum[j] = make([]float64, km.dims)
+	}
+	var err error
+	for it := 0; it < km.maxIter; it++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		for j := range q {
+			q[j] = 0
+			for l := range sum[j] {
+				sum[j][l] = 0
+			}
+		}
+		for _, v := range km.values {
+			// Distances are clamped away from zero to avoid division by zero
+			// when a value coincides with a center.
+			var hp float64
+			for j, m := range km.means {
+				d[j] = math.Max(math.Sqrt(sqDist(v.point, m.point)), 1e-12)
+				hp += math.Pow(d[j], -km.p)
+			}
+			for j := range km.means {
+				w := v.w * math.Pow(d[j], -km.p-2) / (hp * hp)
+				q[j] += w
+				for l, x := range v.point {
+					sum[j][l] += w * x
+				}
+			}
+		}
+		var moved float64
+		for j := range km.means {
+			if q[j] == 0 {
+				continue
+			}
+			for l := range sum[j] {
+				sum[j][l] /= q[j]
+			}
+			moved = math.Max(moved, sqDist(sum[j], km.means[j].point))
+			copy(km.means[j].point, sum[j])
+		}
+		if moved <= km.tol*km.tol {
+			break
+		}
+	}
+
+	for i := range km.means {
+		km.means[i].w = 0
+		km.means[i].count = 0
+		km.means[i].indices = km.means[i].indices[:0]
+	}
+	for i, v := range km.values {
+		c, min := 0, math.Inf(1)
+		for j, m := range km.means {
+			if d := sqDist(v.point, m.point); d < min {
+				c, min = j, d
+			}
+		}
+		km.values[i].cluster = c
+		km.means[c].w += v.w
+		km.means[c].count++
+		km.means[c].indices = append(km.means[c].indices, i)
+	}
+	return err
+}
+
+// Performance returns the k-harmonic means objective for the current centers, the
+// weighted sum over the values of the harmonic average of their distances to the
+// centers raised to the power p.
+func (km *Harmonic) Performance() float64 {
+	k := float64(len(km.means))
+	var perf float64
+	for _, v := range km.values {
+		var hp float64
+		for _, m := range km.means {
+			hp += math.Pow(math.Max(math.Sqrt(sqDist(v.point, m.point)), 1e-12), -km.p)
+		}
+		perf += v.w * k / hp
+	}
+	return perf
+}
+
+// Within calculates the weighted sum of squares within each cluster.
+// Returns nil if Cluster has not been called.
+func (km *Harmonic) Within() []float64 {
+	if km.means == nil {
+		return nil
+	}
+	ss := make([]float64, len(km.means))
+	for _, v := range km.values {
+		ss[v.cluster] += v.w * sqDist(km.means[v.cluster].point, v.point)
+	}
+	return ss
+}
+
+// Centers returns the k centers determined by a previous call to Cluster.
+func (km *Harmonic) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(km.means))
+	for i := range km.means {
+		cs[i] = &km.means[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Harmonic.
+func (km *Harmonic) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(km.values))
+	for i := range km.values {
+		vs[i] = &km.values[i]
+	}
+	return vs
+}
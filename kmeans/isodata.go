@@ -0,0 +1,319 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// IsodataParams holds the thresholds controlling an ISODATA clustering.
+type IsodataParams struct {
+	K           int     // K is the desired number of clusters.
+	MinMembers  int     // MinMembers is the number of members below which a cluster is discarded.
+	MaxSD       float64 // MaxSD is the standard deviation in a dimension above which a cluster may be split.
+	MinDistance float64 // MinDistance is the distance between centers below which clusters may be merged.
+	MaxMerges   int     // MaxMerges is the maximum number of merges in an iteration.
+	MaxIter     int     // MaxIter is the number of iterations.
+}
+
+// Isodata implements clustering of ℝⁿ data according to the ISODATA algorithm.
+// Each iteration makes a Lloyd k-means step, discards clusters with too few
+// members, and then either splits clusters with a large spread or merges clusters
+// with close centers, so that the number of clusters adapts to the data. Splitting
+// is preferred while there are no more than K/2 clusters, and merging is preferred
+// on even iterations, when there are at least 2K clusters and in the last
+// iteration.
+//
+// Reference:
+//
+//	Ball GH, Hall DJ. ISODATA, a novel method of data analysis and pattern
+//	classification. Technical Report, Stanford Research Institute (1965).
+//
+//	Tou JT, Gonzalez RC. Pattern Recognition Principles. Addison-Wesley (1974).
+type Isodata struct {
+	params IsodataParams
+
+	dims   int
+	values []value
+	means  []center
+	source
+}
+
+// NewIsodata creates a new ISODATA object populated with data from an Interface
+// value, data, using the thresholds in p. p.K and p.MaxIter must be positive.
+func NewIsodata(data cluster.Interface, p IsodataParams) (*Isodata, error) {
+	if p.K < 1 || p.MaxIter < 1 || p.MinMembers < 0 || p.MaxMerges < 0 {
+		return nil, errors.New("kmeans: invalid isodata parameters")
+	}
+	v, d, err := convert(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Isodata{params: p, dims: d, values: v}, nil
+}
+
+// SetRand sets the source of the random choices made by Seed to rnd. If rnd is nil,
+// the global math/rand source is used.
+func (km *Isodata) SetRand(rnd *rand.Rand) { km.rnd = rnd }
+
+// Seed generates k initial means for the ISODATA algorithm according to the
+// k-means++ algorithm.
+func (km *Isodata) Seed(k int) {
+	s := Kmeans{dims: km.dims, values: km.values, source: km.source}
+	s.Seed(k)
+	km.means = s.means
+}
+
+// SetCenters sets the locations of the initial centers to c.
+func (km *Isodata) SetCenters(c []cluster.Center) {
+	km.means = make([]center, len(c))
+	for i, cv := range c {
+		km.means[i] = center{point: append(point(nil), cv.V()...)}
+	}
+}
+
+// Cluster runs a clustering of the data using the ISODATA algorithm.
+func (km *Isodata) Cluster() error {
+	return km.ClusterContext(context.Background())
+}
+
+// ClusterContext runs a clustering of the data using the ISODATA algorithm,
+// checking ctx before each iteration. If ctx is done, ClusterContext returns
+// ctx.Err() and values are assigned to the centers of the last completed
+// iteration.
+func (km *Isodata) ClusterContext(ctx context.Context) error {
+	if len(km.means) == 0 {
+		return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrNoCenters}
+	}
+	p := km.params
+	var err error
+	for it := 1; it <= p.MaxIter; it++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		km.update()
+		if km.discard() {
+			km.update()
+		}
+		var split bool
+		switch {
+		case it == p.MaxIter:
+		case len(km.means) <= p.K/2:
+			split = true
+		case it%2 == 1 && len(km.means) < 2*p.K:
+			split = true
+		}
+		if split && km.split() {
+			continue
+		}
+		km.merge()
+	}
+	km.update()
+
+	for i := range km.means {
+		km.means[i].indices = make(cluster.Indices, 0, km.means[i].count)
+	}
+	for i, v := range km.values {
+		km.means[v.cluster].indices = append(km.means[v.cluster].indices, i)
+	}
+	return err
+}
+
+// update assigns each value to its nearest center and moves each center with
+// members to the weighted mean of its members.
+func (km *Isodata) update() {
+	for i, v := range km.values {
+		c, min := 0, math.Inf(1)
+		for j, m := range km.means {
+			if d := sqDist(v.point, m.point); d < min {
+				c, min = j, d
+			}
+		}
+		km.values[i].cluster = c
+	}
+	sum := make([][]float64, len(km.means))
+	for i := range sum {
+		sum[i] = make([]float64, km.dims)
+		km.means[i].w = 0
+		km.means[i].count = 0
+	}
+	for _, v := range km.values {
+		for j, x := range v.point {
+			sum[v.cluster][j] += v.w * x
+		}
+		km.means[v.cluster].w += v.w
+		km.means[v.cluster].count++
+	}
+	for i, s := range sum {
+		m := &km.means[i]
+		if m.w == 0 {
+			continue
+		}
+		for j := range s {
+			m.point[j] = s[j] / m.w
+		}
+	}
+}
+
+// discard removes the centers of clusters with fewer than MinMembers members,
+// retaining the largest cluster if all are too small. It returns whether any
+// center was removed.
+func (km *Isodata) discard() bool {
+	largest := 0
+	for i, m := range km.means {
+		if m.count > km.means[largest].count {
+			largest = i
+		}
+	}
+	keep := km.means[:0]
+	for i, m := range km.means {
+		if m.count >= km.params.MinMembers || i == largest {
+			keep = append(keep, m)
+		}
+	}
+	removed := len(keep) != len(km.means)
+	km.means = keep
+	return removed
+}
+
+// split replaces each cluster with a large spread by a pair of centers displaced
+// by half a standard deviation either side of its center along the dimension with
+// the greatest standard deviation. It returns whether any cluster was split.
+func (km *Isodata) split() bool {
+	k := len(km.means)
+	ss := make([][]float64, k)
+	mean := make([]float64, k)
+	for i := range ss {
+		ss[i] = make([]float64, km.dims)
+	}
+	var all, w float64
+	for _, v := range km.values {
+		m := km.means[v.cluster].point
+		d := math.Sqrt(sqDist(v.point, m))
+		mean[v.cluster] += v.w * d
+		all += v.w * d
+		w += v.w
+		for j, x := range v.point {
+			ss[v.cluster][j] += v.w * (x - m[j]) * (x - m[j])
+		}
+	}
+	all /= w
+
+	var split bool
+	means := make([]center, 0, 2*k)
+	for i, m := range km.means {
+		if m.w == 0 {
+			means = append(means, m)
+			continue
+		}
+		dim, sd := 0, 0.
+		for j, s := range ss[i] {
+			if s := math.Sqrt(s / m.w); s > sd {
+				dim, sd = j, s
+			}
+		}
+		spread := mean[i]/m.w > all && m.count > 2*(km.params.MinMembers+1)
+		if sd <= km.params.MaxSD || !(spread || k <= km.params.K/2) {
+			means = append(means, m)
+			continue
+		}
+		split = true
+		lo := center{point: append(point(nil), m.point...)}
+		hi := center{point: append(point(nil), m.point...)}
+		lo.point[dim] -= sd / 2
+		hi.point[dim] += sd / 2
+		means = append(means, lo, hi)
+	}
+	km.means = means
+	return split
+}
+
+// merge merges up to MaxMerges pairs of clusters with centers closer than
+// MinDistance, closest first. Each cluster is merged at most once in a call and
+// the merged center is the weighted mean of the pair of centers.
+func (km *Isodata) merge() {
+	type pair struct {
+		i, j int
+		d    float64
+	}
+	var pairs []pair
+	for i := range km.means {
+		for j := i + 1; j < len(km.means); j++ {
+			if d := math.Sqrt(sqDist(km.means[i].point, km.means[j].point)); d < km.params.MinDistance {
+				pairs = append(pairs, pair{i, j, d})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(a, b int) bool { return pairs[a].d < pairs[b].d })
+
+	merged := make([]bool, len(km.means))
+	var n int
+	for _, p := range pairs {
+		if n >= km.params.MaxMerges {
+			break
+		}
+		if merged[p.i] || merged[p.j] {
+			continue
+		}
+		a, b := &km.means[p.i], &km.means[p.j]
+		if w := a.w + b.w; w > 0 {
+			for l := range a.point {
+				a.point[l] = (a.w*a.point[l] + b.w*b.point[l]) / w
+			}
+		}
+		a.w += b.w
+		a.count += b.count
+		merged[p.i], merged[p.j] = true, true
+		b.count = -1
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	keep := km.means[:0]
+	for _, m := range km.means {
+		if m.count >= 0 {
+			keep = append(keep, m)
+		}
+	}
+	km.means = keep
+}
+
+// Within calculates the weighted sum of squares within each cluster.
+// Returns nil if Cluster has not been called.
+func (km *Isodata) Within() []float64 {
+	if km.means == nil {
+		return nil
+	}
+	ss := make([]float64, len(km.means))
+	for _, v := range km.values {
+		ss[v.cluster] += v.w * sqDist(km.means[v.cluster].point, v.point)
+	}
+	return ss
+}
+
+// Centers returns the centers determined by a previous call to Cluster.
+func (km *Isodata) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(km.means))
+	for i := range km.means {
+		cs[i] = &km.means[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Isodata.
+func (km *Isodata) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(km.values))
+	for i := range km.values {
+		vs[i] = &km.values[i]
+	}
+	return vs
+}
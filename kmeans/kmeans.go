@@ -8,8 +8,15 @@ package kmeans
 import (
 	"github.com/biogo/cluster/cluster"
 
+	"context"
+	"encoding/gob"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"math/rand"
+	"runtime"
+	"sync"
 )
 
 type point []float64
@@ -30,6 +37,7 @@ type center struct {
 	w       float64
 	count   int
 	indices cluster.Indices
+	pinned  bool
 }
 
 func (c *center) zero() {
@@ -37,7 +45,7 @@ func (c *center) zero() {
 	for i := range p {
 		p[i] = 0
 	}
-	*c = center{point: p}
+	*c = center{point: p, pinned: c.pinned}
 }
 
 func (c *center) Members() cluster.Indices { return c.indices }
@@ -47,31 +55,103 @@ type Kmeans struct {
 	dims   int
 	values []value
 	means  []center
+
+	data    cluster.Fingerprint
+	seeding string
+	source
+	seed *int64
+
+	cons   *constraints
+	accel  Acceleration
+	div    Divergence
+	bounds *bounds
+	cells  *cell
+	sums   *sums
+
+	// unsettled is whether values in cells assigned as a
+	// whole by the last filtering pass are yet to be labeled.
+	unsettled bool
+
+	maxIter int
+	tol     float64
+	workers int
+	empty   EmptyPolicy
+	empties []Empty
+	diag    []Diagnostic
+
+	run *run
+	err error
+
+	iter       int
+	checkpoint *gob.Encoder
+	every      int
 }
 
 // New creates a new k-means object populated with data from an Interface value, data.
-func New(data cluster.Interface) (*Kmeans, error) {
+// The options in opts are applied in order.
+func New(data cluster.Interface, opts ...Option) (*Kmeans, error) {
 	v, d, err := convert(data)
 	if err != nil {
 		return nil, err
 	}
-	return &Kmeans{
+	km := &Kmeans{
 		dims:   d,
 		values: v,
-	}, nil
+		data:   cluster.FingerprintOf(data),
+	}
+	for _, o := range opts {
+		o(km)
+	}
+	return km, nil
+}
+
+// NewSeeded creates a new k-means object populated with data from an Interface value,
+// data. Random choices made during seeding are drawn from a source seeded with seed
+// rather than from the global math/rand source, so clusterings are reproducible and
+// independent of other users of math/rand. The seed is recorded in the Manifest.
+// NewSeeded is equivalent to New(data, WithSeed(seed)). To draw from an existing
+// source, use New(data, WithRand(rnd)).
+func NewSeeded(data cluster.Interface, seed int64) (*Kmeans, error) {
+	return New(data, WithSeed(seed))
+}
+
+// SetRand sets the source of the random choices made during seeding to rnd. If rnd
+// is nil, the global math/rand source is used. No seed is recorded in the Manifest.
+func (km *Kmeans) SetRand(rnd *rand.Rand) { km.rnd, km.seed = rnd, nil }
+
+// source is a source of random numbers. If rnd is nil, the global math/rand source
+// is used.
+type source struct {
+	rnd *rand.Rand
+}
+
+// intn returns a random integer in [0, n) from the random source.
+func (s source) intn(n int) int {
+	if s.rnd == nil {
+		return rand.Intn(n)
+	}
+	return s.rnd.Intn(n)
+}
+
+// float64 returns a random number in [0, 1) from the random source.
+func (s source) float64() float64 {
+	if s.rnd == nil {
+		return rand.Float64()
+	}
+	return s.rnd.Float64()
 }
 
 // convert renders data to the internal float64 representation for a Kmeans.
 func convert(data cluster.Interface) ([]value, int, error) {
 	va := make([]value, data.Len())
 	if data.Len() == 0 {
-		return nil, 0, errors.New("kmeans: no data")
+		return nil, 0, &cluster.Error{Pkg: "kmeans", Err: cluster.ErrEmptyData}
 	}
 	dim := len(data.Values(0))
 	for i := 0; i < data.Len(); i++ {
 		vec := data.Values(i)
 		if len(vec) != dim {
-			return nil, 0, errors.New("kmeans: mismatched dimensions")
+			return nil, 0, &cluster.Error{Pkg: "kmeans", Err: cluster.ErrDimensionMismatch}
 		}
 		va[i] = value{point: append(point(nil), vec...)}
 	}
@@ -89,14 +169,18 @@ func convert(data cluster.Interface) ([]value, int, error) {
 }
 
 // Seed generates the initial means for the k-means algorithm according to the k-means++
-// algorithm
+// algorithm. After the first mean, values are chosen with probability proportional to
+// their weight and squared distance from the nearest mean already chosen.
 func (km *Kmeans) Seed(k int) {
+	km.iter = 0
+	km.run = nil
+	km.seeding = "kmeans++"
 	km.means = make([]center, k)
 	for i := range km.means {
 		km.means[i].point = make(point, km.dims)
 	}
 
-	copy(km.means[0].point, km.values[rand.Intn(len(km.values))].point)
+	copy(km.means[0].point, km.values[km.intn(len(km.values))].point)
 	if k == 1 {
 		return
 	}
@@ -105,10 +189,10 @@ func (km *Kmeans) Seed(k int) {
 		sum := 0.
 		for j, v := range km.values {
 			_, min := km.nearest(v.point)
-			d[j] = min
+			d[j] = min * v.w
 			sum += d[j]
 		}
-		target := rand.Float64() * sum
+		target := km.float64() * sum
 		j := 0
 		for sum = d[0]; sum < target; sum += d[j] {
 			j++
@@ -119,15 +203,52 @@ func (km *Kmeans) Seed(k int) {
 
 // SetCenters sets the locations of the centers to c.
 func (km *Kmeans) SetCenters(c []cluster.Center) {
+	km.iter = 0
+	km.run = nil
+	km.seeding = "user"
 	km.means = make([]center, len(c))
 	for i, cv := range c {
 		km.means[i] = center{point: append(point(nil), cv.V()...)}
 	}
 }
 
+// SeedFrom sets the initial means for the k-means algorithm to copies of the points
+// in centers, for example the centers of a previous clustering or known prototypes.
+// SeedFrom returns an error if no centers are provided or if the dimensionality of
+// a center does not match the data.
+func (km *Kmeans) SeedFrom(centers [][]float64) error {
+	if len(centers) == 0 {
+		return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrNoCenters}
+	}
+	means := make([]center, len(centers))
+	for i, c := range centers {
+		if len(c) != km.dims {
+			return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrDimensionMismatch}
+		}
+		means[i] = center{point: append(point(nil), c...)}
+	}
+	km.iter = 0
+	km.run = nil
+	km.seeding = "user"
+	km.means = means
+	return nil
+}
+
 // Find the nearest center to the point v. Returns c, the index of the nearest center
-// and min, the square of the distance from v to that center.
+// and min, the square of the distance from v to that center, or the divergence of v
+// from the center if a divergence has been set.
 func (km *Kmeans) nearest(v point) (c int, min float64) {
+	if km.div != nil {
+		min = km.div(v, km.means[0].point)
+		for i := 1; i < len(km.means); i++ {
+			if d := km.div(v, km.means[i].point); d < min {
+				min = d
+				c = i
+			}
+		}
+		return c, min
+	}
+
 	var ad float64
 	for j := range v {
 		ad = v[j] - km.means[0].point[j]
@@ -149,20 +270,223 @@ func (km *Kmeans) nearest(v point) (c int, min float64) {
 	return c, min
 }
 
+// Checkpoint is the intermediate state of a k-means clustering.
+type Checkpoint struct {
+	Iteration   int
+	Centers     [][]float64
+	Assignments []int
+	Pinned      []int // Pinned holds the indices of the pinned centers.
+}
+
+// SetCheckpoint arranges for the state of the clustering to be written to w as a
+// gob encoded Checkpoint after every n iterations of Cluster. Successive checkpoints
+// are written to w as a single gob stream. If w is nil or n is less than one,
+// checkpointing is disabled.
+func (km *Kmeans) SetCheckpoint(w io.Writer, n int) {
+	if w == nil || n < 1 {
+		km.checkpoint = nil
+		return
+	}
+	km.checkpoint = gob.NewEncoder(w)
+	km.every = n
+}
+
+// Resume restores the state of the clustering, including pinned centers, from the
+// last Checkpoint in the gob stream read from r. A subsequent call to Cluster
+// continues from the restored state. If Resume returns an error, the state of the
+// clustering is unchanged.
+func (km *Kmeans) Resume(r io.Reader) error {
+	dec := gob.NewDecoder(r)
+	var cp Checkpoint
+	found := false
+	for {
+		var c Checkpoint
+		err := dec.Decode(&c)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("kmeans: resume: %w", err)
+		}
+		cp, found = c, true
+	}
+	if !found {
+		return errors.New("kmeans: resume: no checkpoint")
+	}
+	if len(cp.Assignments) != len(km.values) {
+		return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrDimensionMismatch}
+	}
+	for _, c := range cp.Centers {
+		if len(c) != km.dims {
+			return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrDimensionMismatch}
+		}
+	}
+	for _, c := range cp.Assignments {
+		if c < 0 || c >= len(cp.Centers) {
+			return errors.New("kmeans: resume: invalid assignment")
+		}
+	}
+	for _, i := range cp.Pinned {
+		if i < 0 || i >= len(cp.Centers) {
+			return errors.New("kmeans: resume: invalid pin")
+		}
+	}
+
+	km.settle()
+	km.means = make([]center, len(cp.Centers))
+	for i, c := range cp.Centers {
+		km.means[i] = center{point: append(point(nil), c...)}
+	}
+	for _, i := range cp.Pinned {
+		km.means[i].pinned = true
+	}
+	for i, c := range cp.Assignments {
+		km.values[i].cluster = c
+	}
+	km.iter = cp.Iteration
+	km.run = nil
+	km.seeding = "resumed"
+	return nil
+}
+
+// writeCheckpoint writes the current state of the clustering to the checkpoint
+// stream.
+func (km *Kmeans) writeCheckpoint() error {
+	km.settle()
+	cp := Checkpoint{
+		Iteration:   km.iter,
+		Centers:     make([][]float64, len(km.means)),
+		Assignments: make([]int, len(km.values)),
+		Pinned:      km.Pinned(),
+	}
+	for i, c := range km.means {
+		cp.Centers[i] = c.point
+	}
+	for i, v := range km.values {
+		cp.Assignments[i] = v.cluster
+	}
+	err := km.checkpoint.Encode(cp)
+	if err != nil {
+		return fmt.Errorf("kmeans: checkpoint: %w", err)
+	}
+	return nil
+}
+
+// ErrMaxIterations is returned by Cluster when the clustering has not converged
+// within the maximum number of iterations. The clustering state is valid, with each
+// value assigned to its nearest center. ErrMaxIterations wraps
+// cluster.ErrMaxIterations.
+var ErrMaxIterations error = &cluster.Error{Pkg: "kmeans", Err: cluster.ErrMaxIterations}
+
+// SetLimits sets the conditions for termination of Cluster. Clustering stops when
+// no value changes its assignment, or when no center moves by more than tol times
+// the root mean square distance of the data from their mean. If maxIter is
+// positive, Cluster returns ErrMaxIterations if the clustering has not converged
+// after a total of maxIter iterations. The zero values of maxIter and tol disable
+// the respective limits.
+func (km *Kmeans) SetLimits(maxIter int, tol float64) {
+	km.maxIter = maxIter
+	km.tol = tol
+}
+
 // Cluster runs a clustering of the data using the k-means algorithm.
 func (km *Kmeans) Cluster() error {
+	return km.ClusterContext(context.Background())
+}
+
+// ClusterContext runs a clustering of the data using the k-means algorithm,
+// checking ctx before each iteration. If ctx is done, ClusterContext returns
+// ctx.Err() and the clustering state holds the assignments of the last completed
+// iteration.
+func (km *Kmeans) ClusterContext(ctx context.Context) error {
+	km.run = nil
+	for {
+		if err := ctx.Err(); err != nil {
+			km.run = nil
+			km.err = err
+			return err
+		}
+		if _, done := km.Step(); done {
+			return km.err
+		}
+	}
+}
+
+// run holds the state of a sequence of iterations.
+type run struct {
+	prev  [][]float64
+	limit float64
+}
+
+// Step performs a single iteration of the k-means algorithm, updating the means and
+// then reassigning the values, and returns delta, the greatest squared distance, or
+// divergence, moved by a center. If a center was dropped during the iteration,
+// delta is +Inf. When done is true, the clustering has converged or has been
+// stopped by an error or the iteration limit, and the error is returned by Err. A
+// call to Step after done is true, or after the centers are set, starts a new
+// sequence of iterations.
+func (km *Kmeans) Step() (delta float64, done bool) {
+	if km.run == nil {
+		km.err = km.start()
+		if km.err != nil {
+			return 0, true
+		}
+	}
+	delta, done, km.err = km.step()
+	if done {
+		km.run = nil
+	}
+	return delta, done
+}
+
+// Err returns the error, if any, that ended the last sequence of iterations run by
+// Step or Cluster.
+func (km *Kmeans) Err() error { return km.err }
+
+// start prepares km for a sequence of iterations.
+func (km *Kmeans) start() error {
 	if len(km.means) == 0 {
-		return errors.New("kmeans: no centers")
+		return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrNoCenters}
 	}
-	for i, v := range km.values {
-		n, _ := km.nearest(v.point)
-		km.values[i].cluster = n
+	km.bounds = nil
+	km.empties = nil
+	km.diag = nil
+	if _, err := km.assign(); err != nil {
+		return err
 	}
 
-	for {
+	km.run = &run{prev: make([][]float64, len(km.means))}
+	if km.tol > 0 {
+		var w float64
+		for _, v := range km.values {
+			w += v.w
+		}
+		km.run.limit = km.tol * km.tol * km.Total() / w
+	}
+	return nil
+}
+
+// step performs a single iteration of the k-means algorithm.
+func (km *Kmeans) step() (delta float64, done bool, err error) {
+	if km.checkpoint != nil && km.iter != 0 && km.iter%km.every == 0 {
+		err := km.writeCheckpoint()
+		if err != nil {
+			return 0, true, err
+		}
+	}
+	prev := km.run.prev
+	for i := range km.means {
+		prev[i] = append(prev[i][:0], km.means[i].point...)
+		km.means[i].zero()
+	}
+	if s := km.sums; s != nil {
+		// The weighted sums were accumulated by the filtering pass.
 		for i := range km.means {
-			km.means[i].zero()
+			copy(km.means[i].point, s.point[i])
+			km.means[i].w = s.w[i]
+			km.means[i].count = s.count[i]
 		}
+	} else {
 		for _, v := range km.values {
 			for j := range km.means[v.cluster].point {
 				km.means[v.cluster].point[j] += v.point[j] * v.w
@@ -170,71 +494,266 @@ func (km *Kmeans) Cluster() error {
 			km.means[v.cluster].w += v.w
 			km.means[v.cluster].count++
 		}
-		for i := range km.means {
-			inv := 1 / km.means[i].w
-			for j := range km.means[i].point {
-				km.means[i].point[j] *= inv
-			}
+	}
+	var movement float64
+	for i := range km.means {
+		if km.means[i].pinned {
+			copy(km.means[i].point, prev[i])
+			continue
 		}
+		if km.means[i].w == 0 {
+			continue
+		}
+		inv := 1 / km.means[i].w
+		for j := range km.means[i].point {
+			km.means[i].point[j] *= inv
+		}
+		movement += dist(prev[i], km.means[i].point)
+	}
+	k := len(km.means)
+	km.fixEmpty()
+	dropped := len(km.means) != k
 
-		deltas := 0
-		for i, v := range km.values {
-			if n, _ := km.nearest(v.point); n != v.cluster {
-				deltas++
-				km.values[i].cluster = n
-			}
+	deltas, err := km.assign()
+	if err != nil {
+		return 0, true, err
+	}
+	km.diag = append(km.diag, Diagnostic{
+		Iteration:  km.iter,
+		Reassigned: deltas,
+		Movement:   movement,
+		Inertia:    km.inertia(),
+	})
+	km.iter++
+
+	delta = math.Inf(1)
+	if !dropped {
+		delta = 0
+		for i, m := range km.means {
+			delta = math.Max(delta, km.divergence(prev[i], m.point))
 		}
-		if deltas == 0 {
-			break
+	}
+	switch {
+	case deltas == 0, km.tol > 0 && delta <= km.run.limit:
+		return delta, true, nil
+	case km.maxIter > 0 && km.iter >= km.maxIter:
+		return delta, true, ErrMaxIterations
+	}
+	return delta, false, nil
+}
+
+// assign assigns each value to its nearest center, subject to any constraints,
+// and returns the number of values whose assignment changed.
+func (km *Kmeans) assign() (deltas int, err error) {
+	km.sums = nil
+	if km.cons == nil && km.div == nil && km.accel == Filtering {
+		return km.assignFiltering(), nil
+	}
+	km.settle()
+	if km.cons != nil {
+		return km.assignConstrained()
+	}
+	switch {
+	case km.div != nil:
+		// The accelerations depend on the triangle inequality.
+	case km.accel == Elkan:
+		return km.assignElkan(), nil
+	}
+	if km.workers > 1 {
+		return km.assignParallel(), nil
+	}
+	for i, v := range km.values {
+		if n, _ := km.nearest(v.point); n != v.cluster {
+			deltas++
+			km.values[i].cluster = n
 		}
 	}
-	return nil
+	return deltas, nil
+}
+
+// SetWorkers sets the number of goroutines used to assign values to their nearest
+// centers by exhaustive search. If n is less than one, the value of
+// runtime.GOMAXPROCS is used. By default a single goroutine is used. The
+// clustering found does not depend on the number of workers.
+func (km *Kmeans) SetWorkers(n int) {
+	if n < 1 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	km.workers = n
+}
+
+// assignParallel assigns each value to its nearest center, dividing the values
+// between km.workers goroutines, and returns the number of values that changed
+// assignment.
+func (km *Kmeans) assignParallel() (deltas int) {
+	workers := km.workers
+	if workers > len(km.values) {
+		workers = len(km.values)
+	}
+	counts := make([]int, workers)
+	var wg sync.WaitGroup
+	for w := range counts {
+		from := w * len(km.values) / workers
+		to := (w + 1) * len(km.values) / workers
+		wg.Add(1)
+		go func(w, from, to int) {
+			defer wg.Done()
+			for i := from; i < to; i++ {
+				v := &km.values[i]
+				if n, _ := km.nearest(v.point); n != v.cluster {
+					counts[w]++
+					v.cluster = n
+				}
+			}
+		}(w, from, to)
+	}
+	wg.Wait()
+	for _, n := range counts {
+		deltas += n
+	}
+	return deltas
+}
+
+// Manifest returns a record of the parameters and data used for the clustering.
+// Constraints and pinned centers are recorded when they are set.
+func (km *Kmeans) Manifest() cluster.Manifest {
+	workers := km.workers
+	if workers < 1 {
+		workers = 1
+	}
+	p := map[string]interface{}{
+		"k":            len(km.means),
+		"seeding":      km.seeding,
+		"maxIter":      km.maxIter,
+		"tol":          km.tol,
+		"divergence":   km.divergenceName(),
+		"acceleration": accelerations[km.accel],
+		"emptyPolicy":  emptyPolicies[km.empty],
+		"workers":      workers,
+	}
+	if km.cons != nil {
+		p["mustLink"] = km.cons.mustLink
+		p["cannotLink"] = km.cons.cannotLink
+	}
+	if pinned := km.Pinned(); pinned != nil {
+		p["pinned"] = pinned
+	}
+	return cluster.Manifest{
+		Algorithm:  "kmeans",
+		Parameters: p,
+		Seed:       km.seed,
+		Data:       km.data,
+		Iterations: km.iter,
+		Version:    cluster.ModuleVersion(),
+	}
 }
 
-// Total calculates the total sum of squares for the data relative to the data mean.
+// Total calculates the total weighted sum of squares for the data relative to the
+// weighted data mean. If a divergence has been set, Total returns the total weighted
+// divergence of the data from the weighted data mean.
 func (km *Kmeans) Total() float64 {
 	p := make([]float64, km.dims)
+	var w float64
 	for _, v := range km.values {
 		for j := range p {
-			p[j] += v.point[j]
+			p[j] += v.point[j] * v.w
 		}
+		w += v.w
 	}
-	inv := 1 / float64(len(km.values))
+	inv := 1 / w
 	for j := range p {
 		p[j] *= inv
 	}
 
 	var ss float64
+	if km.div != nil {
+		for _, v := range km.values {
+			ss += km.div(v.point, p) * v.w
+		}
+		return ss
+	}
 	for _, v := range km.values {
 		for j := range p {
 			d := p[j] - v.point[j]
-			ss += d * d
+			ss += d * d * v.w
 		}
 	}
 
 	return ss
 }
 
-// Within calculates the sum of squares within each cluster.
+// Within calculates the weighted sum of squares within each cluster, or the weighted
+// sum of divergences if a divergence has been set.
 // Returns nil if Cluster has not been called.
 func (km *Kmeans) Within() []float64 {
 	if km.means == nil {
 		return nil
 	}
+	km.settle()
 	ss := make([]float64, len(km.means))
+	if km.div != nil {
+		for _, v := range km.values {
+			ss[v.cluster] += km.div(v.point, km.means[v.cluster].point) * v.w
+		}
+		return ss
+	}
 
 	for _, v := range km.values {
 		for j := range v.point {
 			d := km.means[v.cluster].point[j] - v.point[j]
-			ss[v.cluster] += d * d
+			ss[v.cluster] += d * d * v.w
 		}
 	}
 
 	return ss
 }
 
+// Distances returns the squared distance from each value to its assigned center, or
+// the divergence of the value from its center if a divergence has been set.
+// Distances are not weighted. Returns nil if Cluster has not been called.
+func (km *Kmeans) Distances() []float64 {
+	if km.means == nil {
+		return nil
+	}
+	km.settle()
+	d := make([]float64, len(km.values))
+	for i, v := range km.values {
+		d[i] = km.divergence(v.point, km.means[v.cluster].point)
+	}
+	return d
+}
+
+// Predict returns the index of the center nearest to p and the squared distance from
+// p to that center, or the divergence of p from the center if a divergence has been
+// set. The centers are not altered, so new points may be labeled without
+// reclustering. p must have the dimensionality of the data. If there are no centers,
+// Predict returns -1 and +Inf.
+func (km *Kmeans) Predict(p []float64) (cluster int, dist float64) {
+	if len(km.means) == 0 {
+		return -1, math.Inf(1)
+	}
+	return km.nearest(p)
+}
+
+// Assign returns the index of the center nearest to x, as for Predict.
+func (km *Kmeans) Assign(x []float64) int {
+	c, _ := km.Predict(x)
+	return c
+}
+
+// PredictAll returns the results of Predict for each element of data.
+func (km *Kmeans) PredictAll(data cluster.Interface) (clusters []int, dists []float64) {
+	clusters = make([]int, data.Len())
+	dists = make([]float64, data.Len())
+	for i := range clusters {
+		clusters[i], dists[i] = km.Predict(data.Values(i))
+	}
+	return clusters, dists
+}
+
 // Centers returns the k centers determined by a previous call to Cluster.
 func (km *Kmeans) Centers() []cluster.Center {
+	km.settle()
 	c := make([]cluster.Indices, len(km.means))
 	for i := range c {
 		c[i] = make([]int, 0, km.means[i].count)
@@ -252,8 +771,23 @@ func (km *Kmeans) Centers() []cluster.Center {
 	return cs
 }
 
+// Assignments returns the index of the center of each value, in the order of the
+// slice returned by Values. Returns nil if Cluster has not been called.
+func (km *Kmeans) Assignments() []int {
+	if km.means == nil {
+		return nil
+	}
+	km.settle()
+	a := make([]int, len(km.values))
+	for i, v := range km.values {
+		a[i] = v.cluster
+	}
+	return a
+}
+
 // Values returns a slice of the values in the Kmeans.
 func (km *Kmeans) Values() []cluster.Value {
+	km.settle()
 	vs := make([]cluster.Value, len(km.values))
 	for i := range km.values {
 		vs[i] = &km.values[i]
@@ -5,6 +5,12 @@
 package kmeans_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"math"
 	"math/rand"
 	"strings"
 	"testing"
@@ -19,8 +25,6 @@ func Test(t *testing.T) { check.TestingT(t) }
 
 type S struct{}
 
-func (s *S) TearDownSuite(_ *check.C) { rand.Seed(1) } // Reset the seed for the example test.
-
 var _ = check.Suite(&S{})
 
 var (
@@ -81,7 +85,6 @@ var (
 // Tests
 func (s *S) TestKmeans(c *check.C) {
 	for i, t := range tests {
-		rand.Seed(1)
 		km, err := ClusterFeatures(t.set, t.epsilon, t.effort)
 		c.Assert(err, check.Equals, nil)
 		clusters := km.Centers()
@@ -112,12 +115,13 @@ func (b bench) Len() int               { return len(b) }
 func (b bench) Values(i int) []float64 { return b[i][:] }
 
 var benchData bench = func() bench {
+	rnd := rand.New(rand.NewSource(1))
 	b := make(bench, 10000)
 	for i := 0; i < 20; i++ {
-		x, y := float64(rand.Intn(10000)), float64(rand.Intn(10000))
-		r := float64(rand.Intn(200))
+		x, y := float64(rnd.Intn(10000)), float64(rnd.Intn(10000))
+		r := float64(rnd.Intn(200))
 		for j := range b {
-			b[j] = [2]float64{x + r*rand.NormFloat64(), y + r*rand.NormFloat64()}
+			b[j] = [2]float64{x + r*rnd.NormFloat64(), y + r*rnd.NormFloat64()}
 		}
 	}
 	return b
@@ -131,3 +135,1071 @@ func Benchmark(b *testing.B) {
 	}
 	_ = km.Centers()
 }
+
+func BenchmarkFiltering(b *testing.B) {
+	km, _ := kmeans.New(benchData)
+	km.SetAcceleration(kmeans.Filtering)
+	km.Seed(20)
+	for i := 0; i < b.N; i++ {
+		km.Cluster()
+	}
+	_ = km.Centers()
+}
+
+func BenchmarkElkan(b *testing.B) {
+	km, _ := kmeans.New(benchData)
+	km.SetAcceleration(kmeans.Elkan)
+	km.Seed(20)
+	for i := 0; i < b.N; i++ {
+		km.Cluster()
+	}
+	_ = km.Centers()
+}
+
+func (s *S) TestCheckpoint(c *check.C) {
+	km, err := kmeans.NewSeeded(benchData, 1)
+	c.Assert(err, check.Equals, nil)
+	km.Seed(20)
+	var buf bytes.Buffer
+	km.SetCheckpoint(&buf, 2)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	c.Assert(buf.Len() > 0, check.Equals, true)
+	want := km.Within()
+
+	resumed, err := kmeans.New(benchData)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(resumed.Resume(bytes.NewReader(buf.Bytes())), check.Equals, nil)
+	c.Assert(resumed.Cluster(), check.Equals, nil)
+	c.Check(resumed.Within(), check.DeepEquals, want)
+
+	c.Check(resumed.Resume(&bytes.Buffer{}), check.ErrorMatches, "kmeans: resume: no checkpoint")
+	other, _ := kmeans.New(Features(feats))
+	c.Check(other.Resume(bytes.NewReader(buf.Bytes())), check.ErrorMatches, "kmeans: mismatched dimensions")
+
+	// Pins are restored and a failed Resume leaves the clustering unchanged.
+	km, err = kmeans.NewSeeded(benchData, 1)
+	c.Assert(err, check.Equals, nil)
+	km.Seed(5)
+	c.Assert(km.Pin(0, 3), check.Equals, nil)
+	buf.Reset()
+	km.SetCheckpoint(&buf, 1)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	resumed, err = kmeans.New(benchData)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(resumed.Resume(bytes.NewReader(buf.Bytes())), check.Equals, nil)
+	c.Check(resumed.Pinned(), check.DeepEquals, []int{0, 3})
+
+	var bad bytes.Buffer
+	cp := kmeans.Checkpoint{
+		Centers:     [][]float64{{0, 0}},
+		Assignments: make([]int, len(benchData)),
+		Pinned:      []int{1},
+	}
+	c.Assert(gob.NewEncoder(&bad).Encode(cp), check.Equals, nil)
+	before := resumed.Centers()
+	c.Check(resumed.Resume(&bad), check.ErrorMatches, "kmeans: resume: invalid pin")
+	c.Check(resumed.Centers(), check.DeepEquals, before)
+	c.Check(resumed.Pinned(), check.DeepEquals, []int{0, 3})
+}
+
+func (s *S) TestManifest(c *check.C) {
+	km, err := kmeans.New(Features(feats))
+	c.Assert(err, check.Equals, nil)
+	km.Seed(4)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	m := km.Manifest()
+	c.Check(m.Algorithm, check.Equals, "kmeans")
+	c.Check(m.Parameters, check.DeepEquals, map[string]interface{}{
+		"k": 4, "seeding": "kmeans++", "maxIter": 0, "tol": 0.,
+		"divergence": "squaredEuclidean", "acceleration": "naive", "emptyPolicy": "reseed", "workers": 1,
+	})
+	c.Check(m.Seed, check.IsNil)
+	c.Check(m.Data, check.Equals, cluster.FingerprintOf(Features(feats)))
+	c.Check(m.Data.N, check.Equals, len(feats))
+	c.Check(m.Data.Dims, check.Equals, 2)
+	c.Check(m.Iterations > 0, check.Equals, true)
+
+	other, err := kmeans.New(Features(feats[1:]))
+	c.Assert(err, check.Equals, nil)
+	c.Check(other.Manifest().Data.Hash != m.Data.Hash, check.Equals, true)
+
+	km, err = kmeans.New(Features(feats), kmeans.WithDivergence(kmeans.KL), kmeans.WithWorkers(3))
+	c.Assert(err, check.Equals, nil)
+	km.SetAcceleration(kmeans.Elkan)
+	km.SetEmptyPolicy(kmeans.Drop)
+	c.Assert(km.SetConstraints([]kmeans.Link{{A: 0, B: 1}}, []kmeans.Link{{A: 0, B: 2}}), check.Equals, nil)
+	km.Seed(4)
+	c.Assert(km.Pin(1), check.Equals, nil)
+	p := km.Manifest().Parameters
+	c.Check(p["divergence"], check.Equals, "kl")
+	c.Check(p["acceleration"], check.Equals, "elkan")
+	c.Check(p["emptyPolicy"], check.Equals, "drop")
+	c.Check(p["workers"], check.Equals, 3)
+	c.Check(p["mustLink"], check.DeepEquals, []kmeans.Link{{A: 0, B: 1}})
+	c.Check(p["cannotLink"], check.DeepEquals, []kmeans.Link{{A: 0, B: 2}})
+	c.Check(p["pinned"], check.DeepEquals, []int{1})
+	km.SetDivergence(func(x, y []float64) float64 { return 0 })
+	c.Check(km.Manifest().Parameters["divergence"], check.Equals, "custom")
+}
+
+func (s *S) TestOnline(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	o, err := kmeans.NewOnline(3, 50)
+	c.Assert(err, check.Equals, nil)
+	o.SetRand(rand.New(rand.NewSource(1)))
+	centers := [][]float64{{0, 0}, {50, 0}, {0, 50}}
+	for i := 0; i < 20000; i++ {
+		m := centers[i%len(centers)]
+		c.Assert(o.Observe([]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()}), check.Equals, nil)
+	}
+	c.Check(o.Len(), check.Equals, 20000)
+	sk := o.Sketch()
+	c.Check(sk.Len() <= 50, check.Equals, true)
+	var w float64
+	for i := 0; i < sk.Len(); i++ {
+		w += sk.(cluster.Weighter).Weight(i)
+	}
+	c.Check(w, check.Equals, 20000.)
+
+	km, err := o.Cluster()
+	c.Assert(err, check.Equals, nil)
+	c.Assert(len(km.Centers()), check.Equals, 3)
+	for _, cen := range km.Centers() {
+		var found bool
+		for _, m := range centers {
+			if sqDist(cen.V(), m) < 1 {
+				found = true
+			}
+		}
+		c.Check(found, check.Equals, true, check.Commentf("center at %v", cen.V()))
+	}
+
+	c.Check(o.Observe([]float64{1}), check.ErrorMatches, "kmeans: mismatched dimensions")
+	_, err = kmeans.NewOnline(3, 3)
+	c.Check(err, check.ErrorMatches, "kmeans: sketch size too small")
+}
+
+func sqDist(a, b []float64) float64 {
+	var ss float64
+	for i, v := range a {
+		d := v - b[i]
+		ss += d * d
+	}
+	return ss
+}
+
+// Vectors is a set of composition vectors.
+type Vectors [][]float64
+
+func (v Vectors) Len() int               { return len(v) }
+func (v Vectors) Values(i int) []float64 { return v[i] }
+
+func (s *S) TestSpherical(c *check.C) {
+	// Two directions at a range of magnitudes; Euclidean k-means would
+	// separate these by magnitude rather than direction.
+	var vecs Vectors
+	for _, scale := range []float64{1, 10, 100} {
+		vecs = append(vecs,
+			[]float64{4 * scale, 1 * scale, 0},
+			[]float64{5 * scale, 1 * scale, 1 * scale},
+			[]float64{0, 1 * scale, 4 * scale},
+			[]float64{1 * scale, 1 * scale, 5 * scale},
+		)
+	}
+	km, err := kmeans.NewSpherical(vecs)
+	c.Assert(err, check.Equals, nil)
+	km.SetRand(rand.New(rand.NewSource(1)))
+	km.Seed(2)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	cens := km.Centers()
+	c.Assert(len(cens), check.Equals, 2)
+	vals := km.Values()
+	for i := 0; i < len(vecs); i += 4 {
+		c.Check(vals[i].Cluster(), check.Equals, vals[i+1].Cluster())
+		c.Check(vals[i+2].Cluster(), check.Equals, vals[i+3].Cluster())
+		c.Check(vals[i].Cluster(), check.Not(check.Equals), vals[i+2].Cluster())
+		c.Check(vals[i].Cluster(), check.Equals, vals[0].Cluster())
+	}
+	for i, cen := range cens {
+		c.Check(math.Abs(sqDist(cen.V(), make([]float64, 3))-1) < 1e-12, check.Equals, true)
+		c.Check(len(cen.Members()), check.Equals, 6)
+		c.Check(km.Cohesion()[i] > 5.5, check.Equals, true)
+	}
+
+	km.SetMaxIter(1)
+	km.SetCenters([]cluster.Center{center{1, 0, 0}, center{1, 0, 0.3}})
+	c.Check(km.Cluster(), check.Equals, kmeans.ErrMaxIterations)
+	km.SetMaxIter(0)
+	km.SetCenters([]cluster.Center{center{1, 0, 0}, center{1, 0, 0.3}})
+	c.Check(km.Cluster(), check.Equals, nil)
+
+	_, err = kmeans.NewSpherical(Vectors{{1, 0}, {0, 0}})
+	c.Check(err, check.ErrorMatches, "kmeans: zero vector")
+}
+
+func (s *S) TestConstraints(c *check.C) {
+	pts := bench{{0, 0}, {1, 0}, {0, 1}, {10, 0}, {11, 0}, {10, 1}}
+	km, err := kmeans.NewSeeded(pts, 1)
+	c.Assert(err, check.Equals, nil)
+
+	// Force element 2 to join the right group and split the right group.
+	err = km.SetConstraints(
+		[]kmeans.Link{{A: 2, B: 5}},
+		[]kmeans.Link{{A: 3, B: 4}},
+	)
+	c.Assert(err, check.Equals, nil)
+	for i := 0; i < 5; i++ {
+		km.Seed(2)
+		c.Assert(km.Cluster(), check.Equals, nil)
+		vals := km.Values()
+		c.Check(vals[2].Cluster(), check.Equals, vals[5].Cluster())
+		c.Check(vals[3].Cluster(), check.Not(check.Equals), vals[4].Cluster())
+	}
+
+	c.Check(km.SetConstraints([]kmeans.Link{{A: 0, B: 1}}, []kmeans.Link{{A: 1, B: 0}}), check.ErrorMatches, "kmeans: infeasible constraints")
+	c.Check(km.SetConstraints(nil, []kmeans.Link{{A: 0, B: 6}}), check.ErrorMatches, "kmeans: constraint index out of range")
+	c.Assert(km.SetConstraints(nil, []kmeans.Link{{A: 0, B: 1}, {A: 1, B: 2}, {A: 0, B: 2}}), check.Equals, nil)
+	km.Seed(2)
+	c.Check(km.Cluster(), check.ErrorMatches, "kmeans: infeasible constraints")
+
+	c.Assert(km.SetConstraints(nil, nil), check.Equals, nil)
+	km.Seed(2)
+	c.Check(km.Cluster(), check.Equals, nil)
+}
+
+func (s *S) TestHarmonic(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	centers := [][]float64{{0, 0}, {20, 0}, {0, 20}}
+	var pts bench
+	for i := 0; i < 300; i++ {
+		m := centers[i%len(centers)]
+		pts = append(pts, [2]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+	km, err := kmeans.NewHarmonic(pts, 3.5, 1e-6, 500)
+	c.Assert(err, check.Equals, nil)
+
+	// Start with all the centers in one cluster, a poor initialization for
+	// Lloyd's algorithm.
+	km.SetCenters([]cluster.Center{
+		center{0, 0}, center{0.5, 0}, center{0, 0.5},
+	})
+	c.Assert(km.Cluster(), check.Equals, nil)
+	for _, cen := range km.Centers() {
+		var found bool
+		for _, m := range centers {
+			if sqDist(cen.V(), m) < 1 {
+				found = true
+			}
+		}
+		c.Check(found, check.Equals, true, check.Commentf("center at %v", cen.V()))
+		c.Check(len(cen.Members()), check.Equals, 100)
+	}
+	c.Check(km.Performance() > 0, check.Equals, true)
+
+	for _, p := range []float64{1, math.NaN(), math.Inf(1)} {
+		_, err = kmeans.NewHarmonic(pts, p, 1e-6, 500)
+		c.Check(err, check.ErrorMatches, "kmeans: invalid harmonic exponent", check.Commentf("p=%v", p))
+	}
+}
+
+type center []float64
+
+func (c center) V() []float64             { return c }
+func (c center) Members() cluster.Indices { return nil }
+
+func (s *S) TestDimensions(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	centers := [][]float64{
+		{0, 0, 0, 0, 0},
+		{10, 0, 10, 0, 10},
+		{0, 10, 0, 10, 0},
+	}
+	var vecs Vectors
+	for i := 0; i < 300; i++ {
+		m := centers[i%len(centers)]
+		v := make([]float64, len(m))
+		for j := range v {
+			v[j] = m[j] + rnd.NormFloat64()
+		}
+		vecs = append(vecs, v)
+	}
+	km, err := kmeans.NewSeeded(vecs, 1)
+	c.Assert(err, check.Equals, nil)
+	km.SetCenters([]cluster.Center{center(vecs[0]), center(vecs[1]), center(vecs[2])})
+	c.Assert(km.Cluster(), check.Equals, nil)
+	for i, cen := range km.Centers() {
+		c.Check(len(cen.V()), check.Equals, 5)
+		c.Check(sqDist(cen.V(), centers[i]) < 0.5, check.Equals, true, check.Commentf("center at %v", cen.V()))
+		c.Check(len(cen.Members()), check.Equals, 100)
+	}
+
+	_, err = kmeans.New(Vectors{{1, 2, 3}, {1, 2}})
+	c.Check(err, check.ErrorMatches, "kmeans: mismatched dimensions")
+}
+
+// weighted is a set of weighted one-dimensional values.
+type weighted struct {
+	v, w []float64
+}
+
+func (d weighted) Len() int               { return len(d.v) }
+func (d weighted) Values(i int) []float64 { return d.v[i : i+1] }
+func (d weighted) Weight(i int) float64   { return d.w[i] }
+
+func (s *S) TestWeighted(c *check.C) {
+	data := weighted{
+		v: []float64{0, 1, 10, 11},
+		w: []float64{3, 1, 1, 4},
+	}
+	km, err := kmeans.New(data)
+	c.Assert(err, check.Equals, nil)
+	km.SetCenters([]cluster.Center{center{0}, center{10}})
+	c.Assert(km.Cluster(), check.Equals, nil)
+	cens := km.Centers()
+	c.Check(cens[0].V(), check.DeepEquals, []float64{0.25})
+	c.Check(cens[1].V(), check.DeepEquals, []float64{10.8})
+	within := km.Within()
+	c.Check(within[0], check.Equals, 3*0.25*0.25+0.75*0.75)
+	c.Check(math.Abs(within[1]-(0.8*0.8+4*0.2*0.2)) < 1e-12, check.Equals, true)
+	// The weighted mean is 55/9.
+	c.Check(math.Abs(km.Total()-(585-55*55/9.)) < 1e-12, check.Equals, true)
+}
+
+func (s *S) TestElkan(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var pts bench
+	for i := 0; i < 2000; i++ {
+		pts = append(pts, [2]float64{rnd.Float64() * 100, rnd.Float64() * 100})
+	}
+	var init []cluster.Center
+	for _, i := range rnd.Perm(len(pts))[:15] {
+		init = append(init, center(pts[i][:]))
+	}
+
+	naive, err := kmeans.NewSeeded(pts, 1)
+	c.Assert(err, check.Equals, nil)
+	naive.SetCenters(init)
+	c.Assert(naive.Cluster(), check.Equals, nil)
+
+	elkan, err := kmeans.NewSeeded(pts, 1)
+	c.Assert(err, check.Equals, nil)
+	elkan.SetAcceleration(kmeans.Elkan)
+	elkan.SetCenters(init)
+	c.Assert(elkan.Cluster(), check.Equals, nil)
+
+	for i, v := range elkan.Values() {
+		c.Check(v.Cluster(), check.Equals, naive.Values()[i].Cluster())
+	}
+	for i, cen := range elkan.Centers() {
+		c.Check(cen.V(), check.DeepEquals, naive.Centers()[i].V())
+	}
+}
+
+func (s *S) TestFiltering(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var pts bench
+	for i := 0; i < 2000; i++ {
+		pts = append(pts, [2]float64{rnd.Float64() * 100, rnd.Float64() * 100})
+	}
+	var init []cluster.Center
+	for _, i := range rnd.Perm(len(pts))[:15] {
+		init = append(init, center(pts[i][:]))
+	}
+
+	naive, err := kmeans.NewSeeded(pts, 1)
+	c.Assert(err, check.Equals, nil)
+	naive.SetCenters(init)
+	c.Assert(naive.Cluster(), check.Equals, nil)
+
+	filter, err := kmeans.NewSeeded(pts, 1)
+	c.Assert(err, check.Equals, nil)
+	filter.SetAcceleration(kmeans.Filtering)
+	filter.SetCenters(init)
+	c.Assert(filter.Cluster(), check.Equals, nil)
+
+	for i, v := range filter.Values() {
+		c.Check(v.Cluster(), check.Equals, naive.Values()[i].Cluster())
+	}
+	// The filtering algorithm sums whole cells, so the centers differ from
+	// those found by exhaustive search only by rounding.
+	for i, cen := range filter.Centers() {
+		c.Check(sqDist(cen.V(), naive.Centers()[i].V()) < 1e-20, check.Equals, true)
+		c.Check(cen.Members(), check.DeepEquals, naive.Centers()[i].Members())
+	}
+	want := naive.Diagnostics()
+	got := filter.Diagnostics()
+	c.Assert(len(got), check.Equals, len(want))
+	for i, d := range got {
+		c.Check(d.Reassigned, check.Equals, want[i].Reassigned, check.Commentf("iteration %d", i))
+		c.Check(math.Abs(d.Inertia-want[i].Inertia) < 1e-9*want[i].Inertia, check.Equals, true, check.Commentf("iteration %d", i))
+	}
+
+	// Assignments are current after each step.
+	naive.SetCenters(init)
+	filter.SetCenters(init)
+	for i := 0; i < 3; i++ {
+		naive.Step()
+		filter.Step()
+		c.Check(filter.Assignments(), check.DeepEquals, naive.Assignments(), check.Commentf("step %d", i))
+	}
+}
+
+func (s *S) TestSeedParallel(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var centers [][]float64
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			centers = append(centers, []float64{float64(i)*20 + 10, float64(j)*20 + 10})
+		}
+	}
+	var pts bench
+	for i := 0; i < 5000; i++ {
+		m := centers[i%len(centers)]
+		pts = append(pts, [2]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+	km, err := kmeans.NewSeeded(pts, 1)
+	c.Assert(err, check.Equals, nil)
+	best := math.Inf(1)
+	for i := 0; i < 3; i++ {
+		km.SeedParallel(len(centers), 0, 0)
+		c.Check(len(km.Centers()), check.Equals, len(centers))
+		c.Check(km.Manifest().Parameters["seeding"], check.Equals, "kmeans||")
+		c.Assert(km.Cluster(), check.Equals, nil)
+		var ss float64
+		for _, w := range km.Within() {
+			ss += w
+		}
+		best = math.Min(best, ss)
+	}
+	// The optimal clustering has a within sum of squares of about 2 per value.
+	c.Check(best < 1.5*2*float64(len(pts)), check.Equals, true, check.Commentf("within sum of squares %v", best))
+}
+
+func (s *S) TestLimits(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var pts bench
+	for i := 0; i < 5000; i++ {
+		pts = append(pts, [2]float64{rnd.NormFloat64(), rnd.NormFloat64()})
+	}
+	var init []cluster.Center
+	for _, i := range rnd.Perm(len(pts))[:20] {
+		init = append(init, center(pts[i][:]))
+	}
+
+	km, err := kmeans.NewSeeded(pts, 1)
+	c.Assert(err, check.Equals, nil)
+	km.SetCenters(init)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	full := km.Manifest().Iterations
+	c.Assert(full > 5, check.Equals, true)
+
+	km.SetLimits(3, 0)
+	km.SetCenters(init)
+	err = km.Cluster()
+	c.Check(err, check.Equals, kmeans.ErrMaxIterations)
+	c.Check(errors.Is(err, cluster.ErrMaxIterations), check.Equals, true)
+	c.Check(km.Manifest().Iterations, check.Equals, 3)
+	for i, v := range km.Values() {
+		n := 0
+		for j, cen := range km.Centers() {
+			if sqDist(pts[i][:], cen.V()) < sqDist(pts[i][:], km.Centers()[n].V()) {
+				n = j
+			}
+		}
+		c.Check(v.Cluster(), check.Equals, n)
+	}
+
+	km.SetLimits(0, 1e-2)
+	km.SetCenters(init)
+	c.Check(km.Cluster(), check.Equals, nil)
+	c.Check(km.Manifest().Iterations < full, check.Equals, true)
+}
+
+func (s *S) TestEmpty(c *check.C) {
+	pts := bench{{0, 0}, {1, 0}, {0, 1}, {10, 10}, {11, 10}, {10, 11}}
+	// The third center is nearer to no value.
+	init := []cluster.Center{center{0, 0}, center{10, 10}, center{100, 100}}
+
+	for _, t := range []struct {
+		policy kmeans.EmptyPolicy
+		k      int
+	}{
+		{policy: kmeans.Reseed, k: 3},
+		{policy: kmeans.Split, k: 3},
+		{policy: kmeans.Drop, k: 2},
+	} {
+		km, err := kmeans.New(pts)
+		c.Assert(err, check.Equals, nil)
+		km.SetEmptyPolicy(t.policy)
+		km.SetCenters(init)
+		c.Assert(km.Cluster(), check.Equals, nil)
+		c.Check(km.Empties(), check.DeepEquals, []kmeans.Empty{{Iteration: 0, Center: 2, Policy: t.policy}})
+		cens := km.Centers()
+		c.Assert(len(cens), check.Equals, t.k)
+		for _, cen := range cens {
+			c.Check(len(cen.Members()) > 0, check.Equals, true)
+			for _, x := range cen.V() {
+				c.Check(math.IsNaN(x), check.Equals, false)
+			}
+		}
+	}
+}
+
+func (s *S) TestSeedFrom(c *check.C) {
+	pts := bench{{0, 0}, {1, 0}, {0, 1}, {10, 10}, {11, 10}, {10, 11}}
+	km, err := kmeans.New(pts)
+	c.Assert(err, check.Equals, nil)
+	protos := [][]float64{{10, 10}, {0, 0}}
+	c.Assert(km.SeedFrom(protos), check.Equals, nil)
+	protos[0][0] = -1
+	c.Assert(km.Cluster(), check.Equals, nil)
+	var got []cluster.Indices
+	for _, cen := range km.Centers() {
+		got = append(got, cen.Members())
+	}
+	c.Check(got, check.DeepEquals, []cluster.Indices{{3, 4, 5}, {0, 1, 2}})
+	c.Check(km.Manifest().Parameters["seeding"], check.Equals, "user")
+
+	c.Check(km.SeedFrom(nil), check.ErrorMatches, "kmeans: no centers")
+	c.Check(km.SeedFrom([][]float64{{1}}), check.ErrorMatches, "kmeans: mismatched dimensions")
+}
+
+func (s *S) TestSeeded(c *check.C) {
+	var pts bench
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		pts = append(pts, [2]float64{rnd.Float64() * 100, rnd.Float64() * 100})
+	}
+	var means [][][]float64
+	for i := 0; i < 2; i++ {
+		// Disturb the global source; seeded clusterings must not depend on it.
+		rand.Int63()
+		km, err := kmeans.NewSeeded(pts, 42)
+		c.Assert(err, check.Equals, nil)
+		km.Seed(10)
+		c.Assert(km.Cluster(), check.Equals, nil)
+		var m [][]float64
+		for _, cen := range km.Centers() {
+			m = append(m, cen.V())
+		}
+		means = append(means, m)
+		c.Check(*km.Manifest().Seed, check.Equals, int64(42))
+	}
+	c.Check(means[0], check.DeepEquals, means[1])
+
+	for j, fn := range []func() (seeder, error){
+		func() (seeder, error) { return kmeans.NewSoft(pts, 1, 1e-9, 100) },
+		func() (seeder, error) { return kmeans.NewHarmonic(pts, 3.5, 1e-6, 100) },
+		func() (seeder, error) {
+			return kmeans.NewIsodata(pts, kmeans.IsodataParams{K: 10, MaxSD: 20, MinDistance: 5, MaxMerges: 2, MaxIter: 5})
+		},
+	} {
+		var means [2][][]float64
+		for i := range means {
+			rand.Int63()
+			km, err := fn()
+			c.Assert(err, check.Equals, nil)
+			km.SetRand(rand.New(rand.NewSource(42)))
+			km.Seed(10)
+			c.Assert(km.Cluster(), check.Equals, nil)
+			for _, cen := range km.Centers() {
+				means[i] = append(means[i], cen.V())
+			}
+		}
+		c.Check(means[0], check.DeepEquals, means[1], check.Commentf("constructor %d", j))
+	}
+}
+
+// seeder is the seeding and clustering behaviour shared by the kmeans types.
+type seeder interface {
+	SetRand(*rand.Rand)
+	Seed(k int)
+	Cluster() error
+	Centers() []cluster.Center
+}
+
+func (s *S) TestDivergence(c *check.C) {
+	c.Check(math.Abs(kmeans.KL([]float64{0.5, 0.5}, []float64{0.25, 0.75})-(0.5*math.Log(2)+0.5*math.Log(2./3))) < 1e-15, check.Equals, true)
+	c.Check(kmeans.KL([]float64{0, 1}, []float64{0.5, 0.5}), check.Equals, math.Log(2))
+	c.Check(math.IsInf(kmeans.KL([]float64{1, 0}, []float64{0, 1}), 1), check.Equals, true)
+	c.Check(math.Abs(kmeans.ItakuraSaito([]float64{1, 2}, []float64{2, 4})-2*(0.5+math.Log(2)-1)) < 1e-15, check.Equals, true)
+	c.Check(kmeans.SquaredEuclidean([]float64{0, 0}, []float64{3, 4}), check.Equals, 25.)
+
+	pts := Vectors{
+		{0.8, 0.1, 0.1}, {0.7, 0.2, 0.1}, {0.75, 0.15, 0.1},
+		{0.1, 0.1, 0.8}, {0.1, 0.2, 0.7}, {0.2, 0.1, 0.7},
+	}
+	for _, div := range []kmeans.Divergence{kmeans.KL, kmeans.ItakuraSaito} {
+		for _, accel := range []kmeans.Acceleration{kmeans.Naive, kmeans.Elkan, kmeans.Filtering} {
+			km, err := kmeans.New(pts)
+			c.Assert(err, check.Equals, nil)
+			km.SetDivergence(div)
+			km.SetAcceleration(accel)
+			c.Assert(km.SeedFrom([][]float64{{0.6, 0.2, 0.2}, {0.2, 0.2, 0.6}}), check.Equals, nil)
+			c.Assert(km.Cluster(), check.Equals, nil)
+			var got []cluster.Indices
+			var within float64
+			for _, cen := range km.Centers() {
+				got = append(got, cen.Members())
+				for _, i := range cen.Members() {
+					within += div(pts[i], cen.V())
+				}
+			}
+			c.Check(got, check.DeepEquals, []cluster.Indices{{0, 1, 2}, {3, 4, 5}})
+			var sum float64
+			for _, w := range km.Within() {
+				sum += w
+			}
+			c.Check(math.Abs(sum-within) < 1e-12, check.Equals, true)
+			c.Check(sum < km.Total(), check.Equals, true)
+		}
+	}
+}
+
+func (s *S) TestPredict(c *check.C) {
+	pts := bench{{0, 0}, {1, 0}, {0, 1}, {10, 10}, {11, 10}, {10, 11}}
+	km, err := kmeans.New(pts)
+	c.Assert(err, check.Equals, nil)
+	c.Check(km.Assignments(), check.IsNil)
+	n, d := km.Predict([]float64{0, 0})
+	c.Check(n, check.Equals, -1)
+	c.Check(math.IsInf(d, 1), check.Equals, true)
+
+	c.Assert(km.SeedFrom([][]float64{{0, 0}, {10, 10}}), check.Equals, nil)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	clusters, dists := km.PredictAll(pts)
+	c.Check(km.Distances(), check.DeepEquals, dists)
+	c.Check(km.Assignments(), check.DeepEquals, clusters)
+	var within [2]float64
+	for i, v := range km.Values() {
+		c.Check(clusters[i], check.Equals, v.Cluster())
+		within[clusters[i]] += dists[i]
+	}
+	for i, w := range km.Within() {
+		c.Check(math.Abs(within[i]-w) < 1e-12, check.Equals, true)
+	}
+
+	n, d = km.Predict([]float64{9, 9})
+	c.Check(n, check.Equals, 1)
+	var p cluster.Predictor = km
+	c.Check(p.Assign([]float64{9, 9}), check.Equals, 1)
+	c.Check(p.Assign([]float64{-1, 2}), check.Equals, 0)
+	c.Check(math.Abs(d-2*math.Pow(1+1./3, 2)) < 1e-12, check.Equals, true)
+}
+
+func (s *S) TestDiagnostics(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var pts bench
+	for _, m := range [][2]float64{{10, 10}, {20, 10}, {15, 20}} {
+		for i := 0; i < 50; i++ {
+			pts = append(pts, [2]float64{m[0] + rnd.NormFloat64()*2, m[1] + rnd.NormFloat64()*2})
+		}
+	}
+	km, err := kmeans.NewSeeded(pts, 1)
+	c.Assert(err, check.Equals, nil)
+	km.Seed(3)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	diag := km.Diagnostics()
+	c.Assert(len(diag), check.Equals, km.Manifest().Iterations)
+	for i, d := range diag {
+		c.Check(d.Iteration, check.Equals, i)
+		c.Check(d.Movement >= 0, check.Equals, true)
+		if i > 0 {
+			c.Check(d.Inertia <= diag[i-1].Inertia+1e-9, check.Equals, true)
+		}
+	}
+	last := diag[len(diag)-1]
+	c.Check(last.Reassigned, check.Equals, 0)
+	var within float64
+	for _, w := range km.Within() {
+		within += w
+	}
+	c.Check(math.Abs(last.Inertia-within) < 1e-9, check.Equals, true)
+}
+
+func (s *S) TestSoft(c *check.C) {
+	pts := bench{{0, 0}, {1, 0}, {0, 1}, {10, 10}, {9, 10}, {10, 9}, {5, 5}}
+	km, err := kmeans.NewSoft(pts, 1, 1e-9, 100)
+	c.Assert(err, check.Equals, nil)
+	c.Check(km.Memberships(), check.IsNil)
+	c.Check(km.Assign([]float64{0, 0}), check.Equals, -1)
+	km.SetCenters([]cluster.Center{center{0, 0}, center{10, 10}})
+	c.Assert(km.Cluster(), check.Equals, nil)
+
+	var got []cluster.Indices
+	for _, cen := range km.Centers() {
+		got = append(got, cen.Members())
+	}
+	c.Check(got[0][:3], check.DeepEquals, cluster.Indices{0, 1, 2})
+	c.Check(got[1][:3], check.DeepEquals, cluster.Indices{3, 4, 5})
+
+	var sc cluster.SoftClusterer = km
+	m := sc.Memberships()
+	c.Assert(len(m), check.Equals, len(pts))
+	for i, r := range m {
+		c.Check(math.Abs(r[0]+r[1]-1) < 1e-12, check.Equals, true)
+		switch {
+		case i < 3:
+			c.Check(r[0] > 0.99, check.Equals, true)
+		case i < 6:
+			c.Check(r[1] > 0.99, check.Equals, true)
+		default:
+			// The last value lies midway between the symmetric clusters.
+			c.Check(math.Abs(r[0]-0.5) < 1e-6, check.Equals, true)
+		}
+	}
+	c.Check(km.Assign([]float64{2, 1}), check.Equals, 0)
+	c.Check(km.Assign([]float64{8, 9}), check.Equals, 1)
+
+	_, err = kmeans.NewSoft(pts, 0, 1e-9, 100)
+	c.Check(err, check.ErrorMatches, "kmeans: invalid stiffness")
+}
+
+func (s *S) TestSeedGlobal(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	centers := [][]float64{{10, 10}, {30, 10}, {10, 30}, {30, 30}, {50, 20}}
+	var pts bench
+	for i := 0; i < 200; i++ {
+		m := centers[i%len(centers)]
+		pts = append(pts, [2]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+	for _, fast := range []bool{false, true} {
+		var means [][][]float64
+		for r := 0; r < 2; r++ {
+			km, err := kmeans.NewSeeded(pts, 1)
+			c.Assert(err, check.Equals, nil)
+			km.SeedGlobal(len(centers), fast)
+			c.Check(km.Manifest().Parameters["seeding"], check.Equals, "global")
+			c.Assert(km.Cluster(), check.Equals, nil)
+			var m [][]float64
+			for _, cen := range km.Centers() {
+				m = append(m, cen.V())
+				c.Check(len(cen.Members()), check.Equals, 40)
+			}
+			for _, want := range centers {
+				var found bool
+				for _, got := range m {
+					if sqDist(got, want) < 0.5 {
+						found = true
+					}
+				}
+				c.Check(found, check.Equals, true, check.Commentf("fast=%t missed center %v", fast, want))
+			}
+			means = append(means, m)
+		}
+		c.Check(means[0], check.DeepEquals, means[1])
+	}
+}
+
+func (s *S) TestStep(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var pts bench
+	for _, m := range [][2]float64{{10, 10}, {20, 10}, {15, 20}} {
+		for i := 0; i < 50; i++ {
+			pts = append(pts, [2]float64{m[0] + rnd.NormFloat64()*2, m[1] + rnd.NormFloat64()*2})
+		}
+	}
+	init := [][]float64{{10, 10}, {11, 10}, {10, 11}}
+
+	km, err := kmeans.NewSeeded(pts, 1)
+	c.Assert(err, check.Equals, nil)
+	_, done := km.Step()
+	c.Check(done, check.Equals, true)
+	c.Check(km.Err(), check.ErrorMatches, "kmeans: no centers")
+
+	c.Assert(km.SeedFrom(init), check.Equals, nil)
+	var steps int
+	for {
+		delta, done := km.Step()
+		steps++
+		c.Check(delta >= 0, check.Equals, true)
+		if done {
+			break
+		}
+	}
+	c.Assert(km.Err(), check.Equals, nil)
+	c.Check(steps, check.Equals, km.Manifest().Iterations)
+
+	ref, err := kmeans.NewSeeded(pts, 1)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(ref.SeedFrom(init), check.Equals, nil)
+	c.Assert(ref.Cluster(), check.Equals, nil)
+	c.Check(km.Centers(), check.DeepEquals, ref.Centers())
+}
+
+func (s *S) TestPin(c *check.C) {
+	pts := bench{{0, 0}, {1, 0}, {0, 1}, {10, 10}, {11, 10}, {10, 11}}
+	km, err := kmeans.New(pts)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(km.SeedFrom([][]float64{{1, 1}, {9, 9}, {100, 100}}), check.Equals, nil)
+	c.Check(km.Pin(3), check.ErrorMatches, "kmeans: pin index out of range")
+	c.Assert(km.Pin(0, 2), check.Equals, nil)
+	c.Check(km.Pinned(), check.DeepEquals, []int{0, 2})
+	c.Assert(km.Cluster(), check.Equals, nil)
+
+	cens := km.Centers()
+	c.Assert(len(cens), check.Equals, 3)
+	c.Check(cens[0].V(), check.DeepEquals, []float64{1, 1})
+	c.Check(cens[0].Members(), check.DeepEquals, cluster.Indices{0, 1, 2})
+	c.Check(math.Abs(sqDist(cens[1].V(), []float64{31. / 3, 31. / 3})) < 1e-12, check.Equals, true)
+	c.Check(cens[1].Members(), check.DeepEquals, cluster.Indices{3, 4, 5})
+	// The empty pinned center is not reseeded.
+	c.Check(cens[2].V(), check.DeepEquals, []float64{100, 100})
+	c.Check(len(cens[2].Members()), check.Equals, 0)
+	c.Check(km.Empties(), check.HasLen, 0)
+
+	km.Seed(2)
+	c.Check(km.Pinned(), check.HasLen, 0)
+}
+
+func (s *S) TestIsodata(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	centers := [][]float64{{10, 10}, {30, 10}, {20, 30}}
+	var pts bench
+	for i := 0; i < 300; i++ {
+		m := centers[i%len(centers)]
+		pts = append(pts, [2]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+	p := kmeans.IsodataParams{K: 3, MinMembers: 10, MaxSD: 2, MinDistance: 5, MaxMerges: 2, MaxIter: 20}
+	for _, k := range []int{1, 9} {
+		km, err := kmeans.NewIsodata(pts, p)
+		c.Assert(err, check.Equals, nil)
+		km.SetRand(rnd)
+		km.Seed(k)
+		c.Assert(km.Cluster(), check.Equals, nil)
+		cens := km.Centers()
+		c.Check(len(cens), check.Equals, len(centers), check.Commentf("initial k=%d", k))
+		for _, cen := range cens {
+			var found bool
+			for _, m := range centers {
+				if sqDist(cen.V(), m) < 0.5 {
+					found = true
+				}
+			}
+			c.Check(found, check.Equals, true, check.Commentf("initial k=%d center at %v", k, cen.V()))
+			c.Check(len(cen.Members()), check.Equals, 100)
+		}
+	}
+
+	_, err := kmeans.NewIsodata(pts, kmeans.IsodataParams{})
+	c.Check(err, check.ErrorMatches, "kmeans: invalid isodata parameters")
+}
+
+func (s *S) TestSerialize(c *check.C) {
+	pts := bench{{0, 0}, {1, 0}, {0, 1}, {10, 10}, {11, 10}, {10, 11}}
+	km, err := kmeans.NewSeeded(pts, 1)
+	c.Assert(err, check.Equals, nil)
+	_, err = json.Marshal(km)
+	c.Check(err, check.ErrorMatches, ".*kmeans: no centers")
+	km.SetLimits(10, 0)
+	c.Assert(km.SeedFrom([][]float64{{0, 0}, {10, 10}}), check.Equals, nil)
+	c.Assert(km.Cluster(), check.Equals, nil)
+
+	b, err := json.Marshal(km)
+	c.Assert(err, check.Equals, nil)
+	var fromJSON kmeans.Kmeans
+	c.Assert(json.Unmarshal(b, &fromJSON), check.Equals, nil)
+
+	var buf bytes.Buffer
+	c.Assert(gob.NewEncoder(&buf).Encode(km), check.Equals, nil)
+	var fromGob kmeans.Kmeans
+	c.Assert(gob.NewDecoder(&buf).Decode(&fromGob), check.Equals, nil)
+
+	for _, got := range []*kmeans.Kmeans{&fromJSON, &fromGob} {
+		c.Check(got.Assignments(), check.DeepEquals, km.Assignments())
+		c.Assert(len(got.Centers()), check.Equals, len(km.Centers()))
+		for i, cen := range got.Centers() {
+			c.Check(cen.V(), check.DeepEquals, km.Centers()[i].V())
+			c.Check(cen.Members(), check.DeepEquals, km.Centers()[i].Members())
+		}
+		for _, q := range [][]float64{{9, 9}, {-1, 2}, {5, 5.1}} {
+			gc, gd := got.Predict(q)
+			wc, wd := km.Predict(q)
+			c.Check(gc, check.Equals, wc)
+			c.Check(gd, check.Equals, wd)
+		}
+		c.Check(got.Manifest(), check.DeepEquals, km.Manifest())
+	}
+
+	c.Check(json.Unmarshal([]byte(`{"Centers":[[0],[1,2]],"Weights":[1,1]}`), &fromJSON), check.ErrorMatches, "kmeans: mismatched dimensions")
+}
+
+func (s *S) TestOptions(c *check.C) {
+	var pts bench
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		pts = append(pts, [2]float64{rnd.Float64() * 100, rnd.Float64() * 100})
+	}
+
+	var means [][][]float64
+	for _, opts := range [][]kmeans.Option{
+		{kmeans.WithSeed(42)},
+		{kmeans.WithSeed(42), kmeans.WithWorkers(4)},
+		{kmeans.WithSeed(42), kmeans.WithWorkers(0), kmeans.WithDivergence(kmeans.SquaredEuclidean)},
+	} {
+		km, err := kmeans.New(pts, opts...)
+		c.Assert(err, check.Equals, nil)
+		km.Seed(10)
+		c.Assert(km.Cluster(), check.Equals, nil)
+		var m [][]float64
+		for _, cen := range km.Centers() {
+			m = append(m, cen.V())
+		}
+		means = append(means, m)
+		c.Check(*km.Manifest().Seed, check.Equals, int64(42))
+	}
+	c.Check(means[1], check.DeepEquals, means[0])
+	c.Check(means[2], check.DeepEquals, means[0])
+
+	km, err := kmeans.New(pts, kmeans.WithRand(rand.New(rand.NewSource(42))))
+	c.Assert(err, check.Equals, nil)
+	km.Seed(10)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	var m [][]float64
+	for _, cen := range km.Centers() {
+		m = append(m, cen.V())
+	}
+	c.Check(m, check.DeepEquals, means[0])
+	c.Check(km.Manifest().Seed, check.IsNil)
+
+	km, err = kmeans.New(pts, kmeans.WithSeed(42), kmeans.WithMaxIter(1), kmeans.WithTolerance(1e-9))
+	c.Assert(err, check.Equals, nil)
+	p := km.Manifest().Parameters
+	c.Check(p["maxIter"], check.Equals, 1)
+	c.Check(p["tol"], check.Equals, 1e-9)
+	km.Seed(10)
+	c.Check(km.Cluster(), check.Equals, kmeans.ErrMaxIterations)
+}
+
+// countdown is a context that is cancelled after n calls to Err.
+type countdown struct {
+	context.Context
+	n int
+}
+
+func (c *countdown) Err() error {
+	if c.n == 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func (s *S) TestClusterContext(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var pts bench
+	for i := 0; i < 5000; i++ {
+		pts = append(pts, [2]float64{rnd.NormFloat64(), rnd.NormFloat64()})
+	}
+
+	km, err := kmeans.New(pts, kmeans.WithSeed(1))
+	c.Assert(err, check.Equals, nil)
+	km.Seed(20)
+	var cc cluster.ContextClusterer = km
+	err = cc.ClusterContext(&countdown{Context: context.Background(), n: 3})
+	c.Check(err, check.Equals, context.Canceled)
+	c.Check(km.Err(), check.Equals, context.Canceled)
+	c.Check(km.Manifest().Iterations, check.Equals, 3)
+	cens := km.Centers()
+	for i, v := range km.Values() {
+		n := 0
+		for j, cen := range cens {
+			if sqDist(pts[i][:], cen.V()) < sqDist(pts[i][:], cens[n].V()) {
+				n = j
+			}
+		}
+		c.Check(v.Cluster(), check.Equals, n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	soft, err := kmeans.NewSoft(pts, 1, 1e-6, 100)
+	c.Assert(err, check.Equals, nil)
+	soft.Seed(5)
+	c.Check(soft.ClusterContext(ctx), check.Equals, context.Canceled)
+	c.Check(soft.Centers(), check.HasLen, 5)
+	harm, err := kmeans.NewHarmonic(pts, 3.5, 1e-6, 100)
+	c.Assert(err, check.Equals, nil)
+	harm.Seed(5)
+	c.Check(harm.ClusterContext(ctx), check.Equals, context.Canceled)
+	sph, err := kmeans.NewSpherical(pts)
+	c.Assert(err, check.Equals, nil)
+	sph.Seed(5)
+	c.Check(sph.ClusterContext(ctx), check.Equals, context.Canceled)
+	iso, err := kmeans.NewIsodata(pts, kmeans.IsodataParams{K: 5, MaxSD: 1, MinDistance: 0.5, MaxMerges: 2, MaxIter: 10})
+	c.Assert(err, check.Equals, nil)
+	iso.Seed(5)
+	c.Check(iso.ClusterContext(ctx), check.Equals, context.Canceled)
+	c.Check(iso.Centers(), check.HasLen, 5)
+	n := 0
+	for _, cen := range iso.Centers() {
+		n += len(cen.Members())
+	}
+	c.Check(n, check.Equals, len(pts))
+}
+
+func (s *S) TestSparse(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	const dims = 50
+	dense := cluster.NewDense(600, dims)
+	dense.Weights = make([]float64, dense.Rows)
+	for i := 0; i < dense.Rows; i++ {
+		// Each of three groups uses its own block of features.
+		off := (i % 3) * 10
+		for j := 0; j < 4; j++ {
+			dense.Values(i)[off+rnd.Intn(10)] = 1 + rnd.Float64()
+		}
+		dense.Weights[i] = 1 + rnd.Float64()
+	}
+	sp, err := cluster.NewCSR(dense)
+	c.Assert(err, check.Equals, nil)
+
+	var init []cluster.Center
+	for _, i := range []int{0, 1, 2} {
+		init = append(init, center(dense.Values(i)))
+	}
+	want, err := kmeans.New(dense)
+	c.Assert(err, check.Equals, nil)
+	want.SetCenters(init)
+	c.Assert(want.Cluster(), check.Equals, nil)
+
+	got, err := kmeans.NewSparse(sp)
+	c.Assert(err, check.Equals, nil)
+	got.SetCenters(init)
+	c.Assert(got.Cluster(), check.Equals, nil)
+
+	c.Check(cluster.Assignments(got), check.DeepEquals, cluster.Assignments(want))
+	for i, cen := range got.Centers() {
+		w := want.Centers()[i]
+		for j, x := range cen.V() {
+			c.Check(math.Abs(x-w.V()[j]) < 1e-12, check.Equals, true)
+		}
+		c.Check(cen.Members(), check.DeepEquals, w.Members())
+	}
+	for i, ss := range got.Within() {
+		c.Check(math.Abs(ss-want.Within()[i]) < 1e-9*want.Within()[i], check.Equals, true)
+	}
+
+	var seeded [2]*kmeans.Sparse
+	for i := range seeded {
+		seeded[i], err = kmeans.NewSparse(sp)
+		c.Assert(err, check.Equals, nil)
+		seeded[i].SetRand(rand.New(rand.NewSource(1)))
+		seeded[i].Seed(3)
+		c.Assert(seeded[i].Cluster(), check.Equals, nil)
+	}
+	c.Check(cluster.Assignments(seeded[1]), check.DeepEquals, cluster.Assignments(seeded[0]))
+	c.Check(got.Values()[4].V(), check.DeepEquals, dense.Values(4))
+	c.Check(got.Assign(dense.Values(7)), check.Equals, got.Values()[7].Cluster())
+
+	got.SetRand(rand.New(rand.NewSource(1)))
+	got.Seed(3)
+	c.Assert(got.Cluster(), check.Equals, nil)
+	c.Check(got.Centers(), check.HasLen, 3)
+	for i, v := range got.Values() {
+		c.Check(v.Cluster(), check.Equals, got.Values()[i%3].Cluster())
+	}
+
+	_, err = kmeans.NewSparse(&cluster.CSR{Indptr: []int{0}})
+	c.Check(err, check.ErrorMatches, "kmeans: no data")
+}
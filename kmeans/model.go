@@ -0,0 +1,142 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// model is the serialized form of a fitted Kmeans.
+type model struct {
+	Centers     [][]float64
+	Weights     []float64
+	Assignments []int
+
+	Seeding    string
+	Seed       *int64
+	MaxIter    int
+	Tol        float64
+	Data       cluster.Fingerprint
+	Iterations int
+}
+
+func (km *Kmeans) model() (model, error) {
+	if len(km.means) == 0 {
+		return model{}, &cluster.Error{Pkg: "kmeans", Err: cluster.ErrNoCenters}
+	}
+	km.settle()
+	m := model{
+		Centers:     make([][]float64, len(km.means)),
+		Weights:     make([]float64, len(km.means)),
+		Assignments: make([]int, len(km.values)),
+		Seeding:     km.seeding,
+		Seed:        km.seed,
+		MaxIter:     km.maxIter,
+		Tol:         km.tol,
+		Data:        km.data,
+		Iterations:  km.iter,
+	}
+	for i, c := range km.means {
+		m.Centers[i] = c.point
+		m.Weights[i] = c.w
+	}
+	for i, v := range km.values {
+		m.Assignments[i] = v.cluster
+	}
+	return m, nil
+}
+
+func (km *Kmeans) setModel(m model) error {
+	if len(m.Centers) == 0 {
+		return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrNoCenters}
+	}
+	if len(m.Weights) != len(m.Centers) {
+		return errors.New("kmeans: mismatched center weights")
+	}
+	dims := len(m.Centers[0])
+	means := make([]center, len(m.Centers))
+	for i, c := range m.Centers {
+		if len(c) != dims {
+			return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrDimensionMismatch}
+		}
+		means[i] = center{point: c, w: m.Weights[i]}
+	}
+	values := make([]value, len(m.Assignments))
+	for i, c := range m.Assignments {
+		if c < 0 || c >= len(means) {
+			return errors.New("kmeans: invalid assignment")
+		}
+		values[i].cluster = c
+		means[c].count++
+	}
+	*km = Kmeans{
+		dims:    dims,
+		values:  values,
+		means:   means,
+		data:    m.Data,
+		seeding: m.Seeding,
+		seed:    m.Seed,
+		maxIter: m.MaxIter,
+		tol:     m.Tol,
+		iter:    m.Iterations,
+	}
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the fitted model held by km: its centers
+// and their weights, the assignments of the data and the information recorded in
+// its Manifest. The data values are not included. A divergence set by
+// SetDivergence can not be encoded.
+func (km *Kmeans) MarshalJSON() ([]byte, error) {
+	m, err := km.model()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON replaces the state of km with the fitted model encoded in b by
+// MarshalJSON. The restored Kmeans holds no data values, so it may be used to
+// Predict and Assign new points and to report its Centers, Assignments and
+// Manifest, but it can not be clustered and the Values it returns have no
+// coordinates. If the model was fitted using a divergence, the divergence must
+// be set again with SetDivergence.
+func (km *Kmeans) UnmarshalJSON(b []byte) error {
+	var m model
+	err := json.Unmarshal(b, &m)
+	if err != nil {
+		return fmt.Errorf("kmeans: %w", err)
+	}
+	return km.setModel(m)
+}
+
+// GobEncode returns the gob encoding of the fitted model held by km as described
+// for MarshalJSON.
+func (km *Kmeans) GobEncode() ([]byte, error) {
+	m, err := km.model()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = gob.NewEncoder(&buf).Encode(m)
+	return buf.Bytes(), err
+}
+
+// GobDecode replaces the state of km with the fitted model encoded in b by
+// GobEncode as described for UnmarshalJSON.
+func (km *Kmeans) GobDecode(b []byte) error {
+	var m model
+	err := gob.NewDecoder(bytes.NewReader(b)).Decode(&m)
+	if err != nil {
+		return fmt.Errorf("kmeans: %w", err)
+	}
+	return km.setModel(m)
+}
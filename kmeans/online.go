@@ -0,0 +1,188 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// Online maintains a bounded weighted sketch of an unbounded stream of ℝⁿ data
+// suitable for k-means clustering. The sketch is maintained by online facility
+// location: each observed point either opens a new sketch center, with probability
+// proportional to its squared distance from the nearest existing center, or is
+// merged into the nearest center. When the sketch grows beyond its bound, the
+// facility cost is increased and the sketch is re-summarised.
+type Online struct {
+	k, m   int
+	dims   int
+	f      float64
+	n      int
+	sketch []sketched
+	source
+}
+
+type sketched struct {
+	v []float64
+	w float64
+}
+
+// sketch is a weighted cluster.Interface over a set of sketched points.
+type sketch []sketched
+
+func (s sketch) Len() int               { return len(s) }
+func (s sketch) Values(i int) []float64 { return s[i].v }
+func (s sketch) Weight(i int) float64   { return s[i].w }
+
+// NewOnline returns a new Online that will produce k clusters from a sketch of at
+// most m weighted points. The value of m must be greater than k.
+func NewOnline(k, m int) (*Online, error) {
+	if k < 1 {
+		return nil, errors.New("kmeans: invalid k")
+	}
+	if m <= k {
+		return nil, errors.New("kmeans: sketch size too small")
+	}
+	return &Online{k: k, m: m}, nil
+}
+
+// SetRand sets the source of the random choices made when adding points to the
+// sketch and when seeding Cluster to rnd. If rnd is nil, the global math/rand
+// source is used.
+func (o *Online) SetRand(rnd *rand.Rand) { o.rnd = rnd }
+
+// Observe adds the point v with weight 1 to the sketch.
+func (o *Online) Observe(v []float64) error { return o.ObserveWeighted(v, 1) }
+
+// ObserveWeighted adds the point v with weight w to the sketch. The values in v are
+// copied.
+func (o *Online) ObserveWeighted(v []float64, w float64) error {
+	if o.dims == 0 {
+		o.dims = len(v)
+	} else if len(v) != o.dims {
+		return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrDimensionMismatch}
+	}
+	if w <= 0 {
+		return nil
+	}
+	o.n++
+	o.add(sketched{v: append([]float64(nil), v...), w: w})
+	for len(o.sketch) > o.m {
+		o.f *= 2
+		old := o.sketch
+		o.sketch = make([]sketched, 0, o.m+1)
+		for _, p := range old {
+			o.add(p)
+		}
+	}
+	return nil
+}
+
+// ReadFrom observes all the values of s, returning the number of values read.
+func (o *Online) ReadFrom(s cluster.Stream) (int, error) {
+	var n int
+	for {
+		v, w, ok := s.Next()
+		if !ok {
+			return n, nil
+		}
+		err := o.ObserveWeighted(v, w)
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// add adds p to the sketch by online facility location.
+func (o *Online) add(p sketched) {
+	if len(o.sketch) <= o.k {
+		// Collect the first k+1 distinct points and set the initial
+		// facility cost from their closest pair.
+		o.sketch = append(o.sketch, p)
+		if len(o.sketch) == o.k+1 && o.f == 0 {
+			o.f = math.Inf(1)
+			for i := range o.sketch {
+				for j := 0; j < i; j++ {
+					if d := sqDist(o.sketch[i].v, o.sketch[j].v); d > 0 && d < o.f {
+						o.f = d
+					}
+				}
+			}
+			if math.IsInf(o.f, 1) {
+				o.f = 0
+			}
+		}
+		return
+	}
+
+	c, min := -1, math.Inf(1)
+	for i, s := range o.sketch {
+		if d := sqDist(p.v, s.v); d < min {
+			c, min = i, d
+		}
+	}
+	if o.f == 0 {
+		if min == 0 {
+			o.merge(c, p)
+			return
+		}
+		o.f = min
+	}
+	if o.float64() < p.w*min/o.f {
+		o.sketch = append(o.sketch, p)
+		return
+	}
+	o.merge(c, p)
+}
+
+// merge merges p into sketch center c, placing c at the weighted mean.
+func (o *Online) merge(c int, p sketched) {
+	s := &o.sketch[c]
+	tw := s.w + p.w
+	for j := range s.v {
+		s.v[j] += (p.v[j] - s.v[j]) * p.w / tw
+	}
+	s.w = tw
+}
+
+func sqDist(a, b []float64) float64 {
+	var ss float64
+	for i, v := range a {
+		d := v - b[i]
+		ss += d * d
+	}
+	return ss
+}
+
+// Len returns the number of points observed.
+func (o *Online) Len() int { return o.n }
+
+// Sketch returns the current sketch as a weighted cluster.Interface.
+func (o *Online) Sketch() cluster.Interface {
+	return append(sketch(nil), o.sketch...)
+}
+
+// Cluster returns a k-means clustering of the current sketch. The Values of the
+// returned Kmeans are the sketched points, weighted by the total weight of the
+// observations they summarise.
+func (o *Online) Cluster() (*Kmeans, error) {
+	if len(o.sketch) == 0 {
+		return nil, &cluster.Error{Pkg: "kmeans", Err: cluster.ErrEmptyData}
+	}
+	km, err := New(o.Sketch(), WithRand(o.rnd))
+	if err != nil {
+		return nil, err
+	}
+	k := o.k
+	if k > len(o.sketch) {
+		k = len(o.sketch)
+	}
+	km.Seed(k)
+	return km, km.Cluster()
+}
@@ -0,0 +1,51 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"math/rand"
+)
+
+// Option is a functional option for New.
+type Option func(*Kmeans)
+
+// WithSeed returns an Option that draws the random choices made during seeding from
+// a source seeded with seed, as described for NewSeeded.
+func WithSeed(seed int64) Option {
+	return func(km *Kmeans) {
+		km.rnd = rand.New(rand.NewSource(seed))
+		km.seed = &seed
+	}
+}
+
+// WithRand returns an Option that draws the random choices made during seeding from
+// rnd, as described for SetRand.
+func WithRand(rnd *rand.Rand) Option {
+	return func(km *Kmeans) { km.SetRand(rnd) }
+}
+
+// WithMaxIter returns an Option that sets the maximum number of iterations made by
+// Cluster, as described for SetLimits.
+func WithMaxIter(n int) Option {
+	return func(km *Kmeans) { km.maxIter = n }
+}
+
+// WithTolerance returns an Option that sets the convergence tolerance of Cluster, as
+// described for SetLimits.
+func WithTolerance(tol float64) Option {
+	return func(km *Kmeans) { km.tol = tol }
+}
+
+// WithWorkers returns an Option that sets the number of goroutines used for
+// assignment, as described for SetWorkers.
+func WithWorkers(n int) Option {
+	return func(km *Kmeans) { km.SetWorkers(n) }
+}
+
+// WithDivergence returns an Option that sets the divergence minimized by Cluster, as
+// described for SetDivergence.
+func WithDivergence(d Divergence) Option {
+	return func(km *Kmeans) { km.SetDivergence(d) }
+}
@@ -0,0 +1,88 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"math"
+)
+
+// SeedParallel generates the initial means for the k-means algorithm according to
+// the k-means|| algorithm. Starting from a single randomly chosen value, rounds
+// passes are made over the data, each independently sampling values with
+// probability proportional to l times their weighted squared distance from the
+// nearest candidate already chosen, so that about l candidates are added in each
+// pass. The candidates are then weighted by the total weight of the values nearest
+// to them and reclustered into k means by k-means++ seeded k-means. If rounds is
+// less than one, five rounds are made. If l is not positive, 2k is used.
+//
+// Reference:
+//
+//	Bahmani B, Moseley B, Vattani A, Kumar R, Vassilvitskii S. Scalable k-means++.
+//	Proceedings of the VLDB Endowment 5(7):622-633 (2012).
+func (km *Kmeans) SeedParallel(k int, l float64, rounds int) {
+	if rounds < 1 {
+		rounds = 5
+	}
+	if l <= 0 {
+		l = 2 * float64(k)
+	}
+
+	d := make([]float64, len(km.values))
+	for i := range d {
+		d[i] = math.Inf(1)
+	}
+	near := make([]int, len(km.values))
+	cand := []int{km.intn(len(km.values))}
+	update := func(from int) (cost float64) {
+		for i, v := range km.values {
+			for c := from; c < len(cand); c++ {
+				if dd := km.divergence(v.point, km.values[cand[c]].point); dd < d[i] {
+					d[i], near[i] = dd, c
+				}
+			}
+			cost += v.w * d[i]
+		}
+		return cost
+	}
+	cost := update(0)
+	for r := 0; r < rounds && cost > 0; r++ {
+		from := len(cand)
+		for i, v := range km.values {
+			if km.float64() < l*v.w*d[i]/cost {
+				cand = append(cand, i)
+			}
+		}
+		cost = update(from)
+	}
+
+	if len(cand) <= k {
+		// Too few candidates were found to recluster, so fall back
+		// to k-means++ over the complete data.
+		km.Seed(k)
+		return
+	}
+
+	w := make([]float64, len(cand))
+	for i, v := range km.values {
+		w[near[i]] += v.w
+	}
+	sk := make(sketch, len(cand))
+	for c, i := range cand {
+		sk[c] = sketched{v: km.values[i].point, w: w[c]}
+	}
+	rc, _ := New(sk)
+	rc.rnd = km.rnd
+	rc.div = km.div
+	rc.Seed(k)
+	rc.Cluster()
+
+	km.iter = 0
+	km.run = nil
+	km.seeding = "kmeans||"
+	km.means = make([]center, k)
+	for i, m := range rc.means {
+		km.means[i] = center{point: append(point(nil), m.point...)}
+	}
+}
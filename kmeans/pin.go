@@ -0,0 +1,35 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import "errors"
+
+// Pin fixes the locations of the centers with the given indices so that Cluster
+// assigns values to them but does not move them. This allows known prototypes to
+// be supplied, for example with SeedFrom, while the remaining centers are
+// optimized. Pinned centers are never reseeded or dropped when empty. Pins are
+// cleared when the centers are next seeded or set.
+func (km *Kmeans) Pin(indices ...int) error {
+	for _, i := range indices {
+		if i < 0 || i >= len(km.means) {
+			return errors.New("kmeans: pin index out of range")
+		}
+	}
+	for _, i := range indices {
+		km.means[i].pinned = true
+	}
+	return nil
+}
+
+// Pinned returns the indices of the pinned centers.
+func (km *Kmeans) Pinned() []int {
+	var p []int
+	for i, m := range km.means {
+		if m.pinned {
+			p = append(p, i)
+		}
+	}
+	return p
+}
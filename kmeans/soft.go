@@ -0,0 +1,237 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// Soft implements soft k-means clustering of ℝⁿ data. Each value is a member of
+// every cluster with a probability proportional to exp(-β d²), where d is the
+// distance from the value to the cluster's center and β is the stiffness, and each
+// center is the membership weighted mean of the values. As β increases, Soft
+// approaches Lloyd's algorithm. After convergence, each value is assigned to its
+// most probable, and so nearest, center.
+//
+// Reference:
+//
+//	MacKay DJC. Information Theory, Inference, and Learning Algorithms. Chapter 20.
+//	Cambridge University Press (2003).
+type Soft struct {
+	beta    float64
+	tol     float64
+	maxIter int
+
+	dims   int
+	values []value
+	means  []center
+	resp   [][]float64
+	source
+}
+
+// NewSoft creates a new soft k-means object populated with data from an Interface
+// value, data, using the stiffness beta, which must be positive. Cluster iterates
+// until no center moves by more than tol or until maxIter iterations have been
+// made.
+func NewSoft(data cluster.Interface, beta, tol float64, maxIter int) (*Soft, error) {
+	if !(beta > 0) || math.IsInf(beta, 1) {
+		return nil, errors.New("kmeans: invalid stiffness")
+	}
+	v, d, err := convert(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Soft{beta: beta, tol: tol, maxIter: maxIter, dims: d, values: v}, nil
+}
+
+// SetRand sets the source of the random choices made by Seed to rnd. If rnd is nil,
+// the global math/rand source is used.
+func (km *Soft) SetRand(rnd *rand.Rand) { km.rnd = rnd }
+
+// Seed generates the initial means for the soft k-means algorithm according to the
+// k-means++ algorithm.
+func (km *Soft) Seed(k int) {
+	s := Kmeans{dims: km.dims, values: km.values, source: km.source}
+	s.Seed(k)
+	km.means = s.means
+	km.resp = nil
+}
+
+// SetCenters sets the locations of the centers to c.
+func (km *Soft) SetCenters(c []cluster.Center) {
+	km.means = make([]center, len(c))
+	for i, cv := range c {
+		km.means[i] = center{point: append(point(nil), cv.V()...)}
+	}
+	km.resp = nil
+}
+
+// Cluster runs a clustering of the data using the soft k-means algorithm.
+func (km *Soft) Cluster() error {
+	return km.ClusterContext(context.Background())
+}
+
+// ClusterContext runs a clustering of the data using the soft k-means algorithm,
+// checking ctx before each iteration. If ctx is done, ClusterContext returns
+// ctx.Err() and the clustering state is that of the last completed iteration.
+func (km *Soft) ClusterContext(ctx context.Context) error {
+	if len(km.means) == 0 {
+		return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrNoCenters}
+	}
+	k := len(km.means)
+	km.resp = make([][]float64, len(km.values))
+	for i := range km.resp {
+		km.resp[i] = make([]float64, k)
+	}
+	q := make([]float64, k)
+	sum := make([][]float64, k)
+	for j := range sum {
+		sum[j] = make([]float64, km.dims)
+	}
+	var err error
+	for it := 0; ; it++ {
+		km.responsibilities()
+		if it >= km.maxIter {
+			break
+		}
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		for j := range q {
+			q[j] = 0
+			for l := range sum[j] {
+				sum[j][l] = 0
+			}
+		}
+		for i, v := range km.values {
+			for j, r := range km.resp[i] {
+				w := v.w * r
+				q[j] += w
+				for l, x := range v.point {
+					sum[j][l] += w * x
+				}
+			}
+		}
+		var moved float64
+		for j := range km.means {
+			if q[j] == 0 {
+				continue
+			}
+			for l := range sum[j] {
+				sum[j][l] /= q[j]
+			}
+			moved = math.Max(moved, sqDist(sum[j], km.means[j].point))
+			copy(km.means[j].point, sum[j])
+		}
+		if moved <= km.tol*km.tol {
+			km.responsibilities()
+			break
+		}
+	}
+
+	for i := range km.means {
+		km.means[i].w = 0
+		km.means[i].count = 0
+		km.means[i].indices = km.means[i].indices[:0]
+	}
+	for i, v := range km.values {
+		c := 0
+		for j, r := range km.resp[i] {
+			if r > km.resp[i][c] {
+				c = j
+			}
+		}
+		km.values[i].cluster = c
+		km.means[c].w += v.w
+		km.means[c].count++
+		km.means[c].indices = append(km.means[c].indices, i)
+	}
+	return err
+}
+
+// responsibilities calculates the membership probabilities of each value for the
+// current centers. Distances are taken relative to the nearest center to avoid
+// underflow.
+func (km *Soft) responsibilities() {
+	for i, v := range km.values {
+		r := km.resp[i]
+		min := math.Inf(1)
+		for j, m := range km.means {
+			r[j] = sqDist(v.point, m.point)
+			min = math.Min(min, r[j])
+		}
+		var sum float64
+		for j := range r {
+			r[j] = math.Exp(-km.beta * (r[j] - min))
+			sum += r[j]
+		}
+		for j := range r {
+			r[j] /= sum
+		}
+	}
+}
+
+// Memberships returns the probability of membership of each value in each of the
+// clusters returned by Centers. The ith row of the returned matrix holds the
+// membership probabilities of the ith value. Returns nil if Cluster has not been
+// called.
+func (km *Soft) Memberships() [][]float64 {
+	if km.resp == nil {
+		return nil
+	}
+	m := make([][]float64, len(km.resp))
+	for i, r := range km.resp {
+		m[i] = append([]float64(nil), r...)
+	}
+	return m
+}
+
+// Assign returns the index of the center nearest to x, which is the cluster of
+// greatest membership probability. If there are no centers, Assign returns -1.
+func (km *Soft) Assign(x []float64) int {
+	c, min := -1, math.Inf(1)
+	for j, m := range km.means {
+		if d := sqDist(x, m.point); d < min {
+			c, min = j, d
+		}
+	}
+	return c
+}
+
+// Within calculates the weighted sum of squares within each cluster using the hard
+// assignments of the values. Returns nil if Cluster has not been called.
+func (km *Soft) Within() []float64 {
+	if km.resp == nil {
+		return nil
+	}
+	ss := make([]float64, len(km.means))
+	for _, v := range km.values {
+		ss[v.cluster] += v.w * sqDist(km.means[v.cluster].point, v.point)
+	}
+	return ss
+}
+
+// Centers returns the k centers determined by a previous call to Cluster.
+func (km *Soft) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(km.means))
+	for i := range km.means {
+		cs[i] = &km.means[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Soft.
+func (km *Soft) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(km.values))
+	for i := range km.values {
+		vs[i] = &km.values[i]
+	}
+	return vs
+}
@@ -0,0 +1,280 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"context"
+	"math"
+	"math/rand"
+)
+
+// sparseValue is a value represented by the indices and values of its non-zero
+// coordinates.
+type sparseValue struct {
+	idx     []int
+	val     []float64
+	sq      float64 // sq is the squared norm of the value.
+	dims    int
+	w       float64
+	cluster int
+}
+
+// V returns a new slice holding the dense coordinates of v.
+func (v *sparseValue) V() []float64 {
+	p := make([]float64, v.dims)
+	for k, j := range v.idx {
+		p[j] = v.val[k]
+	}
+	return p
+}
+
+func (v *sparseValue) Weight() float64 { return v.w }
+func (v *sparseValue) Cluster() int    { return v.cluster }
+
+// dot returns the inner product of v and the dense vector p.
+func (v *sparseValue) dot(p []float64) float64 {
+	var d float64
+	for k, j := range v.idx {
+		d += v.val[k] * p[j]
+	}
+	return d
+}
+
+// Sparse implements Lloyd k-means clustering of sparse ℝⁿ data. Values are held
+// by their non-zero coordinates and centers are dense. Centers left with no
+// members retain their previous location. The squared distance
+// between a value x and a center c is calculated as |x|²+|c|²-2x·c, so the cost
+// of an assignment is proportional to the number of non-zero coordinates of the
+// value rather than to the dimensionality of the data.
+type Sparse struct {
+	dims   int
+	values []sparseValue
+	means  []center
+	sq     []float64 // sq holds the squared norms of the means.
+	source
+}
+
+// NewSparse creates a new sparse k-means object populated with data from a
+// cluster.Sparse value, data. The non-zero coordinates of data are copied.
+func NewSparse(data cluster.Sparse) (*Sparse, error) {
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "kmeans", Err: cluster.ErrEmptyData}
+	}
+	if err := cluster.CheckSparse(data); err != nil {
+		return nil, err
+	}
+	var nnz int
+	for i := 0; i < data.Len(); i++ {
+		idx, _ := data.Row(i)
+		nnz += len(idx)
+	}
+	var (
+		dims = data.Dims()
+		va   = make([]sparseValue, data.Len())
+		idx  = make([]int, 0, nnz)
+		val  = make([]float64, 0, nnz)
+	)
+	w, isWeighter := data.(cluster.Weighter)
+	for i := range va {
+		ri, rv := data.Row(i)
+		from := len(idx)
+		idx = append(idx, ri...)
+		val = append(val, rv...)
+		v := sparseValue{idx: idx[from:len(idx):len(idx)], val: val[from:len(val):len(val)], dims: dims, w: 1}
+		for _, x := range v.val {
+			v.sq += x * x
+		}
+		if isWeighter {
+			v.w = w.Weight(i)
+		}
+		va[i] = v
+	}
+	return &Sparse{dims: dims, values: va}, nil
+}
+
+// SetRand sets the source of the random choices made by Seed to rnd. If rnd is nil,
+// the global math/rand source is used.
+func (km *Sparse) SetRand(rnd *rand.Rand) { km.rnd = rnd }
+
+// Seed generates the initial means for the k-means algorithm according to the
+// k-means++ algorithm.
+func (km *Sparse) Seed(k int) {
+	km.means = make([]center, k)
+	km.sq = make([]float64, k)
+	km.setMean(0, &km.values[km.intn(len(km.values))])
+	d := make([]float64, len(km.values))
+	for i := 1; i < k; i++ {
+		km.means = km.means[:i]
+		sum := 0.
+		for j := range km.values {
+			_, min := km.nearest(&km.values[j])
+			d[j] = min * km.values[j].w
+			sum += d[j]
+		}
+		j := km.intn(len(km.values))
+		if sum > 0 {
+			target := km.float64() * sum
+			j = 0
+			for sum = d[0]; sum < target && j < len(d)-1; sum += d[j] {
+				j++
+			}
+		}
+		km.means = km.means[:k]
+		km.setMean(i, &km.values[j])
+	}
+}
+
+// setMean sets the location of mean i to the location of v.
+func (km *Sparse) setMean(i int, v *sparseValue) {
+	km.means[i] = center{point: v.V()}
+	km.sq[i] = v.sq
+}
+
+// SetCenters sets the locations of the centers to c.
+func (km *Sparse) SetCenters(c []cluster.Center) {
+	km.means = make([]center, len(c))
+	km.sq = make([]float64, len(c))
+	for i, cv := range c {
+		km.means[i] = center{point: append(point(nil), cv.V()...)}
+		km.sq[i] = dot(km.means[i].point, km.means[i].point)
+	}
+}
+
+// nearest returns the index of the nearest center to v and the squared distance
+// from v to that center.
+func (km *Sparse) nearest(v *sparseValue) (c int, min float64) {
+	min = math.Inf(1)
+	for i, m := range km.means {
+		d := math.Max(0, v.sq+km.sq[i]-2*v.dot(m.point))
+		if d < min {
+			c, min = i, d
+		}
+	}
+	return c, min
+}
+
+// Cluster runs a clustering of the data using the k-means algorithm.
+func (km *Sparse) Cluster() error {
+	return km.ClusterContext(context.Background())
+}
+
+// ClusterContext runs a clustering of the data using the k-means algorithm,
+// checking ctx before each iteration. If ctx is done, ClusterContext returns
+// ctx.Err() and the clustering state holds the assignments of the last completed
+// iteration.
+func (km *Sparse) ClusterContext(ctx context.Context) error {
+	if len(km.means) == 0 {
+		return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrNoCenters}
+	}
+	for i := range km.values {
+		km.values[i].cluster, _ = km.nearest(&km.values[i])
+	}
+
+	sum := make([][]float64, len(km.means))
+	for i := range sum {
+		sum[i] = make([]float64, km.dims)
+	}
+	w := make([]float64, len(km.means))
+	var err error
+	for {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		for i := range sum {
+			for j := range sum[i] {
+				sum[i][j] = 0
+			}
+			w[i] = 0
+		}
+		for _, v := range km.values {
+			for k, j := range v.idx {
+				sum[v.cluster][j] += v.w * v.val[k]
+			}
+			w[v.cluster] += v.w
+		}
+		for i := range km.means {
+			if w[i] == 0 {
+				continue
+			}
+			m := km.means[i].point
+			inv := 1 / w[i]
+			for j := range m {
+				m[j] = sum[i][j] * inv
+			}
+			km.sq[i] = dot(m, m)
+		}
+
+		deltas := 0
+		for i := range km.values {
+			if n, _ := km.nearest(&km.values[i]); n != km.values[i].cluster {
+				deltas++
+				km.values[i].cluster = n
+			}
+		}
+		if deltas == 0 {
+			break
+		}
+	}
+
+	for i := range km.means {
+		km.means[i].indices = km.means[i].indices[:0]
+		km.means[i].w = 0
+		km.means[i].count = 0
+	}
+	for i, v := range km.values {
+		c := &km.means[v.cluster]
+		c.indices = append(c.indices, i)
+		c.w += v.w
+		c.count++
+	}
+	return err
+}
+
+// Within calculates the weighted sum of squares within each cluster.
+// Returns nil if Cluster has not been called.
+func (km *Sparse) Within() []float64 {
+	if km.means == nil {
+		return nil
+	}
+	ss := make([]float64, len(km.means))
+	for _, v := range km.values {
+		c := v.cluster
+		ss[c] += v.w * math.Max(0, v.sq+km.sq[c]-2*v.dot(km.means[c].point))
+	}
+	return ss
+}
+
+// Assign returns the index of the center nearest to the dense vector x. If
+// Cluster has not been called, Assign returns -1.
+func (km *Sparse) Assign(x []float64) int {
+	c, min := -1, math.Inf(1)
+	for i, m := range km.means {
+		if d := sqDist(x, m.point); d < min {
+			c, min = i, d
+		}
+	}
+	return c
+}
+
+// Centers returns the k centers determined by a previous call to Cluster.
+func (km *Sparse) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(km.means))
+	for i := range km.means {
+		cs[i] = &km.means[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Sparse. Each call to V on a
+// returned value allocates a new slice holding its dense coordinates.
+func (km *Sparse) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(km.values))
+	for i := range km.values {
+		vs[i] = &km.values[i]
+	}
+	return vs
+}
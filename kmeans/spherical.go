@@ -0,0 +1,234 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmeans
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// Spherical implements spherical k-means clustering of ℝⁿ data. Values are
+// normalized to unit length and assigned to the center with which they have the
+// greatest cosine similarity. Centers are the normalized weighted sums of their
+// members.
+//
+// Reference:
+//
+//	Dhillon IS, Modha DS. Concept decompositions for large sparse text data using
+//	clustering. Machine Learning 42(1):143-175 (2001).
+type Spherical struct {
+	dims    int
+	values  []value
+	means   []center
+	maxIter int
+	source
+}
+
+// NewSpherical creates a new spherical k-means object populated with data from an
+// Interface value, data. NewSpherical returns an error if any element of data has
+// zero length.
+func NewSpherical(data cluster.Interface) (*Spherical, error) {
+	v, d, err := convert(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range v {
+		if !normalize(p.point) {
+			return nil, errors.New("kmeans: zero vector")
+		}
+	}
+	return &Spherical{dims: d, values: v}, nil
+}
+
+// normalize scales p to unit length, returning false if p has zero length.
+func normalize(p point) bool {
+	var ss float64
+	for _, v := range p {
+		ss += v * v
+	}
+	if ss == 0 {
+		return false
+	}
+	inv := 1 / math.Sqrt(ss)
+	for i := range p {
+		p[i] *= inv
+	}
+	return true
+}
+
+func dot(a, b []float64) float64 {
+	var d float64
+	for i, v := range a {
+		d += v * b[i]
+	}
+	return d
+}
+
+// SetRand sets the source of the random choices made by Seed to rnd. If rnd is nil,
+// the global math/rand source is used.
+func (km *Spherical) SetRand(rnd *rand.Rand) { km.rnd = rnd }
+
+// SetMaxIter sets the maximum number of iterations made by Cluster. If n is
+// positive, Cluster returns ErrMaxIterations if values are still changing their
+// assignments after n iterations. The zero value of n disables the limit.
+func (km *Spherical) SetMaxIter(n int) { km.maxIter = n }
+
+// Seed generates the initial centers using k-means++ seeding with the cosine
+// dissimilarity, 1-cos θ, in place of the squared Euclidean distance.
+func (km *Spherical) Seed(k int) {
+	km.means = make([]center, k)
+	km.means[0].point = append(point(nil), km.values[km.intn(len(km.values))].point...)
+	d := make([]float64, len(km.values))
+	for i := 1; i < k; i++ {
+		sum := 0.
+		for j, v := range km.values {
+			_, max := km.nearest(v.point, i)
+			d[j] = math.Max(0, 1-max)
+			sum += d[j]
+		}
+		j := km.intn(len(km.values))
+		if sum > 0 {
+			target := km.float64() * sum
+			j = 0
+			for sum = d[0]; sum < target && j < len(d)-1; sum += d[j] {
+				j++
+			}
+		}
+		km.means[i].point = append(point(nil), km.values[j].point...)
+	}
+}
+
+// SetCenters sets the locations of the centers to the normalized locations of c.
+// SetCenters panics if any center has zero length.
+func (km *Spherical) SetCenters(c []cluster.Center) {
+	km.means = make([]center, len(c))
+	for i, cv := range c {
+		km.means[i] = center{point: append(point(nil), cv.V()...)}
+		if !normalize(km.means[i].point) {
+			panic("kmeans: zero vector")
+		}
+	}
+}
+
+// nearest returns the index of the center among the first n with the greatest
+// cosine similarity to v and that similarity.
+func (km *Spherical) nearest(v point, n int) (c int, max float64) {
+	max = math.Inf(-1)
+	for i, m := range km.means[:n] {
+		if s := dot(v, m.point); s > max {
+			c, max = i, s
+		}
+	}
+	return c, max
+}
+
+// Cluster runs a clustering of the data using the spherical k-means algorithm.
+// Centers left with no members retain their previous location.
+func (km *Spherical) Cluster() error {
+	return km.ClusterContext(context.Background())
+}
+
+// ClusterContext runs a clustering of the data using the spherical k-means
+// algorithm, checking ctx before each iteration. If ctx is done, ClusterContext
+// returns ctx.Err() and the clustering state holds the assignments of the last
+// completed iteration.
+func (km *Spherical) ClusterContext(ctx context.Context) error {
+	if len(km.means) == 0 {
+		return &cluster.Error{Pkg: "kmeans", Err: cluster.ErrNoCenters}
+	}
+	for i, v := range km.values {
+		km.values[i].cluster, _ = km.nearest(v.point, len(km.means))
+	}
+
+	sum := make(point, km.dims)
+	var err error
+	for it := 0; ; it++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		if km.maxIter > 0 && it >= km.maxIter {
+			err = ErrMaxIterations
+			break
+		}
+		for i := range km.means {
+			for j := range sum {
+				sum[j] = 0
+			}
+			var n int
+			for _, v := range km.values {
+				if v.cluster != i {
+					continue
+				}
+				for j, x := range v.point {
+					sum[j] += x * v.w
+				}
+				n++
+			}
+			if n != 0 && normalize(sum) {
+				copy(km.means[i].point, sum)
+			}
+		}
+
+		deltas := 0
+		for i, v := range km.values {
+			if n, _ := km.nearest(v.point, len(km.means)); n != v.cluster {
+				deltas++
+				km.values[i].cluster = n
+			}
+		}
+		if deltas == 0 {
+			break
+		}
+	}
+
+	for i := range km.means {
+		km.means[i].indices = km.means[i].indices[:0]
+		km.means[i].w = 0
+		km.means[i].count = 0
+	}
+	for i, v := range km.values {
+		c := &km.means[v.cluster]
+		c.indices = append(c.indices, i)
+		c.w += v.w
+		c.count++
+	}
+	return err
+}
+
+// Cohesion calculates the weighted sum of the cosine similarities of the members of
+// each cluster to their center. Returns nil if Cluster has not been called.
+func (km *Spherical) Cohesion() []float64 {
+	if km.means == nil {
+		return nil
+	}
+	s := make([]float64, len(km.means))
+	for _, v := range km.values {
+		s[v.cluster] += v.w * dot(v.point, km.means[v.cluster].point)
+	}
+	return s
+}
+
+// Centers returns the k unit length centers determined by a previous call to
+// Cluster.
+func (km *Spherical) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(km.means))
+	for i := range km.means {
+		cs[i] = &km.means[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the normalized values in the Spherical.
+func (km *Spherical) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(km.values))
+	for i := range km.values {
+		vs[i] = &km.values[i]
+	}
+	return vs
+}
@@ -0,0 +1,566 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package kmedoids implements k-medoids clustering using the partitioning around
+// medoids (PAM) algorithm and its sampling-based extension, CLARA, for large data
+// sets.
+//
+// Reference:
+//
+//	Kaufman L, Rousseeuw PJ. Finding Groups in Data: An Introduction to Cluster
+//	Analysis. Wiley (1990).
+package kmedoids
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/distcache"
+
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	w       float64
+	cluster int
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+type center struct {
+	point
+	medoid  int
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// values is a collection of value that satisfies cluster.Interface.
+type values []value
+
+func (v values) Len() int               { return len(v) }
+func (v values) Values(i int) []float64 { return v[i].point }
+
+// subset is a cluster.Interface over a subset of values.
+type subset struct {
+	values
+	idx []int
+}
+
+func (s subset) Len() int               { return len(s.idx) }
+func (s subset) Values(i int) []float64 { return s.values[s.idx[i]].point }
+
+// medoids holds the common state of the k-medoids Clusterers.
+type medoids struct {
+	k       int
+	metric  cluster.Metric
+	dm      cluster.DistanceMatrixer
+	sparse  cluster.Sparse
+	smetric cluster.SparseMetric
+	values  values
+	centers []center
+	cost    float64
+}
+
+func newMedoids(data cluster.Interface, k int, m cluster.Metric) (medoids, error) {
+	if data.Len() == 0 {
+		return medoids{}, &cluster.Error{Pkg: "kmedoids", Err: cluster.ErrEmptyData}
+	}
+	if k < 1 || k > data.Len() {
+		return medoids{}, errors.New("kmedoids: invalid k")
+	}
+	if m == nil {
+		m = cluster.Euclidean
+	}
+	va := make(values, data.Len())
+	dim := len(data.Values(0))
+	w, isWeighter := data.(cluster.Weighter)
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dim {
+			return medoids{}, &cluster.Error{Pkg: "kmedoids", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...), w: 1}
+		if isWeighter {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return medoids{k: k, metric: m, values: va}, nil
+}
+
+// newMatrixMedoids returns medoids state for the elements of dm, which have no
+// coordinates.
+func newMatrixMedoids(dm cluster.DistanceMatrixer, k int) (medoids, error) {
+	if dm.Len() == 0 {
+		return medoids{}, &cluster.Error{Pkg: "kmedoids", Err: cluster.ErrEmptyData}
+	}
+	if k < 1 || k > dm.Len() {
+		return medoids{}, errors.New("kmedoids: invalid k")
+	}
+	va := make(values, dm.Len())
+	w, isWeighter := dm.(cluster.Weighter)
+	for i := range va {
+		va[i].w = 1
+		if isWeighter {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return medoids{k: k, dm: dm, values: va}, nil
+}
+
+// newSparseMedoids returns medoids state for the elements of data, with distances
+// calculated from their non-zero coordinates under m.
+func newSparseMedoids(data cluster.Sparse, k int, m cluster.SparseMetric) (medoids, error) {
+	if err := cluster.CheckSparse(data); err != nil {
+		return medoids{}, err
+	}
+	if m == nil {
+		m = cluster.SparseEuclidean
+	}
+	md, err := newMatrixMedoids(cluster.SparseDistances(data, m), k)
+	if err != nil {
+		return medoids{}, err
+	}
+	md.sparse = data
+	md.smetric = m
+	return md, nil
+}
+
+// dist returns the distance between values i and j.
+func (km *medoids) dist(i, j int) float64 {
+	if km.dm != nil {
+		return km.dm.Distance(i, j)
+	}
+	return km.metric.Distance(km.values[i].point, km.values[j].point)
+}
+
+// pam returns the indices of k medoids of the n elements with pairwise distances
+// given by dist and element weights given by w, using the BUILD and SWAP phases
+// of PAM. ctx is checked before each BUILD and SWAP step. If ctx is done during
+// BUILD, pam returns nil and ctx.Err(). If ctx is done during SWAP, pam returns
+// the medoids of the last completed swap and ctx.Err().
+func pam(ctx context.Context, n, k int, dist func(i, j int) float64, w func(i int) float64) ([]int, error) {
+	var (
+		med    = make([]int, 0, k)
+		isMed  = make([]bool, n)
+		near   = make([]int, n) // Index into med of the nearest medoid.
+		d1, d2 = make([]float64, n), make([]float64, n)
+	)
+	for i := range d1 {
+		d1[i] = math.Inf(1)
+		d2[i] = math.Inf(1)
+	}
+	// update recalculates the nearest and second nearest medoid distances.
+	update := func() {
+		for j := 0; j < n; j++ {
+			d1[j], d2[j] = math.Inf(1), math.Inf(1)
+			for i, m := range med {
+				d := dist(m, j)
+				switch {
+				case d < d1[j]:
+					d2[j] = d1[j]
+					d1[j], near[j] = d, i
+				case d < d2[j]:
+					d2[j] = d
+				}
+			}
+		}
+	}
+
+	// BUILD
+	for len(med) < k {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		best, gain := -1, math.Inf(-1)
+		for o := 0; o < n; o++ {
+			if isMed[o] {
+				continue
+			}
+			var g float64
+			for j := 0; j < n; j++ {
+				d := dist(o, j)
+				switch {
+				case math.IsInf(d1[j], 1):
+					// With no finite distance to a medoid, prefer the
+					// candidate with the least total weighted distance.
+					g -= w(j) * d
+				case d < d1[j]:
+					g += w(j) * (d1[j] - d)
+				}
+			}
+			if best < 0 || g > gain {
+				best, gain = o, g
+			}
+		}
+		med = append(med, best)
+		isMed[best] = true
+		update()
+	}
+
+	// SWAP
+	for {
+		if err := ctx.Err(); err != nil {
+			return med, err
+		}
+		bi, bo := -1, -1
+		min := 0.
+		for i := range med {
+			for o := 0; o < n; o++ {
+				if isMed[o] {
+					continue
+				}
+				var delta float64
+				for j := 0; j < n; j++ {
+					d := dist(o, j)
+					if near[j] == i {
+						delta += w(j) * (math.Min(d, d2[j]) - d1[j])
+					} else if d < d1[j] {
+						delta += w(j) * (d - d1[j])
+					}
+				}
+				if delta < min {
+					bi, bo, min = i, o, delta
+				}
+			}
+		}
+		if bi < 0 {
+			break
+		}
+		isMed[med[bi]] = false
+		med[bi] = bo
+		isMed[bo] = true
+		update()
+	}
+
+	return med, nil
+}
+
+// assign assigns all values to the nearest of the medoids med, returning the total
+// weighted distance of values to their medoid.
+func (km *medoids) assign(med []int) float64 {
+	var cost float64
+	for j := range km.values {
+		v := &km.values[j]
+		min := math.Inf(1)
+		for i, m := range med {
+			if d := km.dist(m, j); d < min {
+				min = d
+				v.cluster = i
+			}
+		}
+		cost += v.w * min
+	}
+	return cost
+}
+
+// set records med as the medoids of the clustering.
+func (km *medoids) set(med []int) {
+	km.cost = km.assign(med)
+	km.centers = make([]center, len(med))
+	for i, m := range med {
+		km.centers[i] = center{point: km.values[m].point, medoid: m}
+		if km.sparse != nil {
+			km.centers[i].point = dense(km.sparse, m)
+		}
+	}
+	for j, v := range km.values {
+		km.centers[v.cluster].indices = append(km.centers[v.cluster].indices, j)
+	}
+}
+
+// Medoids returns the indices of the medoids determined by a previous call to
+// Cluster. The ith element corresponds to the ith element of Centers.
+func (km *medoids) Medoids() []int {
+	m := make([]int, len(km.centers))
+	for i, c := range km.centers {
+		m[i] = c.medoid
+	}
+	return m
+}
+
+// Assign returns the index of the center whose medoid is nearest to x under the
+// metric of the Clusterer. Assign returns -1 if Cluster has not been called or if
+// the Clusterer was created from a cluster.DistanceMatrixer, since its values have
+// no coordinates. For a Clusterer created from a cluster.Sparse, x is the dense
+// representation of the query.
+func (km *medoids) Assign(x []float64) int {
+	if km.sparse != nil {
+		var (
+			idx []int
+			val []float64
+		)
+		for j, v := range x {
+			if v != 0 {
+				idx = append(idx, j)
+				val = append(val, v)
+			}
+		}
+		c, min := -1, math.Inf(1)
+		for i, cen := range km.centers {
+			mi, mv := km.sparse.Row(cen.medoid)
+			if d := km.smetric.SparseDistance(idx, val, mi, mv); d < min {
+				c, min = i, d
+			}
+		}
+		return c
+	}
+	if km.dm != nil {
+		return -1
+	}
+	c, min := -1, math.Inf(1)
+	for i, cen := range km.centers {
+		if d := km.metric.Distance(x, cen.point); d < min {
+			c, min = i, d
+		}
+	}
+	return c
+}
+
+// Cost returns the total weighted distance of values to their medoid.
+func (km *medoids) Cost() float64 { return km.cost }
+
+// Centers returns the k centers determined by a previous call to Cluster. The
+// location of each center is its medoid.
+func (km *medoids) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(km.centers))
+	for i := range km.centers {
+		cs[i] = &km.centers[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Clusterer.
+func (km *medoids) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(km.values))
+	for i := range km.values {
+		vs[i] = &km.values[i]
+	}
+	return vs
+}
+
+// PAM implements k-medoids clustering using the partitioning around medoids
+// algorithm. PAM requires O(n²) memory and O(kn²) time per iteration.
+type PAM struct {
+	medoids
+}
+
+// NewPAM creates a new PAM Clusterer that will partition data into k clusters using
+// the metric m. If m is nil, Euclidean distance is used.
+func NewPAM(data cluster.Interface, k int, m cluster.Metric) (*PAM, error) {
+	md, err := newMedoids(data, k, m)
+	if err != nil {
+		return nil, err
+	}
+	return &PAM{md}, nil
+}
+
+// NewPAMMatrix creates a new PAM Clusterer that will partition the elements of dm
+// into k clusters using the distances it provides, so that data without
+// coordinates, such as sequences with pairwise alignment distances, can be
+// clustered. If dm satisfies cluster.Weighter, the weights are used. The values
+// and centers of the returned PAM have no coordinates; the location of each
+// center is given by its medoid.
+func NewPAMMatrix(dm cluster.DistanceMatrixer, k int) (*PAM, error) {
+	md, err := newMatrixMedoids(dm, k)
+	if err != nil {
+		return nil, err
+	}
+	return &PAM{md}, nil
+}
+
+// NewPAMSparse creates a new PAM Clusterer that will partition the elements of
+// data into k clusters using the sparse metric m. If m is nil, Euclidean distance
+// is used. Distances are calculated from the non-zero coordinates of the elements
+// and are computed once for each pair when Cluster is called. If data satisfies
+// cluster.Weighter, the weights are used. The values of the returned PAM have no
+// coordinates; the location of each center is the dense representation of its
+// medoid.
+func NewPAMSparse(data cluster.Sparse, k int, m cluster.SparseMetric) (*PAM, error) {
+	md, err := newSparseMedoids(data, k, m)
+	if err != nil {
+		return nil, err
+	}
+	return &PAM{md}, nil
+}
+
+// Cluster runs a clustering of the data using the PAM algorithm.
+func (km *PAM) Cluster() error {
+	return km.ClusterContext(context.Background())
+}
+
+// ClusterContext runs a clustering of the data using the PAM algorithm, checking
+// ctx before each BUILD and SWAP step. If ctx is done during the SWAP phase,
+// ClusterContext returns ctx.Err() and the clustering holds the medoids of the
+// last completed swap. If ctx is done during the BUILD phase, the clustering is
+// not altered.
+func (km *PAM) ClusterContext(ctx context.Context) error {
+	dist := km.dist
+	switch {
+	case km.sparse != nil:
+		dist = precompute(km.dm).Distance
+	case km.dm == nil:
+		dist = distcache.NewMatrix(km.values, km.metric).Distance
+	}
+	med, err := pam(ctx, len(km.values), km.k, dist, func(i int) float64 { return km.values[i].w })
+	if med != nil {
+		km.set(med)
+	}
+	return err
+}
+
+// CLARA implements k-medoids clustering of large data sets by running PAM on
+// random samples of the data and retaining the medoids that give the lowest cost
+// over the complete data set.
+type CLARA struct {
+	medoids
+	samples int
+	size    int
+	rnd     *rand.Rand // rnd is the source of random choices; nil uses math/rand.
+}
+
+// NewCLARA creates a new CLARA Clusterer that will partition data into k clusters
+// using the metric m. If m is nil, Euclidean distance is used. PAM is run on the
+// given number of samples, each of size elements. If samples is zero, 5 samples
+// are used and if size is zero, samples of 40+2k elements are used.
+func NewCLARA(data cluster.Interface, k int, m cluster.Metric, samples, size int) (*CLARA, error) {
+	md, err := newMedoids(data, k, m)
+	if err != nil {
+		return nil, err
+	}
+	return newCLARA(md, samples, size)
+}
+
+// NewCLARAMatrix creates a new CLARA Clusterer that will partition the elements of
+// dm into k clusters using the distances it provides. Only distances between the
+// elements of each sample and from each element to the sampled medoids are
+// requested, so dm may calculate distances on demand. Sampling is as described
+// for NewCLARA and the returned CLARA has no coordinates, as described for
+// NewPAMMatrix.
+func NewCLARAMatrix(dm cluster.DistanceMatrixer, k, samples, size int) (*CLARA, error) {
+	md, err := newMatrixMedoids(dm, k)
+	if err != nil {
+		return nil, err
+	}
+	return newCLARA(md, samples, size)
+}
+
+// NewCLARASparse creates a new CLARA Clusterer that will partition the elements of
+// data into k clusters using the sparse metric m. If m is nil, Euclidean distance
+// is used. Distances are calculated on demand from the non-zero coordinates of the
+// elements. Sampling is as described for NewCLARA and the values and centers of
+// the returned CLARA are as described for NewPAMSparse.
+func NewCLARASparse(data cluster.Sparse, k int, m cluster.SparseMetric, samples, size int) (*CLARA, error) {
+	md, err := newSparseMedoids(data, k, m)
+	if err != nil {
+		return nil, err
+	}
+	return newCLARA(md, samples, size)
+}
+
+func newCLARA(md medoids, samples, size int) (*CLARA, error) {
+	if samples == 0 {
+		samples = 5
+	}
+	if size == 0 {
+		size = 40 + 2*md.k
+	}
+	if samples < 1 || size < md.k {
+		return nil, errors.New("kmedoids: invalid sampling")
+	}
+	if size > len(md.values) {
+		size = len(md.values)
+	}
+	return &CLARA{medoids: md, samples: samples, size: size}, nil
+}
+
+// SetRand sets the source of the samples drawn by Cluster to rnd. If rnd is nil, the
+// global math/rand source is used.
+func (km *CLARA) SetRand(rnd *rand.Rand) { km.rnd = rnd }
+
+// Cluster runs a clustering of the data using the CLARA algorithm. The best
+// medoids found so far are included in each subsequent sample.
+func (km *CLARA) Cluster() error {
+	return km.ClusterContext(context.Background())
+}
+
+// ClusterContext runs a clustering of the data using the CLARA algorithm,
+// checking ctx during the clustering of each sample. If ctx is done,
+// ClusterContext returns ctx.Err() and the clustering holds the best medoids
+// found in the completed samples, or is not altered if no sample was completed.
+func (km *CLARA) ClusterContext(ctx context.Context) error {
+	var (
+		best []int
+		min  = math.Inf(1)
+	)
+	perm := rand.Perm
+	if km.rnd != nil {
+		perm = km.rnd.Perm
+	}
+	for s := 0; s < km.samples; s++ {
+		idx := make([]int, 0, km.size)
+		in := make(map[int]bool, km.size)
+		for _, m := range best {
+			idx = append(idx, m)
+			in[m] = true
+		}
+		for _, j := range perm(len(km.values)) {
+			if len(idx) == km.size {
+				break
+			}
+			if !in[j] {
+				idx = append(idx, j)
+			}
+		}
+		var dist func(i, j int) float64
+		if km.dm != nil {
+			dist = func(i, j int) float64 { return km.dm.Distance(idx[i], idx[j]) }
+		} else {
+			dist = distcache.NewMatrix(subset{values: km.values, idx: idx}, km.metric).Distance
+		}
+		med, err := pam(ctx, len(idx), km.k, dist, func(i int) float64 { return km.values[idx[i]].w })
+		if err != nil {
+			if best != nil {
+				km.set(best)
+			}
+			return err
+		}
+		for i, m := range med {
+			med[i] = idx[m]
+		}
+		if cost := km.assign(med); cost < min {
+			best, min = med, cost
+		}
+	}
+	km.set(best)
+	return nil
+}
+
+// precompute returns a DistanceMatrix holding all the distances provided by dm.
+func precompute(dm cluster.DistanceMatrixer) *cluster.DistanceMatrix {
+	m := cluster.NewDistanceMatrix(dm.Len())
+	for i := 0; i < dm.Len(); i++ {
+		for j := 0; j < i; j++ {
+			m.SetDistance(i, j, dm.Distance(i, j))
+		}
+	}
+	return m
+}
+
+// dense returns the dense representation of element i of data.
+func dense(data cluster.Sparse, i int) point {
+	p := make(point, data.Dims())
+	idx, val := data.Row(i)
+	for k, j := range idx {
+		p[j] = val[k]
+	}
+	return p
+}
@@ -0,0 +1,215 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmedoids_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/kmedoids"
+
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+var manhattan = cluster.MetricFunc(func(x, y []float64) float64 {
+	var d float64
+	for i, v := range x {
+		d += math.Abs(v - y[i])
+	}
+	return d
+})
+
+func (s *S) TestPAM(c *check.C) {
+	data := Points{{0}, {1}, {2}, {100}, {10}, {11}, {12}}
+	km, err := kmedoids.NewPAM(data, 3, manhattan)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	med := km.Medoids()
+	sort.Ints(med)
+	c.Check(med, check.DeepEquals, []int{1, 3, 5})
+	c.Check(km.Cost(), check.Equals, 4.)
+	var p cluster.Predictor = km
+	c.Check(p.Assign([]float64{8}), check.Equals, km.Values()[5].Cluster())
+	c.Check(p.Assign([]float64{60}), check.Equals, km.Values()[3].Cluster())
+	for _, cen := range km.Centers() {
+		for _, j := range cen.Members() {
+			c.Check(km.Values()[j].Cluster(), check.Equals, km.Values()[cen.Members()[0]].Cluster())
+		}
+	}
+}
+
+// weightedMatrix is a cluster.DistanceMatrixer that also satisfies cluster.Weighter.
+type weightedMatrix struct {
+	*cluster.DistanceMatrix
+	w []float64
+}
+
+func (m weightedMatrix) Weight(i int) float64 { return m.w[i] }
+
+func (s *S) TestPAMMatrix(c *check.C) {
+	data := Points{{0}, {1}, {2}, {100}, {10}, {11}, {12}}
+	dm := cluster.NewDistanceMatrix(len(data))
+	for i := range data {
+		for j := 0; j < i; j++ {
+			dm.SetDistance(i, j, manhattan.Distance(data[i], data[j]))
+		}
+	}
+	km, err := kmedoids.NewPAMMatrix(dm, 3)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	med := km.Medoids()
+	sort.Ints(med)
+	c.Check(med, check.DeepEquals, []int{1, 3, 5})
+	c.Check(km.Cost(), check.Equals, 4.)
+	for _, cen := range km.Centers() {
+		c.Check(len(cen.V()), check.Equals, 0)
+	}
+	c.Check(km.Assign([]float64{8}), check.Equals, -1)
+
+	// A heavy element pulls its cluster's medoid to itself.
+	w := []float64{1, 1, 10, 1, 1, 1, 1}
+	km, err = kmedoids.NewPAMMatrix(weightedMatrix{dm, w}, 3)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	med = km.Medoids()
+	sort.Ints(med)
+	c.Check(med, check.DeepEquals, []int{2, 3, 5})
+	c.Check(km.Cost(), check.Equals, 5.)
+
+	cl, err := kmedoids.NewCLARAMatrix(dm, 3, 2, 0)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(cl.Cluster(), check.Equals, nil)
+	c.Check(cl.Cost(), check.Equals, 4.)
+
+	_, err = kmedoids.NewPAMMatrix(cluster.NewDistanceMatrix(0), 1)
+	c.Check(err, check.ErrorMatches, "kmedoids: no data")
+	_, err = kmedoids.NewPAMMatrix(dm, 8)
+	c.Check(err, check.ErrorMatches, "kmedoids: invalid k")
+}
+
+func (s *S) TestCLARA(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var data Points
+	for _, x := range []float64{0, 50, 100} {
+		for i := 0; i < 500; i++ {
+			data = append(data, []float64{x + rnd.NormFloat64(), x + rnd.NormFloat64()})
+		}
+	}
+	km, err := kmedoids.NewCLARA(data, 3, nil, 0, 0)
+	c.Assert(err, check.Equals, nil)
+	km.SetRand(rnd)
+	c.Assert(km.Cluster(), check.Equals, nil)
+
+	var got []cluster.Indices
+	for _, cen := range km.Centers() {
+		got = append(got, cen.Members())
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i][0] < got[j][0] })
+	for g, m := range got {
+		c.Check(len(m), check.Equals, 500)
+		c.Check(m[0], check.Equals, g*500)
+	}
+	for _, m := range km.Medoids() {
+		v := data[m]
+		x := math.Round(v[0]/50) * 50
+		c.Check(math.Abs(v[0]-x) < 1.5 && math.Abs(v[1]-x) < 1.5, check.Equals, true)
+	}
+
+	var med [2][]int
+	for i := range med {
+		km, err := kmedoids.NewCLARA(data, 3, nil, 0, 0)
+		c.Assert(err, check.Equals, nil)
+		km.SetRand(rand.New(rand.NewSource(2)))
+		c.Assert(km.Cluster(), check.Equals, nil)
+		med[i] = km.Medoids()
+	}
+	c.Check(med[1], check.DeepEquals, med[0])
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := kmedoids.NewPAM(Points{}, 1, nil)
+	c.Check(err, check.ErrorMatches, "kmedoids: no data")
+	_, err = kmedoids.NewPAM(Points{{1}}, 2, nil)
+	c.Check(err, check.ErrorMatches, "kmedoids: invalid k")
+	_, err = kmedoids.NewCLARA(Points{{1}, {2}}, 2, nil, -1, 0)
+	c.Check(err, check.ErrorMatches, "kmedoids: invalid sampling")
+}
+
+// countdown is a context that is cancelled after n calls to Err.
+type countdown struct {
+	context.Context
+	n int
+}
+
+func (c *countdown) Err() error {
+	if c.n == 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func (s *S) TestClusterContext(c *check.C) {
+	data := Points{{0}, {1}, {2}, {100}, {10}, {11}, {12}}
+	km, err := kmedoids.NewPAM(data, 3, manhattan)
+	c.Assert(err, check.Equals, nil)
+	var cc cluster.ContextClusterer = km
+	c.Check(cc.ClusterContext(&countdown{Context: context.Background(), n: 2}), check.Equals, context.Canceled)
+	c.Check(km.Centers(), check.HasLen, 0)
+
+	// Cancellation at the first SWAP step retains the BUILD medoids.
+	c.Check(km.ClusterContext(&countdown{Context: context.Background(), n: 3}), check.Equals, context.Canceled)
+	c.Check(km.Centers(), check.HasLen, 3)
+
+	cl, err := kmedoids.NewCLARA(data, 2, manhattan, 3, 5)
+	c.Assert(err, check.Equals, nil)
+	cl.SetRand(rand.New(rand.NewSource(1)))
+	c.Check(cl.ClusterContext(&countdown{Context: context.Background(), n: 0}), check.Equals, context.Canceled)
+	c.Check(cl.Centers(), check.HasLen, 0)
+}
+
+func (s *S) TestSparse(c *check.C) {
+	data := Points{{0, 0, 1}, {0, 0, 2}, {0, 0, 3}, {0, 100, 0}, {10, 0, 0}, {11, 0, 0}, {12, 0, 0}}
+	want, err := kmedoids.NewPAM(data, 3, manhattan)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(want.Cluster(), check.Equals, nil)
+
+	sp, err := cluster.NewCSR(data)
+	c.Assert(err, check.Equals, nil)
+	got, err := kmedoids.NewPAMSparse(sp, 3, cluster.SparseManhattan)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(got.Cluster(), check.Equals, nil)
+	c.Check(got.Medoids(), check.DeepEquals, want.Medoids())
+	c.Check(got.Cost(), check.Equals, want.Cost())
+	for i, cen := range got.Centers() {
+		c.Check(cen.V(), check.DeepEquals, want.Centers()[i].V())
+		c.Check(cen.Members(), check.DeepEquals, want.Centers()[i].Members())
+	}
+	c.Check(got.Assign([]float64{9, 0, 0}), check.Equals, want.Assign([]float64{9, 0, 0}))
+	c.Check(got.Assign([]float64{0, 60, 0}), check.Equals, got.Values()[3].Cluster())
+
+	cl, err := kmedoids.NewCLARASparse(sp, 3, nil, 3, 7)
+	c.Assert(err, check.Equals, nil)
+	cl.SetRand(rand.New(rand.NewSource(1)))
+	c.Assert(cl.Cluster(), check.Equals, nil)
+	med := cl.Medoids()
+	sort.Ints(med)
+	c.Check(med, check.DeepEquals, []int{1, 3, 5})
+}
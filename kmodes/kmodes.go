@@ -0,0 +1,255 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package kmodes implements k-modes clustering of categorical data.
+//
+// Reference:
+//
+//	Huang Z. Extensions to the k-means algorithm for clustering large data sets with
+//	categorical values. Data Mining and Knowledge Discovery 2(3):283-304 (1998).
+package kmodes
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"math"
+	"math/rand"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	w       float64
+	cluster int
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+type center struct {
+	point
+	w       float64
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// Matching returns the simple matching dissimilarity between x and y, the number of
+// attributes at which x and y differ. Attributes that are NaN in either x or y are
+// missing and are not counted.
+func Matching(x, y []float64) float64 {
+	var d float64
+	for i, v := range x {
+		u := y[i]
+		if math.IsNaN(v) || math.IsNaN(u) {
+			continue
+		}
+		if v != u {
+			d++
+		}
+	}
+	return d
+}
+
+// Kmodes implements clustering of categorical data using the k-modes algorithm.
+// Each dimension of the data is a categorical attribute whose values are compared
+// only for equality; NaN values denote missing attributes. Values are assigned to
+// the center with the smallest matching dissimilarity and each center is the
+// attribute-wise weighted mode of its members.
+type Kmodes struct {
+	dims   int
+	values []value
+	modes  []center
+	rnd    *rand.Rand // rnd is the source of random choices; nil uses math/rand.
+}
+
+// New creates a new k-modes Clusterer object populated with data from an Interface
+// value, data.
+func New(data cluster.Interface) (*Kmodes, error) {
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "kmodes", Err: cluster.ErrEmptyData}
+	}
+	dims := len(data.Values(0))
+	va := make([]value, data.Len())
+	w, _ := data.(cluster.Weighter)
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dims {
+			return nil, &cluster.Error{Pkg: "kmodes", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...), w: 1}
+		if w != nil {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return &Kmodes{dims: dims, values: va}, nil
+}
+
+// SetRand sets the source of the random choices made by Seed to rnd. If rnd is nil,
+// the global math/rand source is used.
+func (km *Kmodes) SetRand(rnd *rand.Rand) { km.rnd = rnd }
+
+// intn returns a random integer in [0, n) from the random source.
+func (km *Kmodes) intn(n int) int {
+	if km.rnd == nil {
+		return rand.Intn(n)
+	}
+	return km.rnd.Intn(n)
+}
+
+// float64 returns a random number in [0, 1) from the random source.
+func (km *Kmodes) float64() float64 {
+	if km.rnd == nil {
+		return rand.Float64()
+	}
+	return km.rnd.Float64()
+}
+
+// Seed generates the initial modes for the Kmodes. Modes are chosen from the data
+// in the manner of k-means++, with the probability of choosing a value proportional
+// to the square of its matching dissimilarity from the nearest mode already chosen.
+func (km *Kmodes) Seed(k int) {
+	km.modes = make([]center, k)
+	km.modes[0].point = append(point(nil), km.values[km.intn(len(km.values))].point...)
+	d := make([]float64, len(km.values))
+	for i := 1; i < k; i++ {
+		sum := 0.
+		for j, v := range km.values {
+			_, min := km.nearest(v.point, i)
+			d[j] = min * min * v.w
+			sum += d[j]
+		}
+		j := km.intn(len(km.values))
+		if sum > 0 {
+			target := km.float64() * sum
+			j = 0
+			for sum = d[0]; sum < target && j < len(d)-1; sum += d[j] {
+				j++
+			}
+		}
+		km.modes[i].point = append(point(nil), km.values[j].point...)
+	}
+}
+
+// SetCenters sets the k modes for the Kmodes to the locations of the centers in c.
+func (km *Kmodes) SetCenters(c []cluster.Center) {
+	km.modes = make([]center, len(c))
+	for i, cv := range c {
+		km.modes[i].point = append(point(nil), cv.V()...)
+	}
+}
+
+// nearest returns the index of the nearest of the first n modes to v and its
+// matching dissimilarity.
+func (km *Kmodes) nearest(v point, n int) (c int, min float64) {
+	min = math.Inf(1)
+	for i, m := range km.modes[:n] {
+		if d := Matching(v, m.point); d < min {
+			c, min = i, d
+		}
+	}
+	return c, min
+}
+
+// Cluster runs a clustering of the data using the k-modes algorithm, alternating
+// assignment of values to their nearest mode and recalculation of each mode until
+// no assignment changes. Seed or SetCenters must be called before Cluster.
+func (km *Kmodes) Cluster() error {
+	if len(km.modes) == 0 {
+		return &cluster.Error{Pkg: "kmodes", Err: cluster.ErrNoCenters}
+	}
+	for i := range km.values {
+		km.values[i].cluster = -1
+	}
+	for {
+		changed := false
+		for i, v := range km.values {
+			c, _ := km.nearest(v.point, len(km.modes))
+			if c != v.cluster {
+				km.values[i].cluster = c
+				changed = true
+			}
+		}
+		km.update()
+		if !changed {
+			break
+		}
+	}
+
+	for i := range km.modes {
+		km.modes[i].indices = km.modes[i].indices[:0]
+		km.modes[i].w = 0
+	}
+	for i, v := range km.values {
+		c := &km.modes[v.cluster]
+		c.indices = append(c.indices, i)
+		c.w += v.w
+	}
+
+	return nil
+}
+
+// update sets each mode to the attribute-wise weighted mode of its members. Modes
+// of clusters with no members, and attributes with no non-missing members, are
+// left unchanged. Ties are broken in favor of the smallest attribute value.
+func (km *Kmodes) update() {
+	counts := make([][]map[float64]float64, len(km.modes))
+	for i := range counts {
+		counts[i] = make([]map[float64]float64, km.dims)
+		for j := range counts[i] {
+			counts[i][j] = make(map[float64]float64)
+		}
+	}
+	for _, v := range km.values {
+		for j, x := range v.point {
+			if !math.IsNaN(x) {
+				counts[v.cluster][j][x] += v.w
+			}
+		}
+	}
+	for i, attr := range counts {
+		for j, c := range attr {
+			best, max := math.NaN(), math.Inf(-1)
+			for x, w := range c {
+				if w > max || (w == max && x < best) {
+					best, max = x, w
+				}
+			}
+			if !math.IsNaN(best) {
+				km.modes[i].point[j] = best
+			}
+		}
+	}
+}
+
+// Cost returns the total weighted matching dissimilarity of the values from their
+// modes.
+func (km *Kmodes) Cost() float64 {
+	var cost float64
+	for _, v := range km.values {
+		cost += v.w * Matching(v.point, km.modes[v.cluster].point)
+	}
+	return cost
+}
+
+// Centers returns the k modes determined by a previous call to Cluster.
+func (km *Kmodes) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(km.modes))
+	for i := range km.modes {
+		cs[i] = &km.modes[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Kmodes.
+func (km *Kmodes) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(km.values))
+	for i := range km.values {
+		vs[i] = &km.values[i]
+	}
+	return vs
+}
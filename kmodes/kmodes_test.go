@@ -0,0 +1,97 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kmodes_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/kmodes"
+
+	"math"
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+// Annotations are categorical feature annotations encoded as category codes.
+type Annotations [][]float64
+
+func (a Annotations) Len() int               { return len(a) }
+func (a Annotations) Values(i int) []float64 { return a[i] }
+
+var nan = math.NaN()
+
+var annots = Annotations{
+	{1, 1, 1, 1, 1},
+	{1, 1, 1, 1, 2},
+	{1, 1, 1, 2, 1},
+	{1, 1, 2, 1, 1},
+	{2, 2, 2, 2, 2},
+	{2, 2, 2, 2, 1},
+	{2, 2, 2, 1, 2},
+	{2, 2, nan, 2, 2},
+}
+
+func (s *S) TestMatching(c *check.C) {
+	c.Check(kmodes.Matching([]float64{1, 2, 3}, []float64{1, 2, 3}), check.Equals, 0.)
+	c.Check(kmodes.Matching([]float64{1, 2, 3}, []float64{1, 3, 2}), check.Equals, 2.)
+	c.Check(kmodes.Matching([]float64{1, nan, 3}, []float64{1, 2, 2}), check.Equals, 1.)
+}
+
+func (s *S) TestKmodes(c *check.C) {
+	km, err := kmodes.New(annots)
+	c.Assert(err, check.Equals, nil)
+	km.SetRand(rand.New(rand.NewSource(1)))
+	km.SetCenters([]cluster.Center{cen{1, 1, 1, 2, 2}, cen{2, 2, 1, 2, 2}})
+	c.Assert(km.Cluster(), check.Equals, nil)
+	var got []cluster.Indices
+	var modes [][]float64
+	for _, cen := range km.Centers() {
+		got = append(got, cen.Members())
+		modes = append(modes, cen.V())
+	}
+	c.Check(got, check.DeepEquals, []cluster.Indices{{0, 1, 2, 3}, {4, 5, 6, 7}})
+	c.Check(modes, check.DeepEquals, [][]float64{{1, 1, 1, 1, 1}, {2, 2, 2, 2, 2}})
+	c.Check(km.Cost(), check.Equals, 5.)
+
+	min := math.Inf(1)
+	for i := 0; i < 10; i++ {
+		km.Seed(2)
+		c.Assert(km.Cluster(), check.Equals, nil)
+		min = math.Min(min, km.Cost())
+	}
+	c.Check(min, check.Equals, 5.)
+
+	var seeded [2][][]float64
+	for i := range seeded {
+		km.SetRand(rand.New(rand.NewSource(2)))
+		km.Seed(2)
+		for _, cen := range km.Centers() {
+			seeded[i] = append(seeded[i], cen.V())
+		}
+	}
+	c.Check(seeded[1], check.DeepEquals, seeded[0])
+}
+
+type cen []float64
+
+func (c cen) V() []float64             { return c }
+func (c cen) Members() cluster.Indices { return nil }
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := kmodes.New(Annotations{})
+	c.Check(err, check.ErrorMatches, "kmodes: no data")
+	_, err = kmodes.New(Annotations{{1, 2}, {1}})
+	c.Check(err, check.ErrorMatches, "kmodes: mismatched dimensions")
+	km, err := kmodes.New(annots)
+	c.Assert(err, check.Equals, nil)
+	c.Check(km.Cluster(), check.ErrorMatches, "kmodes: no centers")
+}
@@ -0,0 +1,256 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package knn provides construction of k-nearest neighbor graphs for use by graph
+// and density based clustering algorithms.
+package knn
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/store/kdtree"
+
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Edge is a weighted edge to a neighboring element.
+type Edge struct {
+	To     int     // To is the index of the neighbor.
+	Weight float64 // Weight is the Euclidean distance to the neighbor.
+}
+
+// Graph is a sparse weighted nearest neighbor graph. Element i of a Graph holds the
+// edges from data element i to its neighbors in order of increasing distance.
+type Graph [][]Edge
+
+// Undirected returns the union of g and its transpose; j is a neighbor of i in the
+// returned Graph if i is a neighbor of j or j is a neighbor of i in g.
+func (g Graph) Undirected() Graph {
+	u := make(Graph, len(g))
+	seen := make([]map[int]bool, len(g))
+	for i := range seen {
+		seen[i] = make(map[int]bool)
+	}
+	add := func(i int, e Edge) {
+		if !seen[i][e.To] {
+			seen[i][e.To] = true
+			u[i] = append(u[i], e)
+		}
+	}
+	for i, edges := range g {
+		for _, e := range edges {
+			add(i, e)
+			add(e.To, Edge{To: i, Weight: e.Weight})
+		}
+	}
+	for _, edges := range u {
+		sort.Sort(byWeight(edges))
+	}
+	return u
+}
+
+type byWeight []Edge
+
+func (e byWeight) Len() int      { return len(e) }
+func (e byWeight) Swap(i, j int) { e[i], e[j] = e[j], e[i] }
+func (e byWeight) Less(i, j int) bool {
+	return e[i].Weight < e[j].Weight || (e[i].Weight == e[j].Weight && e[i].To < e[j].To)
+}
+
+func sqDist(a, b []float64) float64 {
+	var sum float64
+	for i, v := range a {
+		d := v - b[i]
+		sum += d * d
+	}
+	return sum
+}
+
+// points returns the values of data, checking for consistent dimensionality.
+func points(data cluster.Interface, k int) ([][]float64, error) {
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "knn", Err: cluster.ErrEmptyData}
+	}
+	if k < 1 || k >= data.Len() {
+		return nil, errors.New("knn: invalid k")
+	}
+	p := make([][]float64, data.Len())
+	dim := len(data.Values(0))
+	for i := range p {
+		p[i] = append([]float64(nil), data.Values(i)...)
+		if len(p[i]) != dim {
+			return nil, &cluster.Error{Pkg: "knn", Err: cluster.ErrDimensionMismatch}
+		}
+	}
+	return p, nil
+}
+
+// Exact returns the exact k-nearest neighbor graph of data using a kd-tree.
+func Exact(data cluster.Interface, k int) (Graph, error) {
+	p, err := points(data, k)
+	if err != nil {
+		return nil, err
+	}
+	nds := make(nodes, len(p))
+	for i := range p {
+		nds[i] = &node{Point: p[i], ID: i}
+	}
+	q := make([]*node, len(nds))
+	copy(q, nds)
+	tree := kdtree.New(nds, false)
+
+	g := make(Graph, len(p))
+	for i, n := range q {
+		keep := kdtree.NewNKeeper(k + 1)
+		tree.NearestSet(keep, n)
+		edges := make([]Edge, 0, k)
+		for _, h := range keep.Heap {
+			if h.Comparable == nil {
+				continue
+			}
+			nb := h.Comparable.(*node)
+			if nb.ID == i {
+				continue
+			}
+			edges = append(edges, Edge{To: nb.ID, Weight: math.Sqrt(h.Dist)})
+		}
+		sort.Sort(byWeight(edges))
+		if len(edges) > k {
+			edges = edges[:k]
+		}
+		g[i] = edges
+	}
+	return g, nil
+}
+
+// candidate is a neighbor held during NN-descent.
+type candidate struct {
+	id   int
+	dist float64
+	new  bool
+}
+
+// update inserts j at distance d into the sorted candidate list of length at most k,
+// returning the updated list and whether the list was changed.
+func update(list []candidate, j int, d float64, k int) ([]candidate, bool) {
+	if len(list) == k && d >= list[k-1].dist {
+		return list, false
+	}
+	for _, c := range list {
+		if c.id == j {
+			return list, false
+		}
+	}
+	i := sort.Search(len(list), func(i int) bool { return list[i].dist > d })
+	if len(list) < k {
+		list = append(list, candidate{})
+	}
+	copy(list[i+1:], list[i:])
+	list[i] = candidate{id: j, dist: d, new: true}
+	return list, true
+}
+
+// NNDescent returns an approximate k-nearest neighbor graph of data using the
+// NN-descent algorithm of Dong, Charikar and Li. Iteration stops when fewer than
+// delta*k*n neighbor list updates are made in an iteration, or after maxIter
+// iterations. The initial neighbor lists are drawn from rnd, or from the global
+// math/rand source if rnd is nil.
+func NNDescent(data cluster.Interface, k, maxIter int, delta float64, rnd *rand.Rand) (Graph, error) {
+	p, err := points(data, k)
+	if err != nil {
+		return nil, err
+	}
+	intn := rand.Intn
+	if rnd != nil {
+		intn = rnd.Intn
+	}
+	n := len(p)
+	lists := make([][]candidate, n)
+	for i := range lists {
+		lists[i] = make([]candidate, 0, k)
+		for len(lists[i]) < k {
+			j := intn(n)
+			if j == i {
+				continue
+			}
+			lists[i], _ = update(lists[i], j, sqDist(p[i], p[j]), k)
+		}
+	}
+
+	var (
+		fresh = make([][]int, n)
+		old   = make([][]int, n)
+	)
+	for iter := 0; iter < maxIter; iter++ {
+		for i := range fresh {
+			fresh[i] = fresh[i][:0]
+			old[i] = old[i][:0]
+		}
+		for i, list := range lists {
+			for l, c := range list {
+				if c.new {
+					fresh[i] = append(fresh[i], c.id)
+					fresh[c.id] = append(fresh[c.id], i)
+					lists[i][l].new = false
+				} else {
+					old[i] = append(old[i], c.id)
+					old[c.id] = append(old[c.id], i)
+				}
+			}
+		}
+
+		var updates int
+		join := func(a, b int) {
+			if a == b {
+				return
+			}
+			d := sqDist(p[a], p[b])
+			var ok bool
+			if lists[a], ok = update(lists[a], b, d, k); ok {
+				updates++
+			}
+			if lists[b], ok = update(lists[b], a, d, k); ok {
+				updates++
+			}
+		}
+		for i := range lists {
+			f := unique(fresh[i])
+			o := unique(old[i])
+			for x, a := range f {
+				for _, b := range f[x+1:] {
+					join(a, b)
+				}
+				for _, b := range o {
+					join(a, b)
+				}
+			}
+		}
+		if float64(updates) <= delta*float64(k*n) {
+			break
+		}
+	}
+
+	g := make(Graph, n)
+	for i, list := range lists {
+		g[i] = make([]Edge, len(list))
+		for l, c := range list {
+			g[i][l] = Edge{To: c.id, Weight: math.Sqrt(c.dist)}
+		}
+	}
+	return g, nil
+}
+
+// unique sorts s and returns it with duplicate elements removed.
+func unique(s []int) []int {
+	sort.Ints(s)
+	u := s[:0]
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			u = append(u, v)
+		}
+	}
+	return u
+}
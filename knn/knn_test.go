@@ -0,0 +1,131 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package knn_test
+
+import (
+	"github.com/biogo/cluster/knn"
+
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type points [][]float64
+
+func (p points) Len() int               { return len(p) }
+func (p points) Values(i int) []float64 { return p[i] }
+
+func random(rnd *rand.Rand, n, dim int) points {
+	p := make(points, n)
+	for i := range p {
+		p[i] = make([]float64, dim)
+		for j := range p[i] {
+			p[i][j] = rnd.Float64()
+		}
+	}
+	return p
+}
+
+// brute returns the indices of the k nearest neighbors of each point.
+func brute(p points, k int) [][]int {
+	nn := make([][]int, len(p))
+	for i := range p {
+		idx := make([]int, 0, len(p)-1)
+		for j := range p {
+			if j != i {
+				idx = append(idx, j)
+			}
+		}
+		d := func(j int) float64 {
+			var ss float64
+			for l := range p[i] {
+				x := p[i][l] - p[j][l]
+				ss += x * x
+			}
+			return ss
+		}
+		sort.Slice(idx, func(a, b int) bool { return d(idx[a]) < d(idx[b]) })
+		nn[i] = idx[:k]
+	}
+	return nn
+}
+
+func (s *S) TestExact(c *check.C) {
+	p := random(rand.New(rand.NewSource(1)), 200, 3)
+	const k = 5
+	g, err := knn.Exact(p, k)
+	c.Assert(err, check.Equals, nil)
+	want := brute(p, k)
+	for i, edges := range g {
+		c.Assert(len(edges), check.Equals, k)
+		for j, e := range edges {
+			c.Check(e.To, check.Equals, want[i][j])
+			var ss float64
+			for l := range p[i] {
+				x := p[i][l] - p[e.To][l]
+				ss += x * x
+			}
+			c.Check(e.Weight, check.Equals, math.Sqrt(ss))
+		}
+	}
+
+	_, err = knn.Exact(p, len(p))
+	c.Check(err, check.ErrorMatches, "knn: invalid k")
+	_, err = knn.Exact(points{}, 1)
+	c.Check(err, check.ErrorMatches, "knn: no data")
+}
+
+func (s *S) TestNNDescent(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	p := random(rnd, 500, 4)
+	const k = 10
+	g, err := knn.NNDescent(p, k, 20, 0.001, rnd)
+	c.Assert(err, check.Equals, nil)
+	want := brute(p, k)
+	var found int
+	for i, edges := range g {
+		c.Assert(len(edges), check.Equals, k)
+		set := make(map[int]bool)
+		for _, j := range want[i] {
+			set[j] = true
+		}
+		for _, e := range edges {
+			if set[e.To] {
+				found++
+			}
+		}
+	}
+	recall := float64(found) / float64(k*len(p))
+	c.Check(recall > 0.95, check.Equals, true, check.Commentf("recall=%f", recall))
+
+	var again [2]knn.Graph
+	for i := range again {
+		again[i], err = knn.NNDescent(p, k, 2, 0.001, rand.New(rand.NewSource(2)))
+		c.Assert(err, check.Equals, nil)
+	}
+	c.Check(again[1], check.DeepEquals, again[0])
+}
+
+func (s *S) TestUndirected(c *check.C) {
+	g := knn.Graph{
+		{{To: 1, Weight: 1}},
+		{{To: 0, Weight: 1}},
+		{{To: 1, Weight: 2}},
+	}
+	c.Check(g.Undirected(), check.DeepEquals, knn.Graph{
+		{{To: 1, Weight: 1}},
+		{{To: 0, Weight: 1}, {To: 2, Weight: 2}},
+		{{To: 1, Weight: 2}},
+	})
+}
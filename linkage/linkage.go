@@ -0,0 +1,212 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package linkage implements hierarchical linkage clustering.
+package linkage
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/mst"
+
+	"errors"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	w       float64
+	cluster int
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+type center struct {
+	point
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// values is a collection of value that satisfies cluster.Interface.
+type values []value
+
+func (v values) Len() int               { return len(v) }
+func (v values) Values(i int) []float64 { return v[i].point }
+
+// Single implements single-linkage clustering of ℝⁿ data. The single-linkage
+// dendrogram is equivalent to the Euclidean minimum spanning tree of the data, which
+// is constructed with kd-tree accelerated nearest neighbor queries rather than by
+// naive agglomeration. Flat clusters are obtained by cutting the tree: values are
+// in the same cluster when they are connected by a chain of values with successive
+// distances no greater than the cut threshold.
+type Single struct {
+	threshold float64
+	k         int
+	metric    cluster.Metric
+	dm        cluster.DistanceMatrixer
+
+	values  values
+	built   bool
+	tree    mst.Tree
+	centers []center
+}
+
+// NewSingle creates a new single-linkage Clusterer object populated with data from
+// an Interface value, data. Clusters are formed by cutting the tree at threshold.
+func NewSingle(data cluster.Interface, threshold float64) (*Single, error) {
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "linkage", Err: cluster.ErrEmptyData}
+	}
+	if threshold < 0 {
+		return nil, errors.New("linkage: invalid threshold")
+	}
+	dim := len(data.Values(0))
+	va := make(values, data.Len())
+	w, isWeighter := data.(cluster.Weighter)
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dim {
+			return nil, &cluster.Error{Pkg: "linkage", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...), w: 1}
+		if isWeighter {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return &Single{threshold: threshold, values: va}, nil
+}
+
+// NewSingleMatrix creates a new single-linkage Clusterer object for the elements of
+// dm using the distances it provides, so that data without coordinates can be
+// clustered. The tree is constructed with Prim's algorithm in O(n²) time. If dm
+// satisfies cluster.Weighter, the weights are retained by the values. The values
+// and centers of the returned Single have no coordinates, and SetMetric has no
+// effect.
+func NewSingleMatrix(dm cluster.DistanceMatrixer, threshold float64) (*Single, error) {
+	if dm.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "linkage", Err: cluster.ErrEmptyData}
+	}
+	if threshold < 0 {
+		return nil, errors.New("linkage: invalid threshold")
+	}
+	va := make(values, dm.Len())
+	w, isWeighter := dm.(cluster.Weighter)
+	for i := range va {
+		va[i].w = 1
+		if isWeighter {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return &Single{threshold: threshold, dm: dm, values: va}, nil
+}
+
+// SetMetric sets the dissimilarity between values. If m is nil, the default,
+// Euclidean distance is used and the tree is constructed with kd-tree accelerated
+// queries. Otherwise the tree is constructed with Prim's algorithm in O(n²) time,
+// and m need not satisfy the triangle inequality. SetMetric discards any tree
+// constructed by a previous call to Cluster.
+func (s *Single) SetMetric(m cluster.Metric) {
+	s.metric = m
+	s.built = false
+}
+
+// Cluster runs a clustering of the data. The minimum spanning tree is constructed
+// on the first call to Cluster and is retained for subsequent cuts.
+func (s *Single) Cluster() error {
+	if !s.built {
+		switch {
+		case s.dm != nil:
+			s.tree = mst.Prim(len(s.values), s.dm.Distance)
+		case s.metric == nil:
+			var err error
+			s.tree, err = mst.Boruvka(s.values, nil)
+			if err != nil {
+				return err
+			}
+		default:
+			s.tree = mst.Prim(len(s.values), func(i, j int) float64 {
+				return s.metric.Distance(s.values[i].point, s.values[j].point)
+			})
+		}
+		s.built = true
+	}
+	var labels []int
+	if s.k > 0 {
+		labels = s.tree.CutK(s.k)
+	} else {
+		labels = s.tree.Cut(s.threshold)
+	}
+	s.label(labels)
+	return nil
+}
+
+// Cut sets the threshold used to cut the tree and relabels the values if the tree
+// has been constructed.
+func (s *Single) Cut(threshold float64) {
+	s.threshold, s.k = threshold, 0
+	if s.built {
+		s.label(s.tree.Cut(threshold))
+	}
+}
+
+// CutK arranges for the tree to be cut into k clusters by removal of its k-1
+// heaviest edges, and relabels the values if the tree has been constructed.
+func (s *Single) CutK(k int) {
+	s.k = k
+	if s.built {
+		s.label(s.tree.CutK(k))
+	}
+}
+
+// Tree returns the minimum spanning tree of the data constructed by Cluster.
+func (s *Single) Tree() mst.Tree { return s.tree }
+
+func (s *Single) label(labels []int) {
+	s.centers = s.centers[:0]
+	for i, l := range labels {
+		if l == len(s.centers) {
+			s.centers = append(s.centers, center{point: make(point, len(s.values[i].point))})
+		}
+		s.values[i].cluster = l
+		s.centers[l].indices = append(s.centers[l].indices, i)
+	}
+	for i := range s.centers {
+		c := &s.centers[i]
+		var w float64
+		for _, j := range c.indices {
+			v := s.values[j]
+			for k, x := range v.point {
+				c.point[k] += v.w * x
+			}
+			w += v.w
+		}
+		for k := range c.point {
+			c.point[k] /= w
+		}
+	}
+}
+
+// Centers returns the centers determined by a previous call to Cluster, ordered by
+// their lowest index member. The location of each center is the weighted mean of
+// its members.
+func (s *Single) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(s.centers))
+	for i := range s.centers {
+		cs[i] = &s.centers[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Single.
+func (s *Single) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(s.values))
+	for i := range s.values {
+		vs[i] = &s.values[i]
+	}
+	return vs
+}
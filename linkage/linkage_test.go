@@ -0,0 +1,102 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linkage_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/linkage"
+
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+// Feature is a genomic feature with a start and end position on a chromosome.
+type Feature struct {
+	ID         string
+	Start, End int
+}
+
+type Features []*Feature
+
+func (f Features) Len() int               { return len(f) }
+func (f Features) Values(i int) []float64 { return []float64{float64(f[i].Start), float64(f[i].End)} }
+
+// chain is a set of features that chain along a chromosome.
+var chain = Features{
+	{ID: "0", Start: 0, End: 100},
+	{ID: "1", Start: 50, End: 150},
+	{ID: "2", Start: 100, End: 200},
+	{ID: "3", Start: 150, End: 250},
+	{ID: "4", Start: 1000, End: 1100},
+	{ID: "5", Start: 1040, End: 1140},
+	{ID: "6", Start: 5000, End: 5100},
+}
+
+func members(c cluster.Clusterer) []cluster.Indices {
+	var m []cluster.Indices
+	for _, cen := range c.Centers() {
+		m = append(m, cen.Members())
+	}
+	return m
+}
+
+func (s *S) TestSingle(c *check.C) {
+	sl, err := linkage.NewSingle(chain, 100)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(sl.Cluster(), check.Equals, nil)
+	c.Check(members(sl), check.DeepEquals, []cluster.Indices{{0, 1, 2, 3}, {4, 5}, {6}})
+	c.Check(len(sl.Tree()), check.Equals, len(chain)-1)
+	c.Check(sl.Centers()[1].V(), check.DeepEquals, []float64{1020, 1120})
+
+	sl.Cut(50)
+	c.Check(members(sl), check.DeepEquals, []cluster.Indices{{0}, {1}, {2}, {3}, {4}, {5}, {6}})
+	sl.CutK(2)
+	c.Check(members(sl), check.DeepEquals, []cluster.Indices{{0, 1, 2, 3, 4, 5}, {6}})
+	c.Assert(sl.Cluster(), check.Equals, nil)
+	c.Check(len(sl.Centers()), check.Equals, 2)
+
+	sl, err = linkage.NewSingle(chain, 90)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(sl.Cluster(), check.Equals, nil)
+	c.Check(members(sl), check.DeepEquals, []cluster.Indices{{0, 1, 2, 3}, {4, 5}, {6}})
+	sl.SetMetric(cluster.Manhattan)
+	c.Assert(sl.Cluster(), check.Equals, nil)
+	c.Check(members(sl), check.DeepEquals, []cluster.Indices{{0}, {1}, {2}, {3}, {4, 5}, {6}})
+	c.Check(sl.Tree()[0].Weight, check.Equals, 80.)
+
+	_, err = linkage.NewSingle(Features{}, 1)
+	c.Check(err, check.ErrorMatches, "linkage: no data")
+	_, err = linkage.NewSingle(chain, -1)
+	c.Check(err, check.ErrorMatches, "linkage: invalid threshold")
+}
+
+func (s *S) TestSingleMatrix(c *check.C) {
+	dm := cluster.NewDistanceMatrix(len(chain))
+	for i := range chain {
+		for j := 0; j < i; j++ {
+			dm.SetDistance(i, j, cluster.Euclidean.Distance(chain.Values(i), chain.Values(j)))
+		}
+	}
+	sl, err := linkage.NewSingleMatrix(dm, 100)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(sl.Cluster(), check.Equals, nil)
+	c.Check(members(sl), check.DeepEquals, []cluster.Indices{{0, 1, 2, 3}, {4, 5}, {6}})
+	c.Check(len(sl.Tree()), check.Equals, len(chain)-1)
+	c.Check(len(sl.Centers()[1].V()), check.Equals, 0)
+	sl.CutK(2)
+	c.Check(members(sl), check.DeepEquals, []cluster.Indices{{0, 1, 2, 3, 4, 5}, {6}})
+
+	_, err = linkage.NewSingleMatrix(cluster.NewDistanceMatrix(0), 1)
+	c.Check(err, check.ErrorMatches, "linkage: no data")
+	_, err = linkage.NewSingleMatrix(dm, -1)
+	c.Check(err, check.ErrorMatches, "linkage: invalid threshold")
+}
@@ -0,0 +1,103 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package meanshift
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/spatial"
+
+	"errors"
+	"math"
+)
+
+// EstimateBandwidth returns a bandwidth for data estimated as the mean distance from
+// each element to its kth nearest neighbor, where k is quantile times the number of
+// elements, and at least one. quantile must be in (0, 1]; smaller values give
+// smaller bandwidths and more clusters. A quantile of about 0.3 is typical. The
+// estimate takes O(n² log n) time in the worst case, so a sample of the data may be
+// used for large data sets. If data has fewer than two elements, the error returned
+// wraps cluster.ErrEmptyData.
+func EstimateBandwidth(data cluster.Interface, quantile float64) (float64, error) {
+	n := data.Len()
+	if n < 2 {
+		return 0, &cluster.Error{Pkg: "meanshift", Err: cluster.ErrEmptyData}
+	}
+	if !(quantile > 0 && quantile <= 1) {
+		return 0, errors.New("meanshift: invalid quantile")
+	}
+	k := int(quantile * float64(n))
+	if k < 1 {
+		k = 1
+	}
+	if k > n-1 {
+		k = n - 1
+	}
+
+	idx := spatial.NewKDTree()
+	idx.Build(data)
+	var (
+		hits []spatial.Neighbor
+		sum  float64
+	)
+	for i := 0; i < n; i++ {
+		// The nearest neighbor of each element is itself or a
+		// coincident element at a distance of zero.
+		hits = idx.NearestSet(hits[:0], data.Values(i), k+1)
+		sum += hits[len(hits)-1].Dist
+	}
+	return sum / float64(n), nil
+}
+
+// Silverman returns a bandwidth for data according to Silverman's rule of thumb,
+// σ(4/((d+2)n))^(1/(d+4)), where σ is the mean over dimensions of the standard
+// deviation of the data and d is the dimensionality. The rule is optimal for
+// Gaussian data, and over-smooths multimodal data.
+func Silverman(data cluster.Interface) (float64, error) {
+	sd, n, d, err := spread(data)
+	if err != nil {
+		return 0, err
+	}
+	return sd * math.Pow(4/((d+2)*n), 1/(d+4)), nil
+}
+
+// Scott returns a bandwidth for data according to Scott's rule, σn^(-1/(d+4)),
+// where σ is the mean over dimensions of the standard deviation of the data and d is
+// the dimensionality.
+func Scott(data cluster.Interface) (float64, error) {
+	sd, n, d, err := spread(data)
+	if err != nil {
+		return 0, err
+	}
+	return sd * math.Pow(n, -1/(d+4)), nil
+}
+
+// spread returns the mean over dimensions of the standard deviation of data and the
+// number and dimensionality of its elements. If data has fewer than two elements,
+// the error returned wraps cluster.ErrEmptyData.
+func spread(data cluster.Interface) (sd, n, d float64, err error) {
+	if data.Len() < 2 {
+		return 0, 0, 0, &cluster.Error{Pkg: "meanshift", Err: cluster.ErrEmptyData}
+	}
+	dims := len(data.Values(0))
+	mean := make([]float64, dims)
+	ss := make([]float64, dims)
+	for i := 0; i < data.Len(); i++ {
+		v := data.Values(i)
+		if len(v) != dims {
+			return 0, 0, 0, &cluster.Error{Pkg: "meanshift", Err: cluster.ErrDimensionMismatch}
+		}
+		// Welford's online update.
+		for j, x := range v {
+			delta := x - mean[j]
+			mean[j] += delta / float64(i+1)
+			ss[j] += delta * (x - mean[j])
+		}
+	}
+	n = float64(data.Len())
+	for _, s := range ss {
+		sd += math.Sqrt(s / (n - 1))
+	}
+	return sd / float64(dims), n, float64(dims), nil
+}
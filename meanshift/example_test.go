@@ -39,7 +39,7 @@ var feats = []*Feature{
 }
 
 func Example() {
-	ms := meanshift.New(Features(feats), meanshift.NewTruncGauss(60, 3), 0.1, 5)
+	ms := meanshift.New(Features(feats), meanshift.NewTruncGauss(60, 3), 0.1, 10)
 	err := ms.Cluster()
 	if err != nil {
 		fmt.Println(err)
@@ -67,24 +67,24 @@ func Example() {
 
 	// Output:
 	// Cluster 0:
-	//  5 -------------------------------------
-	//
-	// Cluster 1:
 	//  0 ------------------------------------------------------------------------------------
 	//  1 ------------------------------------------------------------------------------------
 	//
-	// Cluster 2:
-	//  4 -----------------------------
-	//  3 ------------------------------
+	// Cluster 1:
 	//  2 ------------------------------
+	//  3 ------------------------------
+	//  4 -----------------------------
+	//
+	// Cluster 2:
+	//  5 -------------------------------------
 	//
 	// Cluster 3:
-	//  7                                    ------------
 	//  6                                 ------------
+	//  7                                    ------------
 	//
 	// Cluster 4:
-	//  9                                                --------------------------------------
 	//  8                                                   -----------------------------------
+	//  9                                                --------------------------------------
 	// 10                                                   --------------------------------
 	//
 	// betweenSS / totalSS = 0.998655
@@ -6,7 +6,10 @@
 package meanshift
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 
 	"github.com/biogo/cluster/cluster"
 )
@@ -28,9 +31,25 @@ type center struct {
 	pnt
 	w       float64
 	indices cluster.Indices
+	density float64
 }
 
 func (c *center) Members() cluster.Indices { return c.indices }
+func (c *center) Density() float64         { return c.density }
+func (c *center) Weight() float64          { return c.w }
+
+// Mode is a cluster center located at a mode of a kernel density estimate.
+type Mode interface {
+	cluster.Center
+
+	// Density returns the sum over the data of the data weights multiplied by
+	// the kernel evaluated at the mode. The sum is proportional to the kernel
+	// density estimate at the mode.
+	Density() float64
+
+	// Weight returns the total weight of the members of the cluster.
+	Weight() float64
+}
 
 // Shifter implements a single step of the mean shift algorithm.
 type Shifter interface {
@@ -49,40 +68,80 @@ type Shifter interface {
 	Centers() []cluster.Center
 }
 
+// Inserter is a Shifter that can shift points that are not part of the data it
+// was initialised with.
+type Inserter interface {
+	Shifter
+
+	// Ascend shifts p in place up the kernel density estimate of the
+	// initialised data until the squared distance moved in an iteration
+	// is no greater than tol or maxIter iterations have been performed.
+	// It returns the density at the final location of p.
+	Ascend(p []float64, tol float64, maxIter int) (density float64)
+
+	// MergeRadius returns the distance within which shifted points are
+	// merged into a single mode.
+	MergeRadius() float64
+}
+
 // MeanShift implements data clustering using the mean shift algorithm.
 type MeanShift struct {
 	k       Shifter
 	tol     float64
 	maxIter int
 	values  []value
+	n       int
 	centers []center
 	ci      []cluster.Indices
+	noise   float64
+	periods []float64
+
+	// desc describes the Shifter of a MeanShift
+	// restored from a serialized model, which
+	// has no Shifter.
+	desc description
+
+	data cluster.Fingerprint
+	iter int
 }
 
 // New creates a new mean shift Clusterer object populated with data from an Interface value, data
-// and using the Shifter k.
-func New(data cluster.Interface, k Shifter, tol float64, maxIter int) *MeanShift {
+// and using the Shifter k. The options in opts are applied in order after tol and maxIter
+// are set, so WithTolerance and WithMaxIter override them.
+func New(data cluster.Interface, k Shifter, tol float64, maxIter int, opts ...Option) *MeanShift {
 	k.Init(data)
-	return &MeanShift{
+	ms := &MeanShift{
 		k:       k,
 		tol:     tol,
 		maxIter: maxIter,
-		values:  convert(data),
+		values:  convert(nil, data),
+		n:       data.Len(),
+		periods: periodsOf(k),
+		data:    cluster.FingerprintOf(data),
 	}
+	for _, o := range opts {
+		o(ms)
+	}
+	return ms
 }
 
-// convert renders data to the internal float64 representation for a MeanShift.
-func convert(data cluster.Interface) []value {
-	va := make([]value, data.Len())
-	for i := 0; i < data.Len(); i++ {
-		va[i] = value{pnt: append(pnt(nil), data.Values(i)...)}
+// convert renders data to the internal float64 representation for a MeanShift,
+// reusing the storage held by va.
+func convert(va []value, data cluster.Interface) []value {
+	n := data.Len()
+	if cap(va) < n {
+		va = append(va[:cap(va)], make([]value, n-cap(va))...)
+	}
+	va = va[:n]
+	for i := range va {
+		va[i] = value{pnt: append(va[i].pnt[:0], data.Values(i)...)}
 	}
 	if w, ok := data.(cluster.Weighter); ok {
-		for i := 0; i < data.Len(); i++ {
+		for i := range va {
 			va[i].w = w.Weight(i)
 		}
 	} else {
-		for i := 0; i < data.Len(); i++ {
+		for i := range va {
 			va[i].w = 1
 		}
 	}
@@ -90,44 +149,257 @@ func convert(data cluster.Interface) []value {
 	return va
 }
 
-// Cluster runs a clustering of the data using the mean shift algorithm.
+// Reset replaces the data held by ms with data and reinitialises its Shifter so
+// that ms can be clustered again, reusing storage held by ms, the Shifter and its
+// spatial index where possible. This reduces allocation when clustering many small
+// data sets in turn. Centers and Values previously returned by ms, and results
+// returned by methods of the Shifter, must not be used after a call to Reset.
+// If the Shifter was given a built index, it is not rebuilt and so must already
+// hold data.
+func (ms *MeanShift) Reset(data cluster.Interface) {
+	ms.k.Init(data)
+	ms.values = convert(ms.values[:0], data)
+	ms.n = data.Len()
+	ms.periods = periodsOf(ms.k)
+	ms.centers = nil
+	ms.ci = nil
+	ms.data = cluster.FingerprintOf(data)
+	ms.iter = 0
+}
+
+// Noise is the cluster label of values that are not assigned to any cluster.
+const Noise = -1
+
+// SetNoiseRadius arranges for values that are further than r from every mode to be
+// labelled as Noise rather than assigned to the mode their trajectory converged
+// to. Noise values are not members of any center and are excluded from Within.
+// For kernels with finite support, r is typically the bandwidth. If r is not
+// positive, every value is assigned to a cluster. SetNoiseRadius must be called
+// before Cluster.
+func (ms *MeanShift) SetNoiseRadius(r float64) { ms.noise = r }
+
+// ErrMaxIterations is returned by Cluster when the clustering has not converged
+// within the maximum number of iterations. The clustering state is valid and holds
+// the centers found from the partially shifted data. ErrMaxIterations wraps
+// cluster.ErrMaxIterations.
+var ErrMaxIterations error = &cluster.Error{Pkg: "meanshift", Err: cluster.ErrMaxIterations}
+
+// Cluster runs a clustering of the data using the mean shift algorithm. If the
+// clustering has not converged after maxIter iterations, Cluster stops and returns
+// ErrMaxIterations.
 func (ms *MeanShift) Cluster() error {
-	var err error
-	for i := 0; ; i++ {
-		delta := ms.k.Shift()
-		if delta <= ms.tol {
-			break
+	return ms.ClusterContext(context.Background())
+}
+
+// ClusterContext runs a clustering of the data as for Cluster, checking ctx before
+// each iteration. If ctx is done, ClusterContext stops and returns ctx.Err(). As
+// for ErrMaxIterations, the clustering state is valid and holds the centers found
+// from the partially shifted data.
+func (ms *MeanShift) ClusterContext(ctx context.Context) error {
+	ms.iter = 0
+	for {
+		if err := ctx.Err(); err != nil {
+			ms.collect()
+			return err
+		}
+		if _, done := ms.Step(); done {
+			return nil
 		}
-		if i > ms.maxIter {
-			err = fmt.Errorf("meanshift: exceeded maximum iterations: delta=%f", delta)
+		if ms.iter >= ms.maxIter {
+			ms.collect()
+			return ErrMaxIterations
 		}
 	}
+}
 
-	var cen []cluster.Center
-	cen = ms.k.Centers()
+// Step performs a single iteration of the mean shift algorithm using the Shifter and
+// returns the sum of squares differences between the states before and after the
+// iteration. If delta is no greater than the tolerance, done is true and the centers
+// and cluster assignments are updated. Step does not consider the maximum number of
+// iterations; callers driving the iteration are responsible for termination.
+func (ms *MeanShift) Step() (delta float64, done bool) {
+	delta = ms.k.Shift()
+	ms.iter++
+	if delta > ms.tol {
+		return delta, false
+	}
+	ms.collect()
+	return delta, true
+}
+
+// collect sets the centers and cluster assignments from the current state of the
+// Shifter.
+func (ms *MeanShift) collect() {
+	ms.values = ms.values[:ms.n]
+	cen := ms.k.Centers()
 	ms.ci = make([]cluster.Indices, len(cen))
 	ms.centers = make([]center, len(cen))
 	for i, c := range cen {
 		ms.ci[i] = c.Members()
-		ms.centers[i] = center{pnt: c.V(), indices: ms.ci[i]}
+		ms.centers[i] = center{pnt: c.V(), indices: ms.ci[i], density: math.NaN()}
+		if m, ok := c.(Mode); ok {
+			ms.centers[i].density = m.Density()
+		}
 		for _, j := range ms.ci[i] {
 			ms.values[j].cluster = i
+			ms.centers[i].w += ms.values[j].w
+		}
+	}
+	if ms.noise > 0 {
+		ms.label()
+	}
+}
+
+// label labels values beyond the noise radius of every center as Noise, removing
+// them from the centers, and discards centers that are left without members.
+func (ms *MeanShift) label() {
+	r := ms.noise * ms.noise
+	for j := range ms.values {
+		v := &ms.values[j]
+		if _, d := ms.Predict(v.pnt); d > r {
+			v.cluster = Noise
 		}
 	}
+	centers := ms.centers[:0]
+	ms.ci = ms.ci[:0]
+	for _, c := range ms.centers {
+		var kept cluster.Indices
+		c.w = 0
+		for _, j := range c.indices {
+			v := &ms.values[j]
+			if v.cluster == Noise {
+				continue
+			}
+			v.cluster = len(centers)
+			kept = append(kept, j)
+			c.w += v.w
+		}
+		if len(kept) == 0 {
+			continue
+		}
+		c.indices = kept
+		centers = append(centers, c)
+		ms.ci = append(ms.ci, kept)
+	}
+	ms.centers = centers
+}
 
-	return err
+// Insert adds the elements of data to a clustered MeanShift without reclustering.
+// Each new element is shifted up the kernel density estimate of the original data,
+// which is not altered, and is assigned to the nearest existing mode if it
+// converges within the Shifter's merge radius of it. Otherwise a new mode is added
+// at the converged location. If a noise radius has been set, new elements further
+// than the radius from every mode are labelled as Noise before shifting. New
+// elements are indexed following the existing values. The Shifter must be an
+// Inserter. Inserted elements are discarded by a
+// subsequent call to Cluster.
+func (ms *MeanShift) Insert(data cluster.Interface) error {
+	k, ok := ms.k.(Inserter)
+	if !ok {
+		return errors.New("meanshift: shifter does not support insertion")
+	}
+	if ms.centers == nil {
+		return &cluster.Error{Pkg: "meanshift", Err: cluster.ErrNoCenters}
+	}
+	r := k.MergeRadius()
+	r *= r
+	for _, v := range convert(nil, data) {
+		if _, d := ms.Predict(v.pnt); ms.noise > 0 && d > ms.noise*ms.noise {
+			v.cluster = Noise
+			ms.values = append(ms.values, v)
+			continue
+		}
+		p := append(pnt(nil), v.pnt...)
+		density := k.Ascend(p, ms.tol, ms.maxIter)
+		c, d := ms.Predict(p)
+		if c < 0 || d > r {
+			c = len(ms.centers)
+			ms.centers = append(ms.centers, center{pnt: p, density: density})
+			ms.ci = append(ms.ci, nil)
+		}
+		v.cluster = c
+		ms.centers[c].indices = append(ms.centers[c].indices, len(ms.values))
+		ms.centers[c].w += v.w
+		ms.ci[c] = ms.centers[c].indices
+		ms.values = append(ms.values, v)
+	}
+	return nil
 }
 
-// Total calculates the total sum of squares for the data relative to the data mean.
+// description describes the Shifter used by a MeanShift.
+type description struct {
+	kernel    string
+	bandwidth float64
+	merge     float64 // merge is zero if the Shifter does not provide a merge radius.
+	mode      string  // mode is empty if the Shifter does not provide a center mode.
+	bin       float64 // bin is zero if bin seeding is disabled.
+	minBin    int
+}
+
+// describe returns a description of the Shifter used by ms.
+func (ms *MeanShift) describe() description {
+	if ms.k == nil {
+		return ms.desc
+	}
+	d := description{kernel: fmt.Sprintf("%T", ms.k), bandwidth: ms.k.Bandwidth()}
+	if in, ok := ms.k.(Inserter); ok {
+		d.merge = in.MergeRadius()
+	}
+	if s, ok := ms.k.(describer); ok {
+		s.describe(&d)
+	}
+	return d
+}
+
+// Manifest returns a record of the parameters and data used for the clustering.
+// The merge radius, center mode, periods and bin seeding parameters are recorded
+// when the Shifter provides them.
+func (ms *MeanShift) Manifest() cluster.Manifest {
+	d := ms.describe()
+	m := cluster.Manifest{
+		Algorithm: "meanshift",
+		Parameters: map[string]interface{}{
+			"kernel":    d.kernel,
+			"bandwidth": d.bandwidth,
+			"tol":       ms.tol,
+			"maxIter":   ms.maxIter,
+		},
+		Data:       ms.data,
+		Iterations: ms.iter,
+		Version:    cluster.ModuleVersion(),
+	}
+	if ms.noise > 0 {
+		m.Parameters["noise"] = ms.noise
+	}
+	if d.merge > 0 {
+		m.Parameters["mergeRadius"] = d.merge
+	}
+	if d.mode != "" {
+		m.Parameters["centerMode"] = d.mode
+	}
+	if d.bin > 0 {
+		m.Parameters["binWidth"] = d.bin
+		m.Parameters["minBinCount"] = d.minBin
+	}
+	if ms.periods != nil {
+		m.Parameters["periods"] = ms.periods
+	}
+	return m
+}
+
+// Total calculates the total weighted sum of squares for the data relative to the
+// weighted data mean. Periodic dimensions are treated as linear.
 func (ms *MeanShift) Total() float64 {
 	p := make([]float64, len(ms.values[0].pnt))
 
+	var w float64
 	for _, v := range ms.values {
 		for i := range p {
-			p[i] += v.pnt[i]
+			p[i] += v.pnt[i] * v.w
 		}
+		w += v.w
 	}
-	inv := 1 / float64(len(ms.values))
+	inv := 1 / w
 	for i := range p {
 		p[i] *= inv
 	}
@@ -136,15 +408,16 @@ func (ms *MeanShift) Total() float64 {
 	for _, v := range ms.values {
 		for i := range p {
 			d := p[i] - v.pnt[i]
-			ss += d * d
+			ss += d * d * v.w
 		}
 	}
 
 	return ss
 }
 
-// Within calculates the sum of squares within each cluster. It returns nil if Cluster
-// has not been called.
+// Within calculates the weighted sum of squares within each cluster. Noise values
+// are not included. Differences in periodic dimensions are taken by the shortest
+// path. It returns nil if Cluster has not been called.
 func (ms *MeanShift) Within() []float64 {
 	if ms.centers == nil {
 		return nil
@@ -152,16 +425,74 @@ func (ms *MeanShift) Within() []float64 {
 	ss := make([]float64, len(ms.centers))
 
 	for _, v := range ms.values {
-		for i := range ms.centers[0].pnt {
-			d := ms.centers[v.cluster].pnt[i] - v.pnt[i]
-			ss[v.cluster] += d * d
+		if v.cluster == Noise {
+			continue
+		}
+		for i, x := range ms.centers[v.cluster].pnt {
+			d := wrap(x-v.pnt[i], period(ms.periods, i))
+			ss[v.cluster] += d * d * v.w
 		}
 	}
 
 	return ss
 }
 
-// Centers returns the centers determined by a previous call to Cluster.
+// Distances returns the squared distance from each value to the center of its
+// cluster. The distance for a Noise value is NaN. It returns nil if Cluster has not
+// been called.
+func (ms *MeanShift) Distances() []float64 {
+	if ms.centers == nil {
+		return nil
+	}
+	dist := make([]float64, len(ms.values))
+	for i, v := range ms.values {
+		if v.cluster == Noise {
+			dist[i] = math.NaN()
+			continue
+		}
+		dist[i] = periodicSqDist(ms.centers[v.cluster].pnt, v.pnt, ms.periods)
+	}
+	return dist
+}
+
+// Predict returns the index of the center nearest to p and the squared distance from
+// p to that center. Predict does not shift p, so new points may be labeled without
+// reclustering. p must have the dimensionality of the data. If Cluster has not been
+// called or no centers were found, Predict returns -1 and +Inf.
+func (ms *MeanShift) Predict(p []float64) (cluster int, dist float64) {
+	cluster, dist = -1, math.Inf(1)
+	for i, c := range ms.centers {
+		if ss := periodicSqDist(c.pnt, p, ms.periods); ss < dist {
+			cluster, dist = i, ss
+		}
+	}
+	return cluster, dist
+}
+
+// Assign returns the index of the center nearest to x, as for Predict. If a noise
+// radius has been set and x is further than it from every center, Assign returns
+// Noise.
+func (ms *MeanShift) Assign(x []float64) int {
+	c, d := ms.Predict(x)
+	if ms.noise > 0 && d > ms.noise*ms.noise {
+		return Noise
+	}
+	return c
+}
+
+// PredictAll returns the results of Predict for each element of data.
+func (ms *MeanShift) PredictAll(data cluster.Interface) (clusters []int, dists []float64) {
+	clusters = make([]int, data.Len())
+	dists = make([]float64, data.Len())
+	for i := range clusters {
+		clusters[i], dists[i] = ms.Predict(data.Values(i))
+	}
+	return clusters, dists
+}
+
+// Centers returns the centers determined by a previous call to Cluster. The centers
+// satisfy Mode, and the weight of each center is the total weight of its members.
+// If the Shifter's centers do not report a density, Density returns NaN.
 func (ms *MeanShift) Centers() []cluster.Center {
 	cs := make([]cluster.Center, len(ms.centers))
 	for i := range ms.centers {
@@ -170,7 +501,22 @@ func (ms *MeanShift) Centers() []cluster.Center {
 	return cs
 }
 
-// Values returns a slice of the values in the MeanShift.
+// Assignments returns the index of the center of each value, in the order of the
+// slice returned by Values. The assignment of a value labelled as noise is Noise.
+// It returns nil if Cluster has not been called.
+func (ms *MeanShift) Assignments() []int {
+	if ms.centers == nil {
+		return nil
+	}
+	a := make([]int, len(ms.values))
+	for i, v := range ms.values {
+		a[i] = v.cluster
+	}
+	return a
+}
+
+// Values returns a slice of the values in the MeanShift. Values labelled as noise
+// have a Cluster of Noise.
 func (ms *MeanShift) Values() []cluster.Value {
 	vs := make([]cluster.Value, len(ms.values))
 	for i := range ms.values {
@@ -7,8 +7,16 @@ package meanshift_test
 import (
 	"github.com/biogo/cluster/cluster"
 	"github.com/biogo/cluster/meanshift"
+	"github.com/biogo/cluster/spatial"
 
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"math"
 	"math/rand"
+	"sort"
 	"strings"
 	"testing"
 
@@ -19,8 +27,6 @@ func Test(t *testing.T) { check.TestingT(t) }
 
 type S struct{}
 
-func (s *S) TearDownSuite(_ *check.C) { rand.Seed(1) } // Reset the seed for the example test. See note below.
-
 var _ = check.Suite(&S{})
 
 var (
@@ -49,17 +55,17 @@ var (
 	}{
 		{
 			feats,
-			60, 3, 5,
-			[]cluster.Indices{{5}, {0, 1}, {4, 3, 2}, {7, 6}, {9, 8, 10}},
+			60, 3, 10,
+			[]cluster.Indices{{0, 1}, {2, 3, 4}, {5}, {6, 7}, {8, 9, 10}},
 			4747787,
-			[]float64{0, 0.5, 52, 2500, 3833.1023809507415},
+			[]float64{0.5, 52, 0, 2500, 3834.7816596299117},
 		},
 		{
 			feats,
 			200, 3, 100,
-			[]cluster.Indices{{1, 0}, {4, 3, 2, 5}, {6, 7}, {10, 8, 9}},
+			[]cluster.Indices{{0, 1}, {2, 3, 4, 5}, {6, 7}, {8, 9, 10}},
 			4747787,
-			[]float64{0.5, 15864.884101059888, 2500, 3829.3691610066735},
+			[]float64{0.5, 15878.045666589831, 2500, 3829.370367173978},
 		},
 		{
 			seq,
@@ -71,9 +77,9 @@ var (
 		{
 			seq,
 			500, 3, 500,
-			[]cluster.Indices{{6, 7, 0, 5, 8, 1, 4, 9, 2, 3}},
+			[]cluster.Indices{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
 			1650000,
-			[]float64{1650000},
+			[]float64{1.6499999999999995e+06},
 		},
 	}
 )
@@ -81,10 +87,6 @@ var (
 // Tests
 func (s *S) TestMeanShift(c *check.C) {
 	for i, t := range tests {
-		// Note that though there does not appear to be any randomness in the approach used here, we use
-		// kdtree for storing data. The data are inserted on mass at the creation of the tree based on
-		// kdtree.MedianOfRandoms under the current implementation. So seed makes a difference.
-		rand.Seed(1)
 		c.Logf("Test %d: bandwidth = %.2f effort = %d", i, t.bandwidth, t.effort)
 		ms := meanshift.New(t.set, meanshift.NewTruncGauss(t.bandwidth, t.oversample), 0.1, t.effort)
 		err := ms.Cluster()
@@ -117,12 +119,13 @@ func (b bench) Len() int               { return len(b) }
 func (b bench) Values(i int) []float64 { return b[i][:] }
 
 var benchData bench = func() bench {
+	rnd := rand.New(rand.NewSource(1))
 	b := make(bench, 1000)
 	for i := 0; i < 20; i++ {
-		x, y := float64(rand.Intn(10000)), float64(rand.Intn(10000))
-		r := float64(rand.Intn(200))
+		x, y := float64(rnd.Intn(10000)), float64(rnd.Intn(10000))
+		r := float64(rnd.Intn(200))
 		for j := range b {
-			b[j] = [2]float64{x + r*rand.NormFloat64(), y + r*rand.NormFloat64()}
+			b[j] = [2]float64{x + r*rnd.NormFloat64(), y + r*rnd.NormFloat64()}
 		}
 	}
 	return b
@@ -147,3 +150,674 @@ func BenchmarkUniform(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkUniformBallTree(b *testing.B) {
+	s := meanshift.NewUniform(800)
+	s.SetIndex(spatial.NewBallTree(16))
+	for i := 0; i < b.N; i++ {
+		err := meanshift.New(benchData, s, 20, 5).Cluster()
+		if err != nil {
+			b.Log(err)
+		}
+	}
+}
+
+func BenchmarkUniformReset(b *testing.B) {
+	ms := meanshift.New(benchData, meanshift.NewUniform(800), 20, 5)
+	for i := 0; i < b.N; i++ {
+		ms.Reset(benchData)
+		err := ms.Cluster()
+		if err != nil {
+			b.Log(err)
+		}
+	}
+}
+
+func (s *S) TestManifest(c *check.C) {
+	ms := meanshift.New(Features(feats), meanshift.NewTruncGauss(60, 3), 0.1, 10)
+	c.Assert(ms.Cluster(), check.Equals, nil)
+	m := ms.Manifest()
+	c.Check(m.Algorithm, check.Equals, "meanshift")
+	c.Check(m.Parameters, check.DeepEquals, map[string]interface{}{
+		"kernel":      "*meanshift.TruncGauss",
+		"bandwidth":   60.,
+		"tol":         0.1,
+		"maxIter":     10,
+		"mergeRadius": math.Sqrt(60),
+		"centerMode":  "mean",
+	})
+	c.Check(m.Data, check.Equals, cluster.FingerprintOf(Features(feats)))
+	c.Check(m.Iterations > 0, check.Equals, true)
+
+	k := meanshift.NewUniform(0.5)
+	k.SetMergeRadius(0.25)
+	k.SetPeriods([]float64{360, 0})
+	k.SetBinSeeding(0.5, 2)
+	k.SetCenterMode(meanshift.MedianCenter)
+	ms = meanshift.New(bench{{10, 10}, {10.1, 10}, {359.9, 10}, {200, 20}}, k, 1e-6, 100)
+	c.Assert(ms.Cluster(), check.Equals, nil)
+	p := ms.Manifest().Parameters
+	c.Check(p["mergeRadius"], check.Equals, 0.25)
+	c.Check(p["periods"], check.DeepEquals, []float64{360, 0})
+	c.Check(p["binWidth"], check.Equals, 0.5)
+	c.Check(p["minBinCount"], check.Equals, 2)
+	c.Check(p["centerMode"], check.Equals, "median")
+}
+
+func (s *S) TestMedianCenter(c *check.C) {
+	var centers [2][]cluster.Center
+	for i, mode := range []meanshift.CenterMode{meanshift.MeanCenter, meanshift.MedianCenter} {
+		k := meanshift.NewTruncGauss(200, 3)
+		k.SetCenterMode(mode)
+		ms := meanshift.New(Features(feats), k, 0.1, 100)
+		c.Assert(ms.Cluster(), check.Equals, nil)
+		centers[i] = ms.Centers()
+	}
+	c.Assert(len(centers[1]), check.Equals, len(centers[0]))
+	var moved bool
+	for i, cen := range centers[1] {
+		c.Check(cen.Members(), check.DeepEquals, centers[0][i].Members())
+		if len(cen.Members()) == 1 {
+			c.Check(cen.V(), check.DeepEquals, centers[0][i].V())
+		}
+		for d, x := range cen.V() {
+			if x != centers[0][i].V()[d] {
+				moved = true
+			}
+		}
+	}
+	c.Check(moved, check.Equals, true)
+}
+
+func (s *S) TestPredict(c *check.C) {
+	ms := meanshift.New(Features(feats), meanshift.NewTruncGauss(200, 3), 0.1, 100)
+	n, d := ms.Predict([]float64{0, 0})
+	c.Check(n, check.Equals, -1)
+	c.Check(math.IsInf(d, 1), check.Equals, true)
+
+	c.Assert(ms.Cluster(), check.Equals, nil)
+	var pts bench
+	for i, cen := range ms.Centers() {
+		v := cen.V()
+		n, d := ms.Predict(v)
+		c.Check(n, check.Equals, i)
+		c.Check(d, check.Equals, 0.)
+		pts = append(pts, [2]float64{v[0] + 1, v[1]})
+	}
+	clusters, dists := ms.PredictAll(pts)
+	for i := range pts {
+		c.Check(clusters[i], check.Equals, i)
+		c.Check(dists[i], check.Equals, 1.)
+		c.Check(ms.Assign(pts.Values(i)), check.Equals, i)
+	}
+}
+
+func (s *S) TestDistances(c *check.C) {
+	ms := meanshift.New(Features(feats), meanshift.NewTruncGauss(200, 3), 0.1, 100)
+	c.Check(ms.Distances(), check.IsNil)
+	c.Check(ms.Assignments(), check.IsNil)
+	c.Assert(ms.Cluster(), check.Equals, nil)
+	d := ms.Distances()
+	c.Assert(len(d), check.Equals, len(feats))
+	within := make([]float64, len(ms.Centers()))
+	for i, v := range ms.Values() {
+		within[v.Cluster()] += d[i]
+	}
+	for i, w := range ms.Within() {
+		c.Check(math.Abs(within[i]-w) <= 1e-9*w, check.Equals, true)
+	}
+}
+
+func (s *S) TestStep(c *check.C) {
+	var centers [2][]cluster.Center
+	for i := range centers {
+		ms := meanshift.New(Features(feats), meanshift.NewTruncGauss(200, 3), 0.1, 100)
+		if i == 0 {
+			c.Assert(ms.Cluster(), check.Equals, nil)
+		} else {
+			for {
+				if _, done := ms.Step(); done {
+					break
+				}
+			}
+		}
+		centers[i] = ms.Centers()
+	}
+	c.Check(centers[1], check.DeepEquals, centers[0])
+}
+
+func (s *S) TestEpanechnikov(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	modes := [][2]float64{{0, 0}, {20, 0}, {0, 20}}
+	var pts bench
+	for i := 0; i < 300; i++ {
+		m := modes[i%len(modes)]
+		pts = append(pts, [2]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+	ms := meanshift.New(pts, meanshift.NewEpanechnikov(4), 1e-6, 100)
+	c.Assert(ms.Cluster(), check.Equals, nil)
+	cens := ms.Centers()
+	c.Assert(len(cens), check.Equals, len(modes))
+	for _, cen := range cens {
+		c.Check(len(cen.Members()), check.Equals, 100)
+		v := cen.V()
+		m := modes[cen.Members()[0]%len(modes)]
+		c.Check(math.Hypot(v[0]-m[0], v[1]-m[1]) < 0.5, check.Equals, true, check.Commentf("center at %v", v))
+	}
+	c.Check(ms.Manifest().Parameters["kernel"], check.Equals, "*meanshift.Epanechnikov")
+}
+
+func (s *S) TestGauss(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	modes := [][2]float64{{0, 0}, {20, 0}, {0, 20}}
+	var pts bench
+	for i := 0; i < 150; i++ {
+		m := modes[i%len(modes)]
+		pts = append(pts, [2]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+	ms := meanshift.New(pts, meanshift.NewGauss(2), 1e-6, 200)
+	c.Assert(ms.Cluster(), check.Equals, nil)
+	cens := ms.Centers()
+	c.Assert(len(cens), check.Equals, len(modes))
+	for _, cen := range cens {
+		c.Check(len(cen.Members()), check.Equals, 50)
+		v := cen.V()
+		m := modes[cen.Members()[0]%len(modes)]
+		c.Check(math.Hypot(v[0]-m[0], v[1]-m[1]) < 0.5, check.Equals, true, check.Commentf("center at %v", v))
+	}
+}
+
+func (s *S) TestEstimateBandwidth(c *check.C) {
+	var pts bench
+	for i := 0; i < 10; i++ {
+		pts = append(pts, [2]float64{float64(i), 0})
+	}
+	// Interior elements have a second neighbor at distance 1 and the two
+	// end elements at distance 2.
+	h, err := meanshift.EstimateBandwidth(pts, 0.2)
+	c.Assert(err, check.Equals, nil)
+	c.Check(h, check.Equals, 1.2)
+	h, err = meanshift.EstimateBandwidth(pts, 1)
+	c.Assert(err, check.Equals, nil)
+	c.Check(h, check.Equals, 7.)
+	_, err = meanshift.EstimateBandwidth(pts, 0)
+	c.Check(err, check.ErrorMatches, "meanshift: invalid quantile")
+	_, err = meanshift.EstimateBandwidth(pts[:1], 0.5)
+	c.Check(err, check.ErrorMatches, "meanshift: no data")
+
+	rnd := rand.New(rand.NewSource(1))
+	pts = pts[:0]
+	for i := 0; i < 10000; i++ {
+		pts = append(pts, [2]float64{rnd.NormFloat64(), rnd.NormFloat64()})
+	}
+	h, err = meanshift.Silverman(pts)
+	c.Assert(err, check.Equals, nil)
+	c.Check(math.Abs(h-math.Pow(1e4, -1./6)) < 0.02, check.Equals, true, check.Commentf("h=%v", h))
+	h, err = meanshift.Scott(pts)
+	c.Assert(err, check.Equals, nil)
+	c.Check(math.Abs(h-math.Pow(1e4, -1./6)) < 0.02, check.Equals, true, check.Commentf("h=%v", h))
+}
+
+func (s *S) TestSelect(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	modes := [][2]float64{{0, 0}, {20, 0}, {0, 20}}
+	var pts bench
+	for i := 0; i < 150; i++ {
+		m := modes[i%len(modes)]
+		pts = append(pts, [2]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+	bandwidths := []float64{0.01, 0.5, 5, 100}
+	h, scores, err := meanshift.Selector{Rand: rand.New(rand.NewSource(1))}.Select(pts, bandwidths)
+	c.Assert(err, check.Equals, nil)
+	c.Check(h, check.Equals, 5.)
+	c.Assert(len(scores), check.Equals, len(bandwidths))
+	_, again, err := meanshift.Selector{Rand: rand.New(rand.NewSource(1))}.Select(pts, bandwidths)
+	c.Assert(err, check.Equals, nil)
+	c.Check(again[1], check.Equals, scores[1])
+	c.Check(scores[2].Clusters, check.Equals, 3)
+	c.Check(scores[2].Stability, check.Equals, 1.)
+	c.Check(scores[1].Stability < 1, check.Equals, true)
+	// The smallest bandwidth places every value in its own cluster and the
+	// largest places all the values in one.
+	c.Check(scores[0].Clusters, check.Equals, len(pts))
+	c.Check(math.IsNaN(scores[0].Stability), check.Equals, true)
+	c.Check(scores[3].Clusters, check.Equals, 1)
+	c.Check(math.IsNaN(scores[3].Stability), check.Equals, true)
+
+	_, _, err = meanshift.Selector{}.Select(pts, []float64{100})
+	c.Check(err, check.ErrorMatches, "meanshift: no non-trivial clustering")
+}
+
+func (s *S) TestWorkers(c *check.C) {
+	var centers [2][]cluster.Center
+	for i, n := range []int{1, 4} {
+		k := meanshift.NewUniform(800)
+		k.SetWorkers(n)
+		ms := meanshift.New(benchData[:300], k, 20, 5)
+		ms.Cluster()
+		centers[i] = ms.Centers()
+	}
+	c.Check(centers[1], check.DeepEquals, centers[0])
+}
+
+func (s *S) TestFreeze(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	modes := [][2]float64{{0, 0}, {20, 0}, {0, 20}}
+	var pts bench
+	for i := 0; i < 300; i++ {
+		m := modes[i%len(modes)]
+		pts = append(pts, [2]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+
+	k := meanshift.NewUniform(4)
+	k.SetFreeze(math.Inf(1))
+	k.Init(pts)
+	c.Check(k.Shift() > 0, check.Equals, true)
+	c.Check(k.Shift(), check.Equals, 0.)
+
+	var members [2][]cluster.Indices
+	for i, tol := range []float64{0, 1e-4} {
+		k := meanshift.NewUniform(4)
+		k.SetFreeze(tol)
+		ms := meanshift.New(pts, k, 1e-8, 100)
+		c.Assert(ms.Cluster(), check.Equals, nil)
+		for _, cen := range ms.Centers() {
+			m := append(cluster.Indices(nil), cen.Members()...)
+			sort.Ints(m)
+			members[i] = append(members[i], m)
+		}
+	}
+	c.Check(len(members[0]), check.Equals, len(modes))
+	c.Check(members[1], check.DeepEquals, members[0])
+}
+
+func (s *S) TestBinSeeding(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	modes := [][2]float64{{0, 0}, {20, 0}, {0, 20}}
+	var pts bench
+	for i := 0; i < 3000; i++ {
+		m := modes[i%len(modes)]
+		pts = append(pts, [2]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+	for _, mode := range []meanshift.CenterMode{meanshift.MeanCenter, meanshift.MedianCenter} {
+		k := meanshift.NewUniform(4)
+		k.SetBinSeeding(4, 5)
+		k.SetCenterMode(mode)
+		ms := meanshift.New(pts, k, 1e-8, 100)
+		c.Assert(ms.Cluster(), check.Equals, nil)
+		cens := ms.Centers()
+		c.Assert(len(cens), check.Equals, len(modes))
+		for _, cen := range cens {
+			c.Check(len(cen.Members()), check.Equals, 1000)
+			v := cen.V()
+			m := modes[cen.Members()[0]%len(modes)]
+			c.Check(math.Hypot(v[0]-m[0], v[1]-m[1]) < 0.5, check.Equals, true, check.Commentf("center at %v", v))
+		}
+		for i, v := range ms.Values() {
+			c.Check(cens[v.Cluster()].Members()[0]%len(modes), check.Equals, i%len(modes))
+		}
+	}
+}
+
+func (s *S) TestDensity(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var pts bench
+	for i := 0; i < 300; i++ {
+		// Two thirds of the values are in the mode at the origin.
+		m := [2]float64{20 * float64(i%3/2), 0}
+		pts = append(pts, [2]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+	for _, k := range []meanshift.Shifter{
+		meanshift.NewUniform(4),
+		meanshift.NewTruncGauss(2, 4),
+		meanshift.NewGauss(2),
+		meanshift.NewEpanechnikov(4),
+	} {
+		ms := meanshift.New(pts, k, 1e-8, 200)
+		c.Assert(ms.Cluster(), check.Equals, nil)
+		cens := ms.Centers()
+		c.Assert(len(cens), check.Equals, 2, check.Commentf("%T", k))
+		var dens [2]float64
+		for _, cen := range cens {
+			m := cen.(meanshift.Mode)
+			c.Check(m.Density() > 0, check.Equals, true)
+			dens[int(math.Round(m.V()[0]/20))] = m.Density()
+		}
+		c.Check(dens[0] > 1.5*dens[1], check.Equals, true, check.Commentf("%T densities %v", k, dens))
+	}
+}
+
+func (s *S) TestMaxIterations(c *check.C) {
+	ms := meanshift.New(benchData[:300], meanshift.NewGauss(800), -1, 2)
+	err := ms.Cluster()
+	c.Check(err, check.Equals, meanshift.ErrMaxIterations)
+	c.Check(errors.Is(err, cluster.ErrMaxIterations), check.Equals, true)
+	c.Check(ms.Manifest().Iterations, check.Equals, 2)
+	var n int
+	for _, cen := range ms.Centers() {
+		n += len(cen.Members())
+	}
+	c.Check(n, check.Equals, 300)
+}
+
+func (s *S) TestMergeRadius(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	modes := [][2]float64{{0, 0}, {20, 0}, {0, 20}}
+	var pts bench
+	for i := 0; i < 300; i++ {
+		m := modes[i%len(modes)]
+		pts = append(pts, [2]float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+	for _, t := range []struct {
+		r float64
+		k int
+	}{
+		{r: 0, k: 3},
+		{r: 15, k: 3},
+		{r: 30, k: 1},
+	} {
+		k := meanshift.NewUniform(4)
+		k.SetMergeRadius(t.r)
+		ms := meanshift.New(pts, k, 1e-8, 100)
+		c.Assert(ms.Cluster(), check.Equals, nil)
+		c.Check(len(ms.Centers()), check.Equals, t.k, check.Commentf("merge radius %v", t.r))
+	}
+}
+
+type weighted struct {
+	bench
+	w []float64
+}
+
+func (d weighted) Weight(i int) float64 { return d.w[i] }
+
+func (s *S) TestWeights(c *check.C) {
+	pts := weighted{
+		bench: bench{{0, 0}, {1, 0}, {20, 0}, {21, 0}},
+		w:     []float64{1, 3, 2, 2},
+	}
+	ms := meanshift.New(pts, meanshift.NewUniform(4), 1e-8, 100)
+	c.Assert(ms.Cluster(), check.Equals, nil)
+	cens := ms.Centers()
+	c.Assert(len(cens), check.Equals, 2)
+	weights := make(map[float64]float64)
+	for _, cen := range cens {
+		weights[math.Round(cen.V()[0])] = cen.(meanshift.Mode).Weight()
+	}
+	c.Check(weights, check.DeepEquals, map[float64]float64{1: 4, 21: 4})
+	c.Check(ms.Within(), check.DeepEquals, []float64{0.75, 1})
+	// The weighted mean is at x=10.625.
+	c.Check(ms.Total(), check.Equals, 1*10.625*10.625+3*9.625*9.625+2*9.375*9.375+2*10.375*10.375)
+}
+
+func (s *S) TestInsert(c *check.C) {
+	pts := bench{{0, 0}, {1, 0}, {0, 1}, {20, 0}, {21, 0}, {20, 1}}
+	for _, k := range []meanshift.Shifter{
+		meanshift.NewUniform(3),
+		meanshift.NewEpanechnikov(3),
+	} {
+		ms := meanshift.New(pts, k, 1e-8, 100)
+		c.Check(ms.Insert(bench{{0.5, 0.5}}), check.ErrorMatches, "meanshift: no centers")
+		c.Assert(ms.Cluster(), check.Equals, nil)
+		c.Assert(len(ms.Centers()), check.Equals, 2)
+		near, _ := ms.Predict([]float64{20, 0})
+
+		c.Check(ms.Insert(bench{{21, 1}, {100, 100}}), check.Equals, nil)
+		vals := ms.Values()
+		c.Assert(len(vals), check.Equals, 8)
+		c.Check(vals[6].Cluster(), check.Equals, near)
+		c.Check(vals[7].Cluster(), check.Equals, 2)
+
+		cens := ms.Centers()
+		c.Assert(len(cens), check.Equals, 3)
+		c.Check(cens[near].Members(), check.HasLen, 4)
+		c.Check(cens[near].(meanshift.Mode).Weight(), check.Equals, 4.)
+		c.Check(cens[2].Members(), check.DeepEquals, cluster.Indices{7})
+
+		// Reclustering discards the inserted elements.
+		c.Assert(ms.Cluster(), check.Equals, nil)
+		c.Check(len(ms.Values()), check.Equals, 6)
+	}
+}
+
+// built is a spatial.Index that must not be rebuilt.
+type built struct{ spatial.Index }
+
+func (built) Build(cluster.Interface) { panic("index rebuilt") }
+
+type prebuilt interface {
+	meanshift.Shifter
+	SetBuiltIndex(spatial.Index)
+}
+
+func (s *S) TestBuiltIndex(c *check.C) {
+	pts := bench{{0, 0}, {1, 0}, {0, 1}, {20, 0}, {21, 0}, {20, 1}}
+	kd := spatial.NewKDTree()
+	kd.Build(pts)
+	for _, h := range []float64{3, 30} {
+		for _, k := range []func() prebuilt{
+			func() prebuilt { return meanshift.NewUniform(h) },
+			func() prebuilt { return meanshift.NewEpanechnikov(h) },
+			func() prebuilt { return meanshift.NewTruncGauss(h, 3) },
+		} {
+			want := meanshift.New(pts, k(), 1e-8, 100)
+			c.Assert(want.Cluster(), check.Equals, nil)
+
+			sh := k()
+			sh.SetBuiltIndex(built{kd})
+			got := meanshift.New(pts, sh, 1e-8, 100)
+			c.Assert(got.Cluster(), check.Equals, nil)
+			c.Check(len(got.Centers()), check.Equals, len(want.Centers()))
+			for i, v := range got.Values() {
+				c.Check(v.Cluster(), check.Equals, want.Values()[i].Cluster())
+			}
+		}
+	}
+}
+
+func (s *S) TestReset(c *check.C) {
+	sets := []bench{
+		{{0, 0}, {1, 0}, {0, 1}, {20, 0}, {21, 0}, {20, 1}},
+		{{5, 5}, {6, 5}},
+		{{0, 0}, {1, 1}, {10, 10}, {11, 11}, {20, 20}, {21, 21}, {30, 30}},
+	}
+	ms := meanshift.New(sets[0], meanshift.NewUniform(3), 1e-8, 100)
+	for _, pts := range sets {
+		ms.Reset(pts)
+		c.Check(ms.Centers(), check.HasLen, 0)
+		c.Assert(ms.Cluster(), check.Equals, nil)
+
+		want := meanshift.New(pts, meanshift.NewUniform(3), 1e-8, 100)
+		c.Assert(want.Cluster(), check.Equals, nil)
+		c.Check(ms.Manifest(), check.DeepEquals, want.Manifest())
+		c.Check(len(ms.Centers()), check.Equals, len(want.Centers()))
+		c.Assert(len(ms.Values()), check.Equals, len(pts))
+		for i, v := range ms.Values() {
+			c.Check(v.V(), check.DeepEquals, pts[i][:])
+			c.Check(v.Cluster(), check.Equals, want.Values()[i].Cluster())
+		}
+		c.Check(ms.Within(), check.DeepEquals, want.Within())
+	}
+}
+
+func (s *S) TestNoise(c *check.C) {
+	pts := bench{{0, 0}, {1, 0}, {2, 0}, {1, 1}, {1, -1}, {4.5, 0}, {20, 0}, {21, 0}, {20, 1}}
+	ms := meanshift.New(pts, meanshift.NewUniform(3), 1e-8, 100)
+	ms.SetNoiseRadius(2)
+	c.Assert(ms.Cluster(), check.Equals, nil)
+	cens := ms.Centers()
+	c.Assert(cens, check.HasLen, 2)
+	vals := ms.Values()
+	c.Check(vals[5].Cluster(), check.Equals, meanshift.Noise)
+	a := ms.Assignments()
+	c.Check(a[5], check.Equals, meanshift.Noise)
+	var n int
+	for i, cen := range cens {
+		for _, j := range cen.Members() {
+			c.Check(vals[j].Cluster(), check.Equals, i)
+			c.Check(a[j], check.Equals, i)
+			n++
+		}
+	}
+	c.Check(n, check.Equals, len(pts)-1)
+	c.Check(math.IsNaN(ms.Distances()[5]), check.Equals, true)
+	var p cluster.Predictor = ms
+	c.Check(p.Assign(pts[5][:]), check.Equals, meanshift.Noise)
+	c.Check(p.Assign(pts[6][:]), check.Equals, vals[6].Cluster())
+	c.Check(ms.Manifest().Parameters["noise"], check.Equals, 2.)
+
+	want := meanshift.New(pts, meanshift.NewUniform(3), 1e-8, 100)
+	c.Assert(want.Cluster(), check.Equals, nil)
+	within, all := ms.Within(), want.Within()
+	var got, sum float64
+	for i := range within {
+		got += within[i]
+		sum += all[i]
+	}
+	c.Check(got < sum, check.Equals, true)
+
+	c.Check(ms.Insert(bench{{10, 10}, {1, 0.5}}), check.Equals, nil)
+	vals = ms.Values()
+	c.Check(vals[9].Cluster(), check.Equals, meanshift.Noise)
+	c.Check(vals[10].Cluster(), check.Equals, vals[0].Cluster())
+}
+
+type periodicShifter interface {
+	meanshift.Shifter
+	SetPeriods([]float64)
+	SetCenterMode(meanshift.CenterMode)
+}
+
+func (s *S) TestPeriods(c *check.C) {
+	pts := bench{{355, 0}, {358, 1}, {2, 0}, {5, 1}, {178, 0}, {180, 1}, {182, 0}}
+	for _, mode := range []meanshift.CenterMode{meanshift.MeanCenter, meanshift.MedianCenter} {
+		for _, k := range []func() periodicShifter{
+			func() periodicShifter { return meanshift.NewUniform(10) },
+			func() periodicShifter { return meanshift.NewEpanechnikov(10) },
+			func() periodicShifter { return meanshift.NewGauss(5) },
+		} {
+			ms := meanshift.New(pts, k(), 1e-8, 100)
+			c.Assert(ms.Cluster(), check.Equals, nil)
+			c.Check(len(ms.Centers()) > 2, check.Equals, true)
+
+			sh := k()
+			sh.SetPeriods([]float64{360})
+			sh.SetCenterMode(mode)
+			ms = meanshift.New(pts, sh, 1e-8, 100)
+			c.Assert(ms.Cluster(), check.Equals, nil)
+			cens := ms.Centers()
+			c.Assert(cens, check.HasLen, 2)
+			vals := ms.Values()
+			for i := 1; i < 4; i++ {
+				c.Check(vals[i].Cluster(), check.Equals, vals[0].Cluster())
+			}
+			for i := 5; i < 7; i++ {
+				c.Check(vals[i].Cluster(), check.Equals, vals[4].Cluster())
+			}
+			x := cens[vals[0].Cluster()].V()[0]
+			c.Check(x >= 0 && x < 360, check.Equals, true)
+			c.Check(math.Min(x, 360-x) < 1, check.Equals, true, check.Commentf("center at %v", x))
+			c.Check(math.Abs(cens[vals[4].Cluster()].V()[0]-180) < 1, check.Equals, true)
+
+			n, d := ms.Predict([]float64{359, 0.5})
+			c.Check(n, check.Equals, vals[0].Cluster())
+			c.Check(d < 4, check.Equals, true)
+			for _, d := range ms.Distances() {
+				c.Check(d < 100, check.Equals, true)
+			}
+		}
+	}
+}
+
+func (s *S) TestSerialize(c *check.C) {
+	pts := bench{{0, 0}, {1, 0}, {2, 0}, {1, 1}, {1, -1}, {4.5, 0}, {20, 0}, {21, 0}, {20, 1}}
+	ms := meanshift.New(pts, meanshift.NewUniform(3), 1e-8, 100)
+	ms.SetNoiseRadius(2)
+	_, err := json.Marshal(ms)
+	c.Check(err, check.ErrorMatches, ".*meanshift: no centers")
+	c.Assert(ms.Cluster(), check.Equals, nil)
+
+	b, err := json.Marshal(ms)
+	c.Assert(err, check.Equals, nil)
+	var fromJSON meanshift.MeanShift
+	c.Assert(json.Unmarshal(b, &fromJSON), check.Equals, nil)
+
+	var buf bytes.Buffer
+	c.Assert(gob.NewEncoder(&buf).Encode(ms), check.Equals, nil)
+	var fromGob meanshift.MeanShift
+	c.Assert(gob.NewDecoder(&buf).Decode(&fromGob), check.Equals, nil)
+
+	queries := [][]float64{{0.5, 0.5}, {19, 0}, {10, 0}, {4, 0}}
+	for _, got := range []*meanshift.MeanShift{&fromJSON, &fromGob} {
+		c.Check(got.Assignments(), check.DeepEquals, ms.Assignments())
+		c.Assert(len(got.Centers()), check.Equals, len(ms.Centers()))
+		for i, cen := range got.Centers() {
+			want := ms.Centers()[i]
+			c.Check(cen.V(), check.DeepEquals, want.V())
+			m := append(cluster.Indices(nil), want.Members()...)
+			sort.Ints(m)
+			c.Check(cen.Members(), check.DeepEquals, m)
+			c.Check(cen.(meanshift.Mode).Weight(), check.Equals, want.(meanshift.Mode).Weight())
+			c.Check(cen.(meanshift.Mode).Density(), check.Equals, want.(meanshift.Mode).Density())
+		}
+		for _, q := range queries {
+			gc, gd := got.Predict(q)
+			wc, wd := ms.Predict(q)
+			c.Check(gc, check.Equals, wc)
+			c.Check(gd, check.Equals, wd)
+			c.Check(got.Assign(q), check.Equals, ms.Assign(q))
+		}
+		c.Check(got.Manifest(), check.DeepEquals, ms.Manifest())
+		c.Check(got.Insert(bench{{0, 0}}), check.ErrorMatches, "meanshift: shifter does not support insertion")
+	}
+	c.Check(ms.Manifest().Parameters["kernel"], check.Equals, "*meanshift.Uniform")
+
+	c.Check(json.Unmarshal([]byte(`{"Centers":[[0]],"Weights":[1],"Assignments":[1]}`), &fromJSON), check.ErrorMatches, "meanshift: invalid assignment")
+}
+
+func (s *S) TestOptions(c *check.C) {
+	var centers [2][]cluster.Center
+	for i, n := range []int{1, 4} {
+		k := meanshift.NewUniform(800)
+		ms := meanshift.New(benchData[:300], k, 0, 0, meanshift.WithTolerance(20), meanshift.WithMaxIter(5), meanshift.WithWorkers(n))
+		p := ms.Manifest().Parameters
+		c.Check(p["tol"], check.Equals, 20.)
+		c.Check(p["maxIter"], check.Equals, 5)
+		ms.Cluster()
+		centers[i] = ms.Centers()
+	}
+	c.Check(centers[1], check.DeepEquals, centers[0])
+
+	pts := bench{{0, 0}, {1, 0}, {2, 0}, {1, 1}, {1, -1}, {4.5, 0}, {20, 0}, {21, 0}, {20, 1}}
+	ms := meanshift.New(pts, meanshift.NewUniform(3), 1e-8, 100, meanshift.WithNoiseRadius(2))
+	c.Assert(ms.Cluster(), check.Equals, nil)
+	c.Check(ms.Values()[5].Cluster(), check.Equals, meanshift.Noise)
+}
+
+// countdown is a context that is cancelled after n calls to Err.
+type countdown struct {
+	context.Context
+	n int
+}
+
+func (c *countdown) Err() error {
+	if c.n == 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func (s *S) TestClusterContext(c *check.C) {
+	ms := meanshift.New(benchData[:300], meanshift.NewUniform(800), 0, 100)
+	var cc cluster.ContextClusterer = ms
+	err := cc.ClusterContext(&countdown{Context: context.Background(), n: 2})
+	c.Check(err, check.Equals, context.Canceled)
+	c.Check(ms.Manifest().Iterations, check.Equals, 2)
+	c.Check(len(ms.Centers()) > 0, check.Equals, true)
+	var n int
+	for _, cen := range ms.Centers() {
+		n += len(cen.Members())
+	}
+	c.Check(n, check.Equals, 300)
+}
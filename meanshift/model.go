@@ -0,0 +1,189 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package meanshift
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// model is the serialized form of a fitted MeanShift.
+type model struct {
+	Kernel      string
+	Bandwidth   float64
+	MergeRadius float64
+	CenterMode  string
+	BinWidth    float64
+	MinBinCount int
+
+	Tol     float64
+	MaxIter int
+	Noise   float64
+	Periods []float64
+
+	Centers [][]float64
+	Weights []float64
+
+	// Densities is nil if the density of any center is NaN,
+	// since NaN can not be represented in JSON.
+	Densities []float64
+
+	Assignments []int
+	Data        cluster.Fingerprint
+	Iterations  int
+}
+
+func (ms *MeanShift) model() (model, error) {
+	if ms.centers == nil {
+		return model{}, &cluster.Error{Pkg: "meanshift", Err: cluster.ErrNoCenters}
+	}
+	d := ms.describe()
+	m := model{
+		Kernel:      d.kernel,
+		Bandwidth:   d.bandwidth,
+		MergeRadius: d.merge,
+		CenterMode:  d.mode,
+		BinWidth:    d.bin,
+		MinBinCount: d.minBin,
+		Tol:         ms.tol,
+		MaxIter:     ms.maxIter,
+		Noise:       ms.noise,
+		Periods:     ms.periods,
+		Centers:     make([][]float64, len(ms.centers)),
+		Weights:     make([]float64, len(ms.centers)),
+		Densities:   make([]float64, len(ms.centers)),
+		Assignments: make([]int, len(ms.values)),
+		Data:        ms.data,
+		Iterations:  ms.iter,
+	}
+	for i, c := range ms.centers {
+		m.Centers[i] = c.pnt
+		m.Weights[i] = c.w
+		m.Densities[i] = c.density
+	}
+	for _, c := range ms.centers {
+		if math.IsNaN(c.density) {
+			m.Densities = nil
+			break
+		}
+	}
+	for i, v := range ms.values {
+		m.Assignments[i] = v.cluster
+	}
+	return m, nil
+}
+
+func (ms *MeanShift) setModel(m model) error {
+	if len(m.Weights) != len(m.Centers) || (m.Densities != nil && len(m.Densities) != len(m.Centers)) {
+		return errors.New("meanshift: mismatched center data")
+	}
+	var dims int
+	if len(m.Centers) != 0 {
+		dims = len(m.Centers[0])
+	}
+	centers := make([]center, len(m.Centers))
+	for i, c := range m.Centers {
+		if len(c) != dims {
+			return &cluster.Error{Pkg: "meanshift", Err: cluster.ErrDimensionMismatch}
+		}
+		centers[i] = center{pnt: c, w: m.Weights[i], density: math.NaN()}
+		if m.Densities != nil {
+			centers[i].density = m.Densities[i]
+		}
+	}
+	values := make([]value, len(m.Assignments))
+	for i, c := range m.Assignments {
+		if c != Noise && (c < 0 || c >= len(centers)) {
+			return errors.New("meanshift: invalid assignment")
+		}
+		values[i].cluster = c
+		if c != Noise {
+			centers[c].indices = append(centers[c].indices, i)
+		}
+	}
+	ci := make([]cluster.Indices, len(centers))
+	for i, c := range centers {
+		ci[i] = c.indices
+	}
+	*ms = MeanShift{
+		desc: description{
+			kernel:    m.Kernel,
+			bandwidth: m.Bandwidth,
+			merge:     m.MergeRadius,
+			mode:      m.CenterMode,
+			bin:       m.BinWidth,
+			minBin:    m.MinBinCount,
+		},
+		tol:     m.Tol,
+		maxIter: m.MaxIter,
+		values:  values,
+		n:       len(values),
+		centers: centers,
+		ci:      ci,
+		noise:   m.Noise,
+		periods: m.Periods,
+		data:    m.Data,
+		iter:    m.Iterations,
+	}
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the fitted model held by ms: its centers
+// with their weights and densities, the assignments of the data, the noise radius
+// and periods, and the information recorded in its Manifest, including the type,
+// bandwidth, merge radius, center mode and bin seeding of the Shifter. The data
+// values and the state of the Shifter are not included. MarshalJSON returns an
+// error if Cluster has not been called.
+func (ms *MeanShift) MarshalJSON() ([]byte, error) {
+	m, err := ms.model()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON replaces the state of ms with the fitted model encoded in b by
+// MarshalJSON. The restored MeanShift has no Shifter and holds no data values, so
+// it may be used to Predict and Assign new points and to report its Centers,
+// Assignments and Manifest, but it can not be clustered, Insert returns an error
+// and the Values it returns have no coordinates. The members of each restored
+// center are in increasing order.
+func (ms *MeanShift) UnmarshalJSON(b []byte) error {
+	var m model
+	err := json.Unmarshal(b, &m)
+	if err != nil {
+		return fmt.Errorf("meanshift: %w", err)
+	}
+	return ms.setModel(m)
+}
+
+// GobEncode returns the gob encoding of the fitted model held by ms as described
+// for MarshalJSON.
+func (ms *MeanShift) GobEncode() ([]byte, error) {
+	m, err := ms.model()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = gob.NewEncoder(&buf).Encode(m)
+	return buf.Bytes(), err
+}
+
+// GobDecode replaces the state of ms with the fitted model encoded in b by
+// GobEncode as described for UnmarshalJSON.
+func (ms *MeanShift) GobDecode(b []byte) error {
+	var m model
+	err := gob.NewDecoder(bytes.NewReader(b)).Decode(&m)
+	if err != nil {
+		return fmt.Errorf("meanshift: %w", err)
+	}
+	return ms.setModel(m)
+}
@@ -0,0 +1,42 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package meanshift
+
+// Option is a functional option for New.
+//
+// Clustering by mean shift makes no random choices and its kernels are defined by
+// Euclidean distance, so there are no equivalents of the kmeans WithSeed and
+// WithDivergence options. The subsamples drawn by a Selector are controlled by its
+// Rand field.
+type Option func(*MeanShift)
+
+// WithMaxIter returns an Option that sets the iteration limit of Cluster, as
+// described for Cluster.
+func WithMaxIter(n int) Option {
+	return func(ms *MeanShift) { ms.maxIter = n }
+}
+
+// WithTolerance returns an Option that sets the sum of squares shift of the centers
+// at or below which an iteration is considered converged, as described for Step.
+func WithTolerance(tol float64) Option {
+	return func(ms *MeanShift) { ms.tol = tol }
+}
+
+// WithWorkers returns an Option that sets the number of goroutines used to shift
+// centers if the Shifter provides a SetWorkers method, as the Shifters in this
+// package do. Otherwise the option has no effect.
+func WithWorkers(n int) Option {
+	return func(ms *MeanShift) {
+		if w, ok := ms.k.(interface{ SetWorkers(int) }); ok {
+			w.SetWorkers(n)
+		}
+	}
+}
+
+// WithNoiseRadius returns an Option that sets the noise radius, as described for
+// SetNoiseRadius.
+func WithNoiseRadius(r float64) Option {
+	return func(ms *MeanShift) { ms.noise = r }
+}
@@ -0,0 +1,165 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package meanshift
+
+import (
+	"github.com/biogo/cluster/spatial"
+
+	"math"
+	"sort"
+)
+
+// SetPeriods sets the period of each dimension of the data. Dimensions with a
+// positive period are treated as circular, so a datum at x is equivalent to one
+// at x+period, and distances and means are calculated across the origin. This is
+// suitable for angular data and for positions on circular replicons. Dimensions
+// with a period that is not positive, including those beyond the length of
+// periods, are not periodic. The reported centers are reduced to [0, period) in
+// periodic dimensions. The bandwidth should be less than half of each period.
+// If a built index is provided, it must hold the data reduced to [0, period).
+// SetPeriods must be called before the Shifter is initialised.
+func (s *shifter) SetPeriods(periods []float64) {
+	s.periods = nil
+	for _, t := range periods {
+		if t > 0 {
+			s.periods = append([]float64(nil), periods...)
+			break
+		}
+	}
+}
+
+// Periods returns the periods set by SetPeriods, or nil if no dimension is
+// periodic.
+func (s *shifter) Periods() []float64 { return s.periods }
+
+// periodic is a Shifter that may have periodic dimensions.
+type periodic interface {
+	Periods() []float64
+}
+
+// periodsOf returns the periods of k, or nil if k has no periodic dimensions.
+func periodsOf(k Shifter) []float64 {
+	if p, ok := k.(periodic); ok {
+		return p.Periods()
+	}
+	return nil
+}
+
+// period returns the period of dimension j, or zero if j is not periodic.
+func period(periods []float64, j int) float64 {
+	if j < len(periods) {
+		return periods[j]
+	}
+	return 0
+}
+
+// wrap returns the difference d reduced to [-t/2, t/2]. If t is not positive, d
+// is returned unaltered.
+func wrap(d, t float64) float64 {
+	if t <= 0 {
+		return d
+	}
+	return d - t*math.Round(d/t)
+}
+
+// reduce reduces the periodic coordinates of p to [0, period) in place.
+func reduce(p []float64, periods []float64) {
+	for j := range p {
+		if t := period(periods, j); t > 0 {
+			p[j] = math.Mod(p[j], t)
+			if p[j] < 0 {
+				p[j] += t
+			}
+		}
+	}
+}
+
+// periodicSqDist returns the squared distance between a and b taking the shortest
+// path in periodic dimensions.
+func periodicSqDist(a, b []float64, periods []float64) float64 {
+	var sum float64
+	for j, v := range a {
+		d := wrap(v-b[j], period(periods, j))
+		sum += d * d
+	}
+	return sum
+}
+
+// sqDist returns the squared distance between a and b under the topology of the
+// shifter's data.
+func (s *shifter) sqDist(a, b []float64) float64 {
+	if s.periods == nil {
+		return sqDist(a, b)
+	}
+	return periodicSqDist(a, b, s.periods)
+}
+
+// rangeSet appends the data within r of q to dst. In periodic dimensions the
+// index is also queried at the images of q across the origin, and each datum is
+// reported once at its shortest distance from q. Results are not ordered by
+// distance.
+func (s *shifter) rangeSet(dst []spatial.Neighbor, q []float64, r float64) []spatial.Neighbor {
+	return rangeSet(s.index, s.periods, dst, q, r)
+}
+
+// rangeSet appends the points held by idx within r of q to dst, querying the
+// images of q across the origin in dimensions with a positive period.
+func rangeSet(idx spatial.Index, periods []float64, dst []spatial.Neighbor, q []float64, r float64) []spatial.Neighbor {
+	if periods == nil {
+		return idx.RangeSet(dst, q, r)
+	}
+
+	n := len(dst)
+	img := append([]float64(nil), q...)
+	reduce(img, periods)
+	var query func(j int)
+	query = func(j int) {
+		if j == len(img) {
+			dst = idx.RangeSet(dst, img, r)
+			return
+		}
+		query(j + 1)
+		t := period(periods, j)
+		if t <= 0 {
+			return
+		}
+		x := img[j]
+		if x-r < 0 {
+			img[j] = x + t
+			query(j + 1)
+		}
+		if x+r >= t {
+			img[j] = x - t
+			query(j + 1)
+		}
+		img[j] = x
+	}
+	query(0)
+
+	hits := dst[n:]
+	sort.Sort(byIndex(hits))
+	u := hits[:0]
+	for i, h := range hits {
+		if i != 0 && h.Index == u[len(u)-1].Index {
+			if h.Dist < u[len(u)-1].Dist {
+				u[len(u)-1] = h
+			}
+			continue
+		}
+		u = append(u, h)
+	}
+	return dst[:n+len(u)]
+}
+
+type byIndex []spatial.Neighbor
+
+func (h byIndex) Len() int { return len(h) }
+func (h byIndex) Less(i, j int) bool {
+	if h[i].Index != h[j].Index {
+		return h[i].Index < h[j].Index
+	}
+	return h[i].Dist < h[j].Dist
+}
+func (h byIndex) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
@@ -0,0 +1,84 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package meanshift
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// SetBinSeeding arranges for the shifter to start a trajectory from each cell of
+// a grid of the given width that holds at least minCount data, rather than from
+// every datum. Each trajectory starts at the weighted mean of the data in its cell
+// and carries their total weight when MedianCenter is used. After convergence every
+// datum is assigned to its nearest mode. A width of about the bandwidth greatly reduces the number of
+// trajectories for large data sets while preserving the modes. If no cell holds
+// minCount data, every datum is used. If width is not positive, bin seeding is
+// disabled. SetBinSeeding must be called before the Shifter is initialised.
+func (s *shifter) SetBinSeeding(width float64, minCount int) {
+	s.bin = width
+	s.minBin = minCount
+}
+
+// binSeeds returns the seed locations and weights for bin seeding, or nil if no
+// cell holds at least minBin data.
+func (s *shifter) binSeeds() (seeds [][]float64, weights []float64) {
+	var counts []int
+	dims := len(s.points[0])
+	cells := make(map[string]int)
+	key := make([]byte, 8*dims)
+	for i, p := range s.points {
+		for d, x := range p {
+			binary.LittleEndian.PutUint64(key[8*d:], uint64(int64(math.Floor(x/s.bin))))
+		}
+		c, ok := cells[string(key)]
+		if !ok {
+			c = len(seeds)
+			cells[string(key)] = c
+			seeds = append(seeds, make([]float64, dims))
+			weights = append(weights, 0)
+			counts = append(counts, 0)
+		}
+		w := s.weights[i]
+		for d, x := range p {
+			seeds[c][d] += x * w
+		}
+		weights[c] += w
+		counts[c]++
+	}
+
+	n := 0
+	for c, p := range seeds {
+		if counts[c] < s.minBin || weights[c] == 0 {
+			continue
+		}
+		for d := range p {
+			p[d] /= weights[c]
+		}
+		seeds[n], weights[n] = p, weights[c]
+		n++
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	return seeds[:n], weights[:n]
+}
+
+// nearestModes replaces the members of the centers in cen, which hold seed
+// indices, with the indices of the data nearest to each center.
+func (s *shifter) nearestModes(cen []*center) {
+	for _, c := range cen {
+		c.indices = nil
+	}
+	for i, p := range s.points {
+		n, min := 0, math.Inf(1)
+		for j, c := range cen {
+			if d := s.sqDist(p, c.pnt); d < min {
+				n, min = j, d
+			}
+		}
+		cen[n].indices = append(cen[n].indices, i)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package meanshift
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// Selector selects a mean shift bandwidth by the stability of the clusterings it
+// gives under subsampling. The zero value is usable.
+type Selector struct {
+	// Kernel returns a Shifter with bandwidth h. If Kernel is nil,
+	// NewUniform is used.
+	Kernel func(h float64) Shifter
+
+	// Tol and MaxIter are the tolerance and maximum number of iterations
+	// used for each clustering. If Tol is zero, a value of 1e-6·h² is used
+	// and if MaxIter is zero, a value of 100 is used.
+	Tol     float64
+	MaxIter int
+
+	// Subsamples is the number of subsamples clustered for each bandwidth
+	// and Fraction is the fraction of the data included in each subsample.
+	// If Subsamples is zero, a value of 10 is used and if Fraction is zero, a
+	// value of 0.8 is used.
+	Subsamples int
+	Fraction   float64
+
+	// Rand is the source of randomness used to draw subsamples. If Rand
+	// is nil, the math/rand default source is used.
+	Rand *rand.Rand
+}
+
+// Score is the result of evaluating a bandwidth.
+type Score struct {
+	Bandwidth float64 // Bandwidth is the bandwidth evaluated.
+	Clusters  int     // Clusters is the number of clusters found in the complete data.
+
+	// Stability is the mean adjusted Rand index between the clustering of
+	// each subsample and the clustering of the complete data restricted to
+	// the subsample. Stability is NaN if the clustering of the complete data
+	// failed to converge or is trivial, placing all the data in a single
+	// cluster or each datum in its own cluster.
+	Stability float64
+}
+
+// Select clusters data using each of the bandwidths and returns the bandwidth with
+// the most stable non-trivial clustering, and the Score for each bandwidth in the
+// order given. Ties are broken in favor of the larger bandwidth. Subsamples are
+// drawn using s.Rand, so a Selector with a seeded Rand gives reproducible Scores.
+// Select returns an error if no bandwidth gives a non-trivial clustering.
+func (s Selector) Select(data cluster.Interface, bandwidths []float64) (float64, []Score, error) {
+	if s.Kernel == nil {
+		s.Kernel = func(h float64) Shifter { return NewUniform(h) }
+	}
+	if s.MaxIter == 0 {
+		s.MaxIter = 100
+	}
+	if s.Subsamples == 0 {
+		s.Subsamples = 10
+	}
+	if s.Fraction == 0 {
+		s.Fraction = 0.8
+	}
+	perm := rand.Perm
+	if s.Rand != nil {
+		perm = s.Rand.Perm
+	}
+	if !(s.Fraction > 0 && s.Fraction <= 1) {
+		return 0, nil, errors.New("meanshift: invalid subsample fraction")
+	}
+	n := data.Len()
+	m := int(s.Fraction * float64(n))
+	if m < 2 {
+		return 0, nil, &cluster.Error{Pkg: "meanshift", Err: cluster.ErrEmptyData}
+	}
+
+	var (
+		best   float64
+		stable = math.Inf(-1)
+		scores = make([]Score, len(bandwidths))
+	)
+	for i, h := range bandwidths {
+		scores[i] = Score{Bandwidth: h, Stability: math.NaN()}
+		full, err := s.labels(data, h)
+		if err != nil {
+			continue
+		}
+		k := 0
+		for _, l := range full {
+			if l >= k {
+				k = l + 1
+			}
+		}
+		scores[i].Clusters = k
+		if k < 2 || k == n {
+			continue
+		}
+
+		var sum float64
+		restrict := make([]int, m)
+		for j := 0; j < s.Subsamples; j++ {
+			sub := subset{data: data, idx: perm(n)[:m]}
+			l, err := s.labels(sub, h)
+			if err != nil {
+				// A subsample that fails to converge is scored as
+				// independent of the complete clustering.
+				continue
+			}
+			for p, q := range sub.idx {
+				restrict[p] = full[q]
+			}
+			sum += adjustedRand(l, restrict)
+		}
+		scores[i].Stability = sum / float64(s.Subsamples)
+		if scores[i].Stability > stable || (scores[i].Stability == stable && h > best) {
+			best, stable = h, scores[i].Stability
+		}
+	}
+	if math.IsInf(stable, -1) {
+		return 0, scores, errors.New("meanshift: no non-trivial clustering")
+	}
+	return best, scores, nil
+}
+
+// labels returns the cluster labels of data clustered with bandwidth h.
+func (s Selector) labels(data cluster.Interface, h float64) ([]int, error) {
+	tol := s.Tol
+	if tol == 0 {
+		tol = 1e-6 * h * h
+	}
+	ms := New(data, s.Kernel(h), tol, s.MaxIter)
+	err := ms.Cluster()
+	if err != nil {
+		return nil, err
+	}
+	l := make([]int, len(ms.values))
+	for i, v := range ms.values {
+		l[i] = v.cluster
+	}
+	return l, nil
+}
+
+// subset is a cluster.Interface holding the elements of data with the given indices.
+type subset struct {
+	data cluster.Interface
+	idx  []int
+}
+
+func (s subset) Len() int               { return len(s.idx) }
+func (s subset) Values(i int) []float64 { return s.data.Values(s.idx[i]) }
+func (s subset) Weight(i int) float64 {
+	if w, ok := s.data.(cluster.Weighter); ok {
+		return w.Weight(s.idx[i])
+	}
+	return 1
+}
+
+// adjustedRand returns the adjusted Rand index between the partitions given by the
+// labels a and b. If the index is undefined because both partitions are trivial in
+// the same way, adjustedRand returns 1.
+func adjustedRand(a, b []int) float64 {
+	type pair struct{ a, b int }
+	var (
+		nij = make(map[pair]int)
+		ai  = make(map[int]int)
+		bj  = make(map[int]int)
+	)
+	for i := range a {
+		nij[pair{a[i], b[i]}]++
+		ai[a[i]]++
+		bj[b[i]]++
+	}
+	comb2 := func(n int) float64 { return float64(n) * float64(n-1) / 2 }
+	var index, sa, sb float64
+	for _, n := range nij {
+		index += comb2(n)
+	}
+	for _, n := range ai {
+		sa += comb2(n)
+	}
+	for _, n := range bj {
+		sb += comb2(n)
+	}
+	expected := sa * sb / comb2(len(a))
+	max := (sa + sb) / 2
+	if max == expected {
+		return 1
+	}
+	return (index - expected) / (max - expected)
+}
@@ -6,243 +6,675 @@ package meanshift
 
 import (
 	"github.com/biogo/cluster/cluster"
-	"github.com/biogo/store/kdtree"
+	"github.com/biogo/cluster/spatial"
 
 	"math"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
 )
 
-// shiftPoint is a weighted point which carries group identity and membership information.
-// shiftPoint satisfies the kdtree.Comparable interface.
-type shiftPoint struct {
-	Point   []float64
-	Weight  float64
-	ID      int
-	Members []int
-}
-
-func (p *shiftPoint) Clone() kdtree.Comparable {
-	return &shiftPoint{Point: append(kdtree.Point(nil), p.Point...), Weight: p.Weight}
-}
-func (p *shiftPoint) Compare(c kdtree.Comparable, d kdtree.Dim) float64 {
-	q := c.(*shiftPoint)
-	return p.Point[d] - q.Point[d]
-}
-func (p *shiftPoint) Dims() int { return len(p.Point) }
-func (p *shiftPoint) Distance(c kdtree.Comparable) float64 {
-	q := c.(*shiftPoint)
+// sqDist returns the squared Euclidean distance between a and b.
+func sqDist(a, b []float64) float64 {
 	var sum float64
-	for dim, c := range p.Point {
-		d := c - q.Point[dim]
+	for dim, c := range a {
+		d := c - b[dim]
 		sum += d * d
 	}
 	return sum
 }
 
-// shiftPoints is a collection of shiftPoint values that satisfies the kdtree.Interface.
-type shiftPoints []*shiftPoint
+// rows is a cluster.Interface over a slice of points.
+type rows [][]float64
 
-func (p shiftPoints) Index(i int) kdtree.Comparable         { return p[i] }
-func (p shiftPoints) Len() int                              { return len(p) }
-func (p shiftPoints) Pivot(d kdtree.Dim) int                { return plane{shiftPoints: p, Dim: d}.Pivot() }
-func (p shiftPoints) Slice(start, end int) kdtree.Interface { return p[start:end] }
-func (p shiftPoints) Values(i int) []float64                { return p[i].Point }
+func (r rows) Len() int               { return len(r) }
+func (r rows) Values(i int) []float64 { return r[i] }
 
-// plane wraps a shiftPoints type allowing it to be pivoted on a dimension.
-type plane struct {
-	kdtree.Dim
-	shiftPoints
-}
+// shifter holds the data and neighbor search state shared by the kernel Shifters.
+type shifter struct {
+	index   spatial.Index
+	built   bool
+	points  [][]float64
+	weights []float64
+	traj    rows      // traj holds views of the trajectories in coords.
+	flat    []float64 // flat holds the coordinates of the data.
+	coords  []float64 // coords holds the coordinates of the trajectories.
+	mode    CenterMode
 
-func (p plane) Less(i, j int) bool {
-	return p.shiftPoints[i].Point[p.Dim] < p.shiftPoints[j].Point[p.Dim]
-}
-func (p plane) Pivot() int { return kdtree.Partition(p, kdtree.MedianOfRandoms(p, kdtree.Randoms)) }
-func (p plane) Slice(start, end int) kdtree.SortSlicer {
-	p.shiftPoints = p.shiftPoints[start:end]
-	return p
+	workers int
+	scratch []*scratch
+	delta   []float64
+
+	freeze float64
+	frozen []bool
+
+	merge float64
+
+	periods []float64
+
+	bin    float64
+	minBin int
+	seeded bool
+	cw     []float64
+
+	collation *spatial.KDTree
 }
-func (p plane) Swap(i, j int) { p.shiftPoints[i], p.shiftPoints[j] = p.shiftPoints[j], p.shiftPoints[i] }
 
-type Uniform struct {
-	centers []*shiftPoint
-	cn      []float64
-	tree    *kdtree.Tree
-	hits    *kdtree.DistKeeper
+// scratch holds the per-worker state used to shift a center.
+type scratch struct {
+	hits []spatial.Neighbor
+	cn   []float64
+	div  float64
+
+	// at is the location of the center being shifted,
+	// used to find the nearest images of data in
+	// periodic dimensions.
+	at      []float64
+	periods []float64
 }
 
-func NewUniform(h float64) *Uniform {
-	return &Uniform{
-		hits: kdtree.NewDistKeeper(h * h),
+// add adds the datum p with kernel weight k to the weighted sum held by w.
+func (w *scratch) add(p []float64, k float64) {
+	w.div += k
+	for j, v := range p {
+		if t := period(w.periods, j); t > 0 {
+			v = w.at[j] + wrap(v-w.at[j], t)
+		}
+		w.cn[j] += v * k
 	}
 }
 
-func (s *Uniform) Init(data cluster.Interface) {
+// init initialises the shifter with the provided data, building the spatial index
+// over the data unless a built index has been provided. If no index has been set,
+// a kd-tree is used. The coordinates of the data and of the trajectories are each
+// held in a single contiguous array, and storage held from a previous
+// initialisation is reused.
+func (s *shifter) init(data cluster.Interface) {
 	w, isWeighter := data.(cluster.Weighter)
 
-	s.centers = make([]*shiftPoint, data.Len())
-	vals := make(shiftPoints, data.Len())
-
-	for i := 0; i < data.Len(); i++ {
-		s.centers[i] = &shiftPoint{ID: i}
-		s.centers[i].Point = append([]float64(nil), data.Values(i)...)
-		v := &shiftPoint{Point: data.Values(i)}
+	n := data.Len()
+	var dims int
+	if n != 0 {
+		dims = len(data.Values(0))
+	}
+	s.flat = resize(s.flat, n*dims)
+	s.coords = resize(s.coords, n*dims)
+	s.traj = s.traj[:0]
+	s.points = s.points[:0]
+	s.weights = s.weights[:0]
+
+	for i := 0; i < n; i++ {
+		p := s.flat[i*dims : (i+1)*dims : (i+1)*dims]
+		copy(p, data.Values(i))
+		reduce(p, s.periods)
+		s.points = append(s.points, p)
 		if isWeighter {
-			v.Weight = w.Weight(i)
+			s.weights = append(s.weights, w.Weight(i))
 		} else {
-			v.Weight = 1
+			s.weights = append(s.weights, 1)
+		}
+
+		t := s.coords[i*dims : (i+1)*dims : (i+1)*dims]
+		copy(t, p)
+		s.traj = append(s.traj, t)
+	}
+
+	s.cw = s.weights
+	s.seeded = false
+	if s.bin > 0 && n != 0 {
+		seeds, weights := s.binSeeds()
+		if seeds != nil {
+			// There are never more seeds than data, so the
+			// seeds are held in the leading trajectories.
+			s.traj = s.traj[:len(seeds)]
+			for i, p := range seeds {
+				copy(s.traj[i], p)
+			}
+			s.cw = weights
+			s.seeded = true
 		}
-		vals[i] = v
 	}
 
-	s.tree = kdtree.New(vals, false)
-	s.cn = make([]float64, len(s.centers[0].Point))
+	if s.index == nil {
+		s.index = spatial.NewKDTree()
+	}
+	if !s.built {
+		s.index.Build(rows(s.points))
+	}
+	if len(s.scratch) != 0 && len(s.traj) != 0 && len(s.scratch[0].cn) != len(s.traj[0]) {
+		s.scratch = nil
+	}
+	for _, w := range s.scratch {
+		w.periods = s.periods
+	}
+	s.delta = append(s.delta[:0], make([]float64, len(s.traj))...)
+	s.frozen = append(s.frozen[:0], make([]bool, len(s.traj))...)
+}
+
+// resize returns x resized to length n, reusing its storage if possible.
+func resize(x []float64, n int) []float64 {
+	if cap(x) < n {
+		return make([]float64, n)
+	}
+	return x[:n]
+}
+
+// SetWorkers sets the number of goroutines used to shift centers. If n is less
+// than one, the value of runtime.GOMAXPROCS is used. The result of a shift does not
+// depend on the number of workers.
+func (s *shifter) SetWorkers(n int) { s.workers = n }
+
+// SetFreeze sets the squared distance moved by a center in an iteration at or below
+// which the center is frozen and is not shifted in later iterations. Since the data
+// do not move, a center that does not move at all is at a fixed point and is always
+// frozen; a positive tol additionally freezes centers that have nearly converged,
+// reducing the cost of later iterations at the expense of exactness.
+func (s *shifter) SetFreeze(tol float64) { s.freeze = tol }
+
+// SetMergeRadius sets the distance within which converged trajectories are merged
+// into a single mode. If r is not positive, a radius derived from the bandwidth is
+// used: the bandwidth for Uniform, Gauss and Epanechnikov, and the square root of
+// the bandwidth for TruncGauss.
+func (s *shifter) SetMergeRadius(r float64) { s.merge = r }
+
+// mergeRadius returns the squared merge radius, or def if no merge radius has been
+// set.
+func (s *shifter) mergeRadius(def float64) float64 {
+	if s.merge > 0 {
+		return s.merge * s.merge
+	}
+	return def
 }
 
-func (s *Uniform) Bandwidth() float64 { return s.hits.Heap[len(s.hits.Heap)-1].Dist }
+// block is the number of centers claimed at a time by a shift worker.
+const block = 64
+
+// shift moves each center to the weighted mean of the data added to the worker's
+// scratch by fn, and returns the sum of squares differences between the initial
+// and final locations of the centers. A center is not moved if fn adds no weight,
+// and frozen centers are skipped.
+// Centers are shifted concurrently, so fn must not alter shared state.
+func (s *shifter) shift(fn func(w *scratch, c []float64)) (delta float64) {
+	workers := s.workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if max := (len(s.traj) + block - 1) / block; workers > max {
+		workers = max
+	}
+	for len(s.scratch) < workers {
+		s.scratch = append(s.scratch, &scratch{cn: make([]float64, len(s.traj[0])), periods: s.periods})
+	}
 
-func (s *Uniform) Shift() (delta float64) {
-	for i, c := range s.centers {
-		s.tree.NearestSet(s.hits, c)
+	work := func(w *scratch, from, to int) {
+		for i := from; i < to; i++ {
+			if s.frozen[i] {
+				s.delta[i] = 0
+				continue
+			}
+			c := s.traj[i]
+			w.at = c
+			fn(w, c)
+			var d float64
+			if w.div != 0 {
+				for j := range w.cn {
+					w.cn[j] /= w.div
+					d += (c[j] - w.cn[j]) * (c[j] - w.cn[j])
+				}
+				reduce(w.cn, s.periods)
+				copy(c, w.cn)
+			}
+			s.delta[i] = d
+			s.frozen[i] = d <= s.freeze
 
-		div := 0.
-		for _, hit := range s.hits.Heap[:len(s.hits.Heap)-1] {
-			h := hit.Comparable.(*shiftPoint)
-			div += h.Weight
-			for j := range s.cn {
-				s.cn[j] += h.Point[j] * h.Weight
+			for j := range w.cn {
+				w.cn[j] = 0
 			}
+			w.div = 0
 		}
-		for j := range s.cn {
-			s.cn[j] /= div
-			delta += (c.Point[j] - s.cn[j]) * (c.Point[j] - s.cn[j])
+	}
+	if workers <= 1 {
+		work(s.scratch[0], 0, len(s.traj))
+	} else {
+		var (
+			wg   sync.WaitGroup
+			next int64 = -block
+		)
+		for _, w := range s.scratch[:workers] {
+			wg.Add(1)
+			go func(w *scratch) {
+				defer wg.Done()
+				for {
+					from := int(atomic.AddInt64(&next, block))
+					if from >= len(s.traj) {
+						return
+					}
+					to := from + block
+					if to > len(s.traj) {
+						to = len(s.traj)
+					}
+					work(w, from, to)
+				}
+			}(w)
 		}
-		copy(s.centers[i].Point, s.cn)
+		wg.Wait()
+	}
 
-		for j := range s.cn {
-			s.cn[j] = 0
+	for _, d := range s.delta {
+		delta += d
+	}
+	return delta
+}
+
+// SetIndex sets the spatial index used for neighbor searches by the shifter. It
+// must be called before the Shifter is initialised.
+func (s *shifter) SetIndex(idx spatial.Index) { s.index, s.built = idx, false }
+
+// SetBuiltIndex sets a spatial index that has already been built over the data
+// the Shifter will be initialised with. The index is not rebuilt by Init, so a
+// single index may be shared by Shifters clustering the same data, for example
+// when trying a range of bandwidths. Since queries on the indexes provided by the
+// spatial package are safe for concurrent use, the Shifters may be run
+// concurrently. It must be called before the Shifter is initialised.
+func (s *shifter) SetBuiltIndex(idx spatial.Index) { s.index, s.built = idx, true }
+
+// CenterMode specifies how the location of a mode is determined from the shifted
+// points that are merged into it.
+type CenterMode int
+
+const (
+	// MeanCenter locates a mode at the unweighted mean of its merged points.
+	MeanCenter CenterMode = iota
+
+	// MedianCenter locates a mode at the component-wise median of its merged
+	// points, weighted by the weights of the data they originated from. The
+	// median is robust to points that had not fully converged to the mode.
+	MedianCenter
+)
+
+// centerModes holds the names of the center modes recorded in a manifest.
+var centerModes = [...]string{MeanCenter: "mean", MedianCenter: "median"}
+
+// SetCenterMode sets the method used to locate the reported cluster centers.
+// The default is MeanCenter.
+func (s *shifter) SetCenterMode(m CenterMode) { s.mode = m }
+
+// describer is a Shifter that can describe its center mode and bin seeding.
+type describer interface {
+	describe(d *description)
+}
+
+// describe sets the center mode and bin seeding parameters of d from s.
+func (s *shifter) describe(d *description) {
+	d.mode = centerModes[s.mode]
+	if s.bin > 0 {
+		d.bin, d.minBin = s.bin, s.minBin
+	}
+}
+
+// locate relocates the centers in cen according to the shifter's CenterMode. If
+// the trajectories were bin seeded, the members of each center are then replaced
+// by the data nearest to it. The density at each center is calculated by density.
+func (s *shifter) locate(cen []cluster.Center, density func(p []float64) float64) []cluster.Center {
+	if s.mode == MedianCenter {
+		for _, c := range cen {
+			c := c.(*center)
+			m := c.indices
+			p := make(pnt, len(c.pnt))
+			vals := make([]weighted, len(m))
+			for d := range p {
+				t := period(s.periods, d)
+				for k, i := range m {
+					v := s.traj[i][d]
+					if t > 0 {
+						v = c.pnt[d] + wrap(v-c.pnt[d], t)
+					}
+					vals[k] = weighted{v: v, w: s.cw[i]}
+				}
+				p[d] = weightedMedian(vals)
+			}
+			reduce(p, s.periods)
+			c.pnt = p
 		}
-		s.hits.Heap[0] = kdtree.ComparableDist{Comparable: nil, Dist: s.hits.Heap[len(s.hits.Heap)-1].Dist}
-		s.hits.Heap = s.hits.Heap[:1]
 	}
+	if s.seeded {
+		cs := make([]*center, len(cen))
+		for i, c := range cen {
+			cs[i] = c.(*center)
+		}
+		s.nearestModes(cs)
+	}
+	for _, c := range cen {
+		c := c.(*center)
+		c.density = density(c.pnt)
+	}
+	return cen
+}
 
-	return delta
+// rangeDensity returns the sum of the weights of the data within r of p, each
+// multiplied by kernel of its squared distance from p.
+func (s *shifter) rangeDensity(p []float64, r float64, kernel func(d2 float64) float64) float64 {
+	var sum float64
+	for _, hit := range s.rangeSet(nil, p, r) {
+		sum += s.weights[hit.Index] * kernel(s.sqDist(s.points[hit.Index], p))
+	}
+	return sum
+}
+
+// ascend shifts p in place using the kernel weighting of fn until the squared
+// distance moved in an iteration is no greater than tol or maxIter iterations have
+// been performed, and returns the density at the final location of p. Only the
+// data the shifter was initialised with contribute to the shift.
+func (s *shifter) ascend(p []float64, tol float64, maxIter int, fn func(w *scratch, c []float64), density func(p []float64) float64) float64 {
+	w := &scratch{cn: make([]float64, len(p)), at: p, periods: s.periods}
+	for i := 0; i < maxIter; i++ {
+		fn(w, p)
+		if w.div == 0 {
+			break
+		}
+		var d float64
+		for j := range w.cn {
+			w.cn[j] /= w.div
+			d += (p[j] - w.cn[j]) * (p[j] - w.cn[j])
+		}
+		reduce(w.cn, s.periods)
+		copy(p, w.cn)
+		for j := range w.cn {
+			w.cn[j] = 0
+		}
+		w.div = 0
+		if d <= tol {
+			break
+		}
+	}
+	return density(p)
+}
+
+// weighted is a weighted scalar value.
+type weighted struct {
+	v, w float64
+}
+
+type byValue []weighted
+
+func (v byValue) Len() int           { return len(v) }
+func (v byValue) Less(i, j int) bool { return v[i].v < v[j].v }
+func (v byValue) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+
+// weightedMedian returns the weighted median of vals. The order of elements
+// in vals is altered.
+func weightedMedian(vals []weighted) float64 {
+	sort.Sort(byValue(vals))
+	var total float64
+	for _, v := range vals {
+		total += v.w
+	}
+	half := total / 2
+	var sum float64
+	for i, v := range vals {
+		sum += v.w
+		if sum > half {
+			return v.v
+		}
+		if sum == half && i+1 < len(vals) {
+			return (v.v + vals[i+1].v) / 2
+		}
+	}
+	return vals[len(vals)-1].v
 }
 
+// Uniform is a Shifter using a flat kernel.
+type Uniform struct {
+	h float64
+	shifter
+}
+
+// NewUniform returns a new Uniform Shifter with bandwidth h.
+func NewUniform(h float64) *Uniform {
+	return &Uniform{h: h}
+}
+
+// Init initialises the Shifter with the provided data.
+func (s *Uniform) Init(data cluster.Interface) { s.init(data) }
+
+// Bandwidth returns the bandwidth parameter of the Shifter.
+func (s *Uniform) Bandwidth() float64 { return s.h }
+
+// Shift performs a single iteration of the mean shift algorithm.
+func (s *Uniform) Shift() (delta float64) { return s.shift(s.gather) }
+
+// gather adds the data within the kernel of c to w.
+func (s *Uniform) gather(w *scratch, c []float64) {
+	w.hits = s.rangeSet(w.hits[:0], c, s.h)
+	for _, hit := range w.hits {
+		w.add(s.points[hit.Index], s.weights[hit.Index])
+	}
+}
+
+// density returns the kernel density sum at p.
+func (s *Uniform) density(p []float64) float64 {
+	return s.rangeDensity(p, s.h, func(float64) float64 { return 1 })
+}
+
+// Ascend shifts p toward a mode of the kernel density estimate of the data.
+func (s *Uniform) Ascend(p []float64, tol float64, maxIter int) float64 {
+	return s.ascend(p, tol, maxIter, s.gather, s.density)
+}
+
+// MergeRadius returns the distance within which shifted points are merged.
+func (s *Uniform) MergeRadius() float64 { return math.Sqrt(s.mergeRadius(s.h * s.h)) }
+
+// Centers returns the cluster centers of the clustered data.
 func (s *Uniform) Centers() []cluster.Center {
-	return collate(shiftPoints(s.centers), s.Bandwidth())
+	return s.locate(s.collate(s.mergeRadius(s.h*s.h)), s.density)
 }
 
+// TruncGauss is a Shifter using a truncated Gaussian kernel.
 type TruncGauss struct {
-	h       float64
-	centers []*shiftPoint
-	cn      []float64
-	tree    *kdtree.Tree
-	hits    *kdtree.DistKeeper
+	h, r float64
+	shifter
 }
 
+// NewTruncGauss returns a new TruncGauss Shifter with bandwidth h. The kernel is
+// truncated at a squared distance of h²·oversample.
 func NewTruncGauss(h, oversample float64) *TruncGauss {
 	return &TruncGauss{
-		h:    h,
-		hits: kdtree.NewDistKeeper(h * h * oversample),
+		h: h,
+		r: math.Sqrt(h * h * oversample),
 	}
 }
 
-func (s *TruncGauss) Init(data cluster.Interface) {
-	w, isWeighter := data.(cluster.Weighter)
+// Init initialises the Shifter with the provided data.
+func (s *TruncGauss) Init(data cluster.Interface) { s.init(data) }
 
-	s.centers = make([]*shiftPoint, data.Len())
-	vals := make(shiftPoints, data.Len())
+// Bandwidth returns the bandwidth parameter of the Shifter.
+func (s *TruncGauss) Bandwidth() float64 { return s.h }
 
-	for i := 0; i < data.Len(); i++ {
-		s.centers[i] = &shiftPoint{ID: i}
-		s.centers[i].Point = append([]float64(nil), data.Values(i)...)
-		v := &shiftPoint{Point: data.Values(i)}
-		if isWeighter {
-			v.Weight = w.Weight(i)
-		} else {
-			v.Weight = 1
-		}
-		vals[i] = v
+// Shift performs a single iteration of the mean shift algorithm.
+func (s *TruncGauss) Shift() (delta float64) { return s.shift(s.gather) }
+
+// gather adds the data within the kernel of c to w.
+func (s *TruncGauss) gather(w *scratch, c []float64) {
+	inv := 1 / (2 * s.h * s.h)
+	w.hits = s.rangeSet(w.hits[:0], c, s.r)
+	for _, hit := range w.hits {
+		p := s.points[hit.Index]
+		w.add(p, s.weights[hit.Index]*math.Exp(-s.sqDist(p, c)*inv))
 	}
+}
 
-	s.tree = kdtree.New(vals, false)
-	s.cn = make([]float64, len(s.centers[0].Point))
+// density returns the kernel density sum at p.
+func (s *TruncGauss) density(p []float64) float64 {
+	inv := 1 / (2 * s.h * s.h)
+	return s.rangeDensity(p, s.r, func(d2 float64) float64 { return math.Exp(-d2 * inv) })
 }
 
-func (s *TruncGauss) Bandwidth() float64 { return s.h }
+// Ascend shifts p toward a mode of the kernel density estimate of the data.
+func (s *TruncGauss) Ascend(p []float64, tol float64, maxIter int) float64 {
+	return s.ascend(p, tol, maxIter, s.gather, s.density)
+}
+
+// MergeRadius returns the distance within which shifted points are merged.
+func (s *TruncGauss) MergeRadius() float64 { return math.Sqrt(s.mergeRadius(s.h)) }
+
+// Centers returns the cluster centers of the clustered data.
+func (s *TruncGauss) Centers() []cluster.Center {
+	return s.locate(s.collate(s.mergeRadius(s.h)), s.density)
+}
+
+// Gauss is a Shifter using an untruncated Gaussian kernel. Every datum contributes
+// to the shift of every center, so each iteration takes O(n²) time, but the shift
+// is free of the bias introduced by truncating the kernel.
+type Gauss struct {
+	h float64
+	shifter
+}
+
+// NewGauss returns a new Gauss Shifter with bandwidth h.
+func NewGauss(h float64) *Gauss {
+	return &Gauss{h: h}
+}
+
+// Init initialises the Shifter with the provided data.
+func (s *Gauss) Init(data cluster.Interface) { s.init(data) }
+
+// Bandwidth returns the bandwidth parameter of the Shifter.
+func (s *Gauss) Bandwidth() float64 { return s.h }
 
-func (s *TruncGauss) Shift() (delta float64) {
+// Shift performs a single iteration of the mean shift algorithm.
+func (s *Gauss) Shift() (delta float64) { return s.shift(s.gather) }
+
+// gather adds every datum to w, weighted by the kernel about c.
+func (s *Gauss) gather(w *scratch, c []float64) {
 	inv := 1 / (2 * s.h * s.h)
-	for i, c := range s.centers {
-		s.tree.NearestSet(s.hits, c)
-
-		div := 0.
-		for _, hit := range s.hits.Heap[:len(s.hits.Heap)-1] {
-			h := hit.Comparable.(*shiftPoint)
-			kfn := h.Weight * math.Exp(hit.Comparable.Distance(c)*inv)
-			div += kfn
-			for j := range s.cn {
-				s.cn[j] += h.Point[j] * kfn
-			}
-		}
-		for j := range s.cn {
-			s.cn[j] /= div
-			delta += (c.Point[j] - s.cn[j]) * (c.Point[j] - s.cn[j])
-		}
-		copy(s.centers[i].Point, s.cn)
+	// Kernel values are taken relative to the nearest datum to
+	// avoid underflow far from the data.
+	min := math.Inf(1)
+	for _, p := range s.points {
+		min = math.Min(min, s.sqDist(p, c))
+	}
+	for k, p := range s.points {
+		w.add(p, s.weights[k]*math.Exp((min-s.sqDist(p, c))*inv))
+	}
+}
 
-		for j := range s.cn {
-			s.cn[j] = 0
-		}
-		s.hits.Heap[0] = kdtree.ComparableDist{Comparable: nil, Dist: s.hits.Heap[len(s.hits.Heap)-1].Dist}
-		s.hits.Heap = s.hits.Heap[:1]
+// density returns the kernel density sum at p.
+func (s *Gauss) density(p []float64) float64 {
+	inv := 1 / (2 * s.h * s.h)
+	var sum float64
+	for k, q := range s.points {
+		sum += s.weights[k] * math.Exp(-s.sqDist(q, p)*inv)
 	}
+	return sum
+}
 
-	return delta
+// Ascend shifts p toward a mode of the kernel density estimate of the data.
+func (s *Gauss) Ascend(p []float64, tol float64, maxIter int) float64 {
+	return s.ascend(p, tol, maxIter, s.gather, s.density)
 }
 
-func (s *TruncGauss) Centers() []cluster.Center {
-	return collate(shiftPoints(s.centers), s.Bandwidth())
+// MergeRadius returns the distance within which shifted points are merged.
+func (s *Gauss) MergeRadius() float64 { return math.Sqrt(s.mergeRadius(s.h * s.h)) }
+
+// Centers returns the cluster centers of the clustered data.
+func (s *Gauss) Centers() []cluster.Center {
+	return s.locate(s.collate(s.mergeRadius(s.h*s.h)), s.density)
+}
+
+// Epanechnikov is a Shifter using an Epanechnikov kernel, 1-d²/h² for data at a
+// distance d within the bandwidth h of a center. The mean shift is weighted by the
+// negative derivative of the kernel profile, which is constant, so each center is
+// moved to the mean of the data within the bandwidth, and the reported densities
+// are those of the Epanechnikov kernel density estimate. The kernel has finite
+// support without truncation.
+type Epanechnikov struct {
+	h float64
+	shifter
 }
 
-func collate(kc kdtree.Interface, h float64) []cluster.Center {
+// NewEpanechnikov returns a new Epanechnikov Shifter with bandwidth h.
+func NewEpanechnikov(h float64) *Epanechnikov {
+	return &Epanechnikov{h: h}
+}
+
+// Init initialises the Shifter with the provided data.
+func (s *Epanechnikov) Init(data cluster.Interface) { s.init(data) }
+
+// Bandwidth returns the bandwidth parameter of the Shifter.
+func (s *Epanechnikov) Bandwidth() float64 { return s.h }
+
+// Shift performs a single iteration of the mean shift algorithm.
+func (s *Epanechnikov) Shift() (delta float64) { return s.shift(s.gather) }
+
+// gather adds the data within the kernel of c to w.
+func (s *Epanechnikov) gather(w *scratch, c []float64) {
+	w.hits = s.rangeSet(w.hits[:0], c, s.h)
+	for _, hit := range w.hits {
+		w.add(s.points[hit.Index], s.weights[hit.Index])
+	}
+}
+
+// density returns the kernel density sum at p.
+func (s *Epanechnikov) density(p []float64) float64 {
+	inv := 1 / (s.h * s.h)
+	return s.rangeDensity(p, s.h, func(d2 float64) float64 { return math.Max(1-d2*inv, 0) })
+}
+
+// Ascend shifts p toward a mode of the kernel density estimate of the data.
+func (s *Epanechnikov) Ascend(p []float64, tol float64, maxIter int) float64 {
+	return s.ascend(p, tol, maxIter, s.gather, s.density)
+}
+
+// MergeRadius returns the distance within which shifted points are merged.
+func (s *Epanechnikov) MergeRadius() float64 { return math.Sqrt(s.mergeRadius(s.h * s.h)) }
+
+// Centers returns the cluster centers of the clustered data.
+func (s *Epanechnikov) Centers() []cluster.Center {
+	return s.locate(s.collate(s.mergeRadius(s.h*s.h)), s.density)
+}
+
+// collate merges the shifted centers into modes using the squared merge radius h.
+// Trajectories are visited in order, and those within the merge radius of each that
+// have not yet been assigned form a new mode located at the mean of all of the
+// trajectories within the radius. Modes are reported in the order they are found,
+// and their members in ascending order.
+func (s *shifter) collate(h float64) []cluster.Center {
+	traj := s.traj
+	if s.collation == nil {
+		s.collation = spatial.NewKDTree()
+	}
+	s.collation.Build(traj)
+
 	var (
-		ct        = kdtree.New(kc, false)
-		neighbors = kdtree.NewDistKeeper(h)
-		centers   kdtree.Tree
+		r    = math.Sqrt(h)
+		used = make([]bool, len(traj))
+		hits []spatial.Neighbor
+		cen  []cluster.Center
 	)
-	for i := 0; i < kc.Len(); i++ {
-		ct.NearestSet(neighbors, kc.Index(i))
-
-		wp := &shiftPoint{Point: make(kdtree.Point, kc.Index(0).Dims())}
-		for _, c := range neighbors.Heap[:len(neighbors.Heap)-1] {
-			p := c.Comparable.(*shiftPoint)
-			if p.ID >= 0 {
-				wp.Members = append(wp.Members, p.ID)
-				p.ID = -1
-			}
-			for j := range wp.Point {
-				wp.Point[j] += p.Point[j] / float64(len(neighbors.Heap)-1)
+	for _, q := range traj {
+		hits = rangeSet(s.collation, s.periods, hits[:0], q, r)
+
+		var members cluster.Indices
+		for _, hit := range hits {
+			if !used[hit.Index] {
+				members = append(members, hit.Index)
+				used[hit.Index] = true
 			}
 		}
-
-		if _, d := centers.Nearest(wp); d != 0 {
-			centers.Insert(wp, false)
+		if len(members) == 0 {
+			continue
 		}
+		sort.Ints(members)
 
-		neighbors.Heap[0] = kdtree.ComparableDist{Comparable: nil, Dist: h}
-		neighbors.Heap = neighbors.Heap[:1]
-	}
-
-	cen := make([]cluster.Center, 0, centers.Len())
-	centers.Do(func(c kdtree.Comparable, _ *kdtree.Bounding, _ int) (done bool) {
-		p := c.(*shiftPoint)
-		if len(p.Members) == 0 {
-			return
+		p := make(pnt, len(q))
+		for _, hit := range hits {
+			for j, v := range traj[hit.Index] {
+				p[j] += (q[j] + wrap(v-q[j], period(s.periods, j))) / float64(len(hits))
+			}
 		}
-		cen = append(cen, &center{pnt: p.Point, indices: p.Members})
-		return
-	})
-
+		reduce(p, s.periods)
+		cen = append(cen, &center{pnt: p, indices: members})
+	}
 	return cen
 }
@@ -0,0 +1,350 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package minibatch implements mini-batch k-means clustering for large ℝⁿ data sets.
+//
+// Reference:
+//
+//	Sculley D. Web-scale k-means clustering. Proceedings of the 19th International
+//	Conference on World Wide Web 1177-1178 (2010).
+package minibatch
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"context"
+	"errors"
+	"math/rand"
+	"unsafe"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	p32     []float32 // p32 holds the value's coordinates if the data are stored as float32.
+	w       float64
+	cluster int
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+// V returns the coordinates of v. If the data are stored as float32, a new slice
+// holding the coordinates converted to float64 is returned.
+func (v *value) V() []float64 {
+	if v.p32 == nil {
+		return v.point
+	}
+	return v.coords(nil)
+}
+
+// coords returns the coordinates of v. If the data are stored as float32, the
+// converted coordinates are written to buf, which is grown if necessary, and the
+// result is returned. Otherwise the stored coordinates are returned and buf is
+// not used.
+func (v *value) coords(buf []float64) []float64 {
+	if v.p32 == nil {
+		return v.point
+	}
+	if cap(buf) < len(v.p32) {
+		buf = make([]float64, len(v.p32))
+	}
+	buf = buf[:len(v.p32)]
+	for i, x := range v.p32 {
+		buf[i] = float64(x)
+	}
+	return buf
+}
+
+type center struct {
+	point
+	w       float64 // w is the total weight of values used to update the center.
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// Kmeans implements clustering of ℝⁿ data using mini-batch k-means. Each iteration
+// assigns a random batch of values to their nearest center and moves each center
+// towards its assigned values with a per-center learning rate that decreases as
+// the weight of values assigned to the center in all batches grows.
+type Kmeans struct {
+	dims    int
+	batch   int
+	maxIter int
+	tol     float64
+	values  []value
+	means   []center
+	rnd     *rand.Rand // rnd is the source of random choices; nil uses math/rand.
+}
+
+// New creates a new mini-batch k-means object populated with data from an Interface
+// value, data. Each iteration uses batch randomly chosen values and Cluster runs for
+// at most maxIter iterations, stopping early if the squared movement of all centers
+// during an iteration is no greater than tol.
+func New(data cluster.Interface, batch, maxIter int, tol float64) (*Kmeans, error) {
+	return newKmeans(data, batch, maxIter, tol, false)
+}
+
+// NewFloat32 is equivalent to New, except that the coordinates of data are stored
+// internally as float32, halving the memory required for the data. Centers and
+// all sums are calculated in float64. Values returned by the Kmeans allocate a
+// new float64 slice on each call to V.
+func NewFloat32(data cluster.Interface, batch, maxIter int, tol float64) (*Kmeans, error) {
+	return newKmeans(data, batch, maxIter, tol, true)
+}
+
+// NewOf is equivalent to New for data with coordinates of type F. The coordinates
+// are read directly from data without an intermediate []float64 per element. If
+// F is a 32-bit type, the data are stored as for NewFloat32.
+func NewOf[F cluster.Float](data cluster.Data[F], batch, maxIter int, tol float64) (*Kmeans, error) {
+	var z F
+	return newKmeans(data, batch, maxIter, tol, unsafe.Sizeof(z) == 4)
+}
+
+func newKmeans[F cluster.Float](data cluster.Data[F], batch, maxIter int, tol float64, f32 bool) (*Kmeans, error) {
+	if batch < 1 {
+		return nil, errors.New("minibatch: invalid batch size")
+	}
+	if maxIter < 1 {
+		return nil, errors.New("minibatch: invalid maximum iterations")
+	}
+	v, d, err := convert(data, f32)
+	if err != nil {
+		return nil, err
+	}
+	return &Kmeans{
+		dims:    d,
+		batch:   batch,
+		maxIter: maxIter,
+		tol:     tol,
+		values:  v,
+	}, nil
+}
+
+// convert renders data to the internal representation for a Kmeans. If f32 is
+// true, the coordinates of all the values are stored in a single float32 slice.
+func convert[F cluster.Float](data cluster.Data[F], f32 bool) ([]value, int, error) {
+	if data.Len() == 0 {
+		return nil, 0, &cluster.Error{Pkg: "minibatch", Err: cluster.ErrEmptyData}
+	}
+	va := make([]value, data.Len())
+	dim := len(data.Values(0))
+	var store []float32
+	if f32 {
+		store = make([]float32, len(va)*dim)
+	}
+	w, isWeighter := data.(cluster.Weighter)
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dim {
+			return nil, 0, &cluster.Error{Pkg: "minibatch", Err: cluster.ErrDimensionMismatch}
+		}
+		if f32 {
+			p := store[i*dim : (i+1)*dim : (i+1)*dim]
+			for j, x := range vec {
+				p[j] = float32(x)
+			}
+			va[i] = value{p32: p, w: 1}
+		} else {
+			p := make(point, dim)
+			for j, x := range vec {
+				p[j] = float64(x)
+			}
+			va[i] = value{point: p, w: 1}
+		}
+		if isWeighter {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return va, dim, nil
+}
+
+// SetRand sets the source of the random choices made by Seed and Cluster to rnd. If
+// rnd is nil, the global math/rand source is used.
+func (km *Kmeans) SetRand(rnd *rand.Rand) { km.rnd = rnd }
+
+// intn returns a random integer in [0, n) from the random source.
+func (km *Kmeans) intn(n int) int {
+	if km.rnd == nil {
+		return rand.Intn(n)
+	}
+	return km.rnd.Intn(n)
+}
+
+// float64 returns a random number in [0, 1) from the random source.
+func (km *Kmeans) float64() float64 {
+	if km.rnd == nil {
+		return rand.Float64()
+	}
+	return km.rnd.Float64()
+}
+
+// Seed generates the initial means for the k-means algorithm according to the
+// k-means++ algorithm applied to a random sample of the data of the batch size.
+func (km *Kmeans) Seed(k int) {
+	km.means = make([]center, k)
+	sample := km.sample(nil)
+	if len(sample) < k {
+		perm := rand.Perm
+		if km.rnd != nil {
+			perm = km.rnd.Perm
+		}
+		sample = perm(len(km.values))
+	}
+
+	km.means[0].point = append(point(nil), km.values[sample[km.intn(len(sample))]].coords(nil)...)
+	d := make([]float64, len(sample))
+	buf := make([]float64, km.dims)
+	for i := 1; i < k; i++ {
+		km.means = km.means[:i]
+		sum := 0.
+		for j, s := range sample {
+			_, min := km.nearest(km.values[s].coords(buf))
+			d[j] = min
+			sum += d[j]
+		}
+		target := km.float64() * sum
+		j := 0
+		for sum = d[0]; sum < target && j < len(d)-1; sum += d[j] {
+			j++
+		}
+		km.means = km.means[:k]
+		km.means[i].point = append(point(nil), km.values[sample[j]].coords(nil)...)
+	}
+}
+
+// SetCenters sets the locations of the centers to c.
+func (km *Kmeans) SetCenters(c []cluster.Center) {
+	km.means = make([]center, len(c))
+	for i, cv := range c {
+		km.means[i] = center{point: append(point(nil), cv.V()...)}
+	}
+}
+
+// sample appends the indices of a random batch of values to dst.
+func (km *Kmeans) sample(dst []int) []int {
+	for i := 0; i < km.batch; i++ {
+		dst = append(dst, km.intn(len(km.values)))
+	}
+	return dst
+}
+
+// Find the nearest center to the point v. Returns c, the index of the nearest center
+// and min, the square of the distance from v to that center.
+func (km *Kmeans) nearest(v point) (c int, min float64) {
+	for i, m := range km.means {
+		var d float64
+		for j := range v {
+			ad := v[j] - m.point[j]
+			d += ad * ad
+		}
+		if i == 0 || d < min {
+			min = d
+			c = i
+		}
+	}
+	return c, min
+}
+
+// Cluster runs a clustering of the data using the mini-batch k-means algorithm.
+// After the final iteration all values are assigned to their nearest center.
+func (km *Kmeans) Cluster() error {
+	return km.ClusterContext(context.Background())
+}
+
+// ClusterContext runs a clustering of the data as for Cluster, checking ctx before
+// each mini-batch. If ctx is done, ClusterContext returns ctx.Err() after
+// assigning all values to the centers updated by the completed mini-batches.
+func (km *Kmeans) ClusterContext(ctx context.Context) error {
+	if len(km.means) == 0 {
+		return &cluster.Error{Pkg: "minibatch", Err: cluster.ErrNoCenters}
+	}
+	for i := range km.means {
+		km.means[i].w = 0
+	}
+	var (
+		batch []int
+		near  = make([]int, km.batch)
+		prev  = make([]float64, km.dims)
+		buf   = make([]float64, km.dims)
+		err   error
+	)
+	for it := 0; it < km.maxIter; it++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		batch = km.sample(batch[:0])
+		for i, j := range batch {
+			near[i], _ = km.nearest(km.values[j].coords(buf))
+		}
+		var delta float64
+		for i, j := range batch {
+			v := km.values[j]
+			if v.w == 0 {
+				continue
+			}
+			c := &km.means[near[i]]
+			copy(prev, c.point)
+			c.w += v.w
+			eta := v.w / c.w
+			for d, x := range v.coords(buf) {
+				c.point[d] += eta * (x - c.point[d])
+				dd := c.point[d] - prev[d]
+				delta += dd * dd
+			}
+		}
+		if delta <= km.tol {
+			break
+		}
+	}
+
+	for i := range km.means {
+		km.means[i].indices = km.means[i].indices[:0]
+	}
+	for i, v := range km.values {
+		c, _ := km.nearest(v.coords(buf))
+		km.values[i].cluster = c
+		km.means[c].indices = append(km.means[c].indices, i)
+	}
+	return err
+}
+
+// Within calculates the weighted sum of squares within each cluster.
+// Returns nil if Cluster has not been called.
+func (km *Kmeans) Within() []float64 {
+	if km.means == nil {
+		return nil
+	}
+	ss := make([]float64, len(km.means))
+	buf := make([]float64, km.dims)
+	for _, v := range km.values {
+		for j, x := range v.coords(buf) {
+			d := km.means[v.cluster].point[j] - x
+			ss[v.cluster] += v.w * d * d
+		}
+	}
+	return ss
+}
+
+// Centers returns the k centers determined by a previous call to Cluster.
+func (km *Kmeans) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(km.means))
+	for i := range km.means {
+		cs[i] = &km.means[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Kmeans.
+func (km *Kmeans) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(km.values))
+	for i := range km.values {
+		vs[i] = &km.values[i]
+	}
+	return vs
+}
@@ -0,0 +1,158 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package minibatch_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/minibatch"
+
+	"math"
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+func (s *S) TestMinibatch(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	centers := [][]float64{{0, 0}, {20, 0}, {0, 20}}
+	var data Points
+	for i := 0; i < 10000; i++ {
+		m := centers[i%len(centers)]
+		data = append(data, []float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+
+	km, err := minibatch.New(data, 100, 200, 0)
+	c.Assert(err, check.Equals, nil)
+	km.SetRand(rnd)
+	km.Seed(3)
+	c.Assert(km.Cluster(), check.Equals, nil)
+
+	cens := km.Centers()
+	c.Assert(len(cens), check.Equals, 3)
+	for _, cen := range cens {
+		var found bool
+		for _, m := range centers {
+			if math.Hypot(cen.V()[0]-m[0], cen.V()[1]-m[1]) < 0.5 {
+				found = true
+			}
+		}
+		c.Check(found, check.Equals, true, check.Commentf("center at %v", cen.V()))
+		c.Check(len(cen.Members()), check.Equals, len(data)/3+boolInt(cen.Members()[0] == 0))
+	}
+	vals := km.Values()
+	for i, v := range vals {
+		c.Check(v.Cluster(), check.Equals, vals[i%3].Cluster())
+	}
+	var ss float64
+	for _, w := range km.Within() {
+		ss += w
+	}
+	c.Check(ss/float64(len(data)) < 2.1, check.Equals, true)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := minibatch.New(Points{}, 1, 1, 0)
+	c.Check(err, check.ErrorMatches, "minibatch: no data")
+	_, err = minibatch.New(Points{{1}}, 0, 1, 0)
+	c.Check(err, check.ErrorMatches, "minibatch: invalid batch size")
+	km, _ := minibatch.New(Points{{1}}, 1, 1, 0)
+	c.Check(km.Cluster(), check.ErrorMatches, "minibatch: no centers")
+}
+
+func (s *S) TestFloat32(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	centers := [][]float64{{0, 0}, {20, 0}, {0, 20}}
+	var data Points
+	for i := 0; i < 3000; i++ {
+		m := centers[i%len(centers)]
+		data = append(data, []float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+
+	var kms [2]*minibatch.Kmeans
+	for i, fn := range []func() (*minibatch.Kmeans, error){
+		func() (*minibatch.Kmeans, error) { return minibatch.New(data, 100, 100, 0) },
+		func() (*minibatch.Kmeans, error) { return minibatch.NewFloat32(data, 100, 100, 0) },
+	} {
+		km, err := fn()
+		c.Assert(err, check.Equals, nil)
+		km.SetRand(rand.New(rand.NewSource(2)))
+		km.Seed(3)
+		c.Assert(km.Cluster(), check.Equals, nil)
+		kms[i] = km
+	}
+
+	want, got := kms[0], kms[1]
+	c.Assert(len(got.Centers()), check.Equals, len(want.Centers()))
+	for i, cen := range got.Centers() {
+		w := want.Centers()[i]
+		for j, x := range cen.V() {
+			c.Check(math.Abs(x-w.V()[j]) < 1e-4, check.Equals, true)
+		}
+		c.Check(cen.Members(), check.DeepEquals, w.Members())
+	}
+	for i, v := range got.Values() {
+		for j, x := range v.V() {
+			c.Check(x, check.Equals, float64(float32(data[i][j])))
+		}
+	}
+	for i, ss := range got.Within() {
+		c.Check(math.Abs(ss-want.Within()[i]) < 1e-6*want.Within()[i], check.Equals, true)
+	}
+}
+
+func (s *S) TestNewOf(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	centers := [][]float64{{0, 0}, {20, 0}, {0, 20}}
+	var data Points
+	d32 := cluster.NewDenseOf[float32](3000, 2)
+	for i := 0; i < d32.Rows; i++ {
+		m := centers[i%len(centers)]
+		p := []float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()}
+		data = append(data, p)
+		for j, x := range p {
+			d32.Values(i)[j] = float32(x)
+		}
+	}
+
+	var kms [3]*minibatch.Kmeans
+	for i, fn := range []func() (*minibatch.Kmeans, error){
+		func() (*minibatch.Kmeans, error) { return minibatch.NewFloat32(data, 100, 100, 0) },
+		func() (*minibatch.Kmeans, error) { return minibatch.NewOf[float32](d32, 100, 100, 0) },
+		func() (*minibatch.Kmeans, error) { return minibatch.NewOf[float64](data, 100, 100, 0) },
+	} {
+		km, err := fn()
+		c.Assert(err, check.Equals, nil)
+		km.SetRand(rand.New(rand.NewSource(2)))
+		km.Seed(3)
+		c.Assert(km.Cluster(), check.Equals, nil)
+		kms[i] = km
+	}
+	for i, cen := range kms[1].Centers() {
+		c.Check(cen.V(), check.DeepEquals, kms[0].Centers()[i].V())
+		c.Check(cen.Members(), check.DeepEquals, kms[0].Centers()[i].Members())
+	}
+	for i, v := range kms[2].Values() {
+		c.Check(v.V(), check.DeepEquals, data[i])
+	}
+}
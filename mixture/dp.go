@@ -0,0 +1,247 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mixture
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// DP implements clustering of ℝⁿ data using a Dirichlet process Gaussian mixture
+// model sampled by collapsed Gibbs sampling. The number of clusters is not bounded
+// and is controlled by the concentration parameter; larger concentrations give more
+// clusters. Values are initially assigned by a sequential draw from the Chinese
+// restaurant process in a random order, and the clustering reported is the state
+// of the sampler after the final sweep. Weighted values contribute their weights
+// to the cluster counts and sufficient statistics. The prior on component
+// parameters is equivalent to a single observation with an expected variance of
+// one tenth of the data variance.
+//
+// Reference:
+//
+//	Neal RM. Markov chain sampling methods for Dirichlet process mixture models.
+//	Journal of Computational and Graphical Statistics 9(2):249-265 (2000).
+type DP struct {
+	alpha  float64
+	sweeps int
+
+	values values
+	prior  prior
+
+	stats   []*stats
+	trace   []int
+	centers []center
+
+	rnd *rand.Rand // rnd is the source of random choices; nil uses math/rand.
+}
+
+// stats holds the weighted sufficient statistics of a cluster.
+type stats struct {
+	n      float64
+	sum    []float64
+	sumsq  []float64
+	labels int
+}
+
+func (s *stats) add(v value, sign float64) {
+	w := sign * v.w
+	s.n += w
+	for d, x := range v.point {
+		s.sum[d] += w * x
+		s.sumsq[d] += w * x * x
+	}
+}
+
+// posterior returns the Normal-Gamma posterior parameters of the dth dimension of
+// a cluster with statistics s.
+func (p prior) posterior(s *stats, d int) (m, beta, a, b float64) {
+	if s == nil || s.n <= 0 {
+		return p.m[d], p.beta, p.a, p.b[d]
+	}
+	beta = p.beta + s.n
+	m = (p.beta*p.m[d] + s.sum[d]) / beta
+	a = p.a + s.n/2
+	mean := s.sum[d] / s.n
+	ss := math.Max(0, s.sumsq[d]-s.sum[d]*mean)
+	dm := mean - p.m[d]
+	b = p.b[d] + 0.5*ss + 0.5*p.beta*s.n*dm*dm/beta
+	return m, beta, a, b
+}
+
+// logPredictive returns the log posterior predictive density of x under a cluster
+// with statistics s, a product of Student's t distributions.
+func (p prior) logPredictive(x []float64, s *stats) float64 {
+	var l float64
+	for d, v := range x {
+		m, beta, a, b := p.posterior(s, d)
+		nu := 2 * a
+		scale := b * (beta + 1) / (a * beta)
+		lg1, _ := math.Lgamma((nu + 1) / 2)
+		lg2, _ := math.Lgamma(nu / 2)
+		dx := v - m
+		l += lg1 - lg2 - 0.5*math.Log(nu*math.Pi*scale) - (nu+1)/2*math.Log1p(dx*dx/(nu*scale))
+	}
+	return l
+}
+
+// NewDP creates a new Dirichlet process mixture Clusterer populated with data from
+// an Interface value, data. The concentration parameter alpha must be positive, and
+// Cluster runs the given number of Gibbs sweeps over the data.
+func NewDP(data cluster.Interface, alpha float64, sweeps int) (*DP, error) {
+	if alpha <= 0 {
+		return nil, errors.New("mixture: invalid concentration")
+	}
+	if sweeps < 0 {
+		return nil, errors.New("mixture: invalid sweep count")
+	}
+	va, pr, err := convert(data, 1, 1, 0.1)
+	if err != nil {
+		return nil, err
+	}
+	return &DP{alpha: alpha, sweeps: sweeps, values: va, prior: pr}, nil
+}
+
+// SetRand sets the source of the random choices made by Cluster to rnd. If rnd is
+// nil, the global math/rand source is used.
+func (g *DP) SetRand(rnd *rand.Rand) { g.rnd = rnd }
+
+func (g *DP) newStats() *stats {
+	dims := len(g.prior.m)
+	return &stats{sum: make([]float64, dims), sumsq: make([]float64, dims)}
+}
+
+// Cluster runs the Gibbs sampler.
+func (g *DP) Cluster() error {
+	return g.ClusterContext(context.Background())
+}
+
+// ClusterContext runs the Gibbs sampler, checking ctx before each sweep. If ctx is
+// done, ClusterContext returns ctx.Err() after labelling the values with the
+// clusters of the last completed sweep.
+func (g *DP) ClusterContext(ctx context.Context) error {
+	g.stats = g.stats[:0]
+	g.trace = g.trace[:0]
+	label := make([]int, len(g.values))
+	perm := rand.Perm
+	if g.rnd != nil {
+		perm = g.rnd.Perm
+	}
+	for _, i := range perm(len(g.values)) {
+		label[i] = g.sample(g.values[i])
+		if label[i] == len(g.stats) {
+			g.stats = append(g.stats, g.newStats())
+		}
+		g.stats[label[i]].add(g.values[i], 1)
+		g.stats[label[i]].labels++
+	}
+
+	var err error
+	for sweep := 0; sweep < g.sweeps; sweep++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		for i, v := range g.values {
+			s := g.stats[label[i]]
+			s.add(v, -1)
+			s.labels--
+			if s.labels == 0 {
+				// Drop the empty cluster by moving the last
+				// cluster into its place.
+				last := len(g.stats) - 1
+				g.stats[label[i]] = g.stats[last]
+				for j, l := range label {
+					if l == last {
+						label[j] = label[i]
+					}
+				}
+				g.stats = g.stats[:last]
+			}
+			label[i] = g.sample(v)
+			if label[i] == len(g.stats) {
+				g.stats = append(g.stats, g.newStats())
+			}
+			g.stats[label[i]].add(v, 1)
+			g.stats[label[i]].labels++
+		}
+		g.trace = append(g.trace, len(g.stats))
+	}
+
+	// Renumber clusters in order of their lowest index member.
+	id := make(map[int]int)
+	g.centers = g.centers[:0]
+	for i, l := range label {
+		c, ok := id[l]
+		if !ok {
+			c = len(g.centers)
+			id[l] = c
+			p := make(point, len(g.prior.m))
+			for d := range p {
+				p[d], _, _, _ = g.prior.posterior(g.stats[l], d)
+			}
+			g.centers = append(g.centers, center{point: p})
+		}
+		g.values[i].cluster = c
+		g.centers[c].indices = append(g.centers[c].indices, i)
+		g.centers[c].w += g.values[i].w
+	}
+	return err
+}
+
+// sample returns a cluster for v drawn from its conditional distribution given the
+// current clusters. A returned value of len(g.stats) indicates a new cluster.
+func (g *DP) sample(v value) int {
+	lp := make([]float64, len(g.stats)+1)
+	max := math.Inf(-1)
+	for k, s := range g.stats {
+		lp[k] = math.Log(s.n) + g.prior.logPredictive(v.point, s)
+		max = math.Max(max, lp[k])
+	}
+	lp[len(g.stats)] = math.Log(g.alpha) + g.prior.logPredictive(v.point, nil)
+	max = math.Max(max, lp[len(g.stats)])
+	var sum float64
+	for k, l := range lp {
+		lp[k] = math.Exp(l - max)
+		sum += lp[k]
+	}
+	u := rand.Float64
+	if g.rnd != nil {
+		u = g.rnd.Float64
+	}
+	target := u() * sum
+	for k, p := range lp {
+		target -= p
+		if target < 0 {
+			return k
+		}
+	}
+	return len(lp) - 1
+}
+
+// Trace returns the number of clusters after each sweep of the last call to
+// Cluster.
+func (g *DP) Trace() []int { return g.trace }
+
+// Centers returns the clusters determined by a previous call to Cluster. The
+// location of each center is the posterior mean of the cluster.
+func (g *DP) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(g.centers))
+	for i := range g.centers {
+		cs[i] = &g.centers[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the DP.
+func (g *DP) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(g.values))
+	for i := range g.values {
+		vs[i] = &g.values[i]
+	}
+	return vs
+}
@@ -0,0 +1,152 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mixture implements Bayesian Gaussian mixture model clustering of ℝⁿ
+// data with automatic determination of the number of components.
+//
+// Components have diagonal covariance matrices with a conjugate Normal-Gamma
+// prior on the mean and precision of each dimension. The prior is centered on the
+// data mean with an expected variance proportional to the data variance.
+package mixture
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"math"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	w       float64
+	cluster int
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+type center struct {
+	point
+	w       float64
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// values is a collection of value that satisfies cluster.Interface and
+// cluster.Weighter.
+type values []value
+
+func (v values) Len() int               { return len(v) }
+func (v values) Values(i int) []float64 { return v[i].point }
+func (v values) Weight(i int) float64   { return v[i].w }
+
+// prior is a Normal-Gamma prior over the per-dimension means and precisions of a
+// component.
+type prior struct {
+	m    []float64 // m is the prior mean.
+	beta float64   // beta is the prior mean pseudo-count.
+	a    float64   // a is the prior precision shape.
+	b    []float64 // b is the prior precision rate.
+}
+
+// convert renders data to the internal representation and returns the data prior
+// with the mean pseudo-count beta and precision shape a. The expected variance of
+// each dimension under the prior is f times the data variance.
+func convert(data cluster.Interface, beta, a, f float64) (values, prior, error) {
+	if data.Len() == 0 {
+		return nil, prior{}, &cluster.Error{Pkg: "mixture", Err: cluster.ErrEmptyData}
+	}
+	dims := len(data.Values(0))
+	va := make(values, data.Len())
+	w, isWeighter := data.(cluster.Weighter)
+	var sw float64
+	mean := make([]float64, dims)
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dims {
+			return nil, prior{}, &cluster.Error{Pkg: "mixture", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...), w: 1}
+		if isWeighter {
+			va[i].w = w.Weight(i)
+		}
+		sw += va[i].w
+		for j, x := range vec {
+			mean[j] += va[i].w * x
+		}
+	}
+	for j := range mean {
+		mean[j] /= sw
+	}
+	b := make([]float64, dims)
+	for _, v := range va {
+		for j, x := range v.point {
+			d := x - mean[j]
+			b[j] += v.w * d * d
+		}
+	}
+	for j := range b {
+		b[j] /= sw
+		if b[j] == 0 {
+			b[j] = 1
+		}
+		b[j] *= a * f
+	}
+	return va, prior{m: mean, beta: beta, a: a, b: b}, nil
+}
+
+// digamma returns the logarithmic derivative of the gamma function at x > 0.
+func digamma(x float64) float64 {
+	var r float64
+	for ; x < 6; x++ {
+		r -= 1 / x
+	}
+	f := 1 / (x * x)
+	return r + math.Log(x) - 0.5/x - f*(1.0/12-f*(1.0/120-f*(1.0/252-f*(1.0/240-f/132))))
+}
+
+// label assigns each value to the listed component with the greatest
+// responsibility and returns the centers of the occupied components. Components
+// with no assigned values are dropped and the remaining components are
+// renumbered in order. The ith row of resp holds the responsibilities of each
+// component for the ith value and means holds the location of each component.
+// The returned slice holds the original index of each retained component.
+func label(vals values, resp [][]float64, means [][]float64) ([]center, []int) {
+	k := len(means)
+	counts := make([]int, k)
+	for i, r := range resp {
+		c := 0
+		for j, p := range r {
+			if p > r[c] {
+				c = j
+			}
+		}
+		vals[i].cluster = c
+		counts[c]++
+	}
+	id := make([]int, k)
+	var keep []int
+	for j, n := range counts {
+		id[j] = -1
+		if n != 0 {
+			id[j] = len(keep)
+			keep = append(keep, j)
+		}
+	}
+	cens := make([]center, len(keep))
+	for i, j := range keep {
+		cens[i].point = append(point(nil), means[j]...)
+	}
+	for i := range vals {
+		c := id[vals[i].cluster]
+		vals[i].cluster = c
+		cens[c].indices = append(cens[c].indices, i)
+		cens[c].w += vals[i].w
+	}
+	return cens, keep
+}
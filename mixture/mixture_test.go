@@ -0,0 +1,121 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mixture_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/mixture"
+
+	"math"
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+var means = [][]float64{{0, 0}, {10, 0}, {0, 10}}
+
+// blobs returns n points drawn from unit variance Gaussians about means using rnd.
+func blobs(rnd *rand.Rand, n int) Points {
+	var pts Points
+	for i := 0; i < n; i++ {
+		m := means[i%len(means)]
+		pts = append(pts, []float64{m[0] + rnd.NormFloat64(), m[1] + rnd.NormFloat64()})
+	}
+	return pts
+}
+
+func near(p []float64, tol float64) bool {
+	for _, m := range means {
+		if math.Hypot(p[0]-m[0], p[1]-m[1]) < tol {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *S) TestVariational(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	pts := blobs(rnd, 600)
+	g, err := mixture.NewVariational(pts, 10, 1e-3, 1e-3, 1000)
+	c.Assert(err, check.Equals, nil)
+	g.SetRand(rnd)
+	c.Check(g.Memberships(), check.IsNil)
+	c.Check(g.Assign([]float64{0, 0}), check.Equals, -1)
+	c.Assert(g.Cluster(), check.Equals, nil)
+	c.Check(g.Effective(), check.Equals, 3)
+	c.Assert(len(g.Centers()), check.Equals, 3)
+	for i, cen := range g.Centers() {
+		c.Check(near(cen.V(), 0.5), check.Equals, true, check.Commentf("center at %v", cen.V()))
+		c.Check(len(cen.Members()), check.Equals, 200)
+		c.Check(math.Abs(g.Weights()[i]-1./3) < 0.01, check.Equals, true)
+		for _, v := range g.Variances()[i] {
+			c.Check(math.Abs(v-1) < 0.3, check.Equals, true)
+		}
+	}
+	var sum float64
+	for _, r := range g.Responsibility(0) {
+		sum += r
+	}
+	c.Check(math.Abs(sum-1) < 1e-9, check.Equals, true)
+	var p cluster.Predictor = g
+	for i, cen := range g.Centers() {
+		c.Check(p.Assign(cen.V()), check.Equals, i)
+	}
+	for i := 0; i < 30; i++ {
+		c.Check(p.Assign(pts[i]), check.Equals, g.Values()[i].Cluster())
+	}
+	var sc cluster.SoftClusterer = g
+	m := sc.Memberships()
+	c.Assert(len(m), check.Equals, len(pts))
+	for i, r := range m {
+		c.Check(r, check.DeepEquals, g.Responsibility(i))
+		for j, p := range r {
+			c.Check(p <= r[g.Values()[i].Cluster()], check.Equals, true, check.Commentf("value %d component %d", i, j))
+		}
+	}
+
+	_, err = mixture.NewVariational(pts, 0, 1e-3, 1e-3, 100)
+	c.Check(err, check.ErrorMatches, "mixture: invalid maximum k")
+	_, err = mixture.NewVariational(pts, 3, 0, 1e-3, 100)
+	c.Check(err, check.ErrorMatches, "mixture: invalid concentration")
+	_, err = mixture.NewVariational(Points{}, 3, 1, 1e-3, 100)
+	c.Check(err, check.ErrorMatches, "mixture: no data")
+}
+
+func (s *S) TestDP(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	pts := blobs(rnd, 300)
+	g, err := mixture.NewDP(pts, 1, 30)
+	c.Assert(err, check.Equals, nil)
+	g.SetRand(rnd)
+	c.Assert(g.Cluster(), check.Equals, nil)
+	c.Check(len(g.Trace()), check.Equals, 30)
+	var big int
+	for _, cen := range g.Centers() {
+		if len(cen.Members()) < 10 {
+			continue
+		}
+		big++
+		c.Check(near(cen.V(), 0.5), check.Equals, true, check.Commentf("center at %v", cen.V()))
+	}
+	c.Check(big, check.Equals, 3)
+
+	_, err = mixture.NewDP(pts, 0, 10)
+	c.Check(err, check.ErrorMatches, "mixture: invalid concentration")
+	_, err = mixture.NewDP(pts, 1, -1)
+	c.Check(err, check.ErrorMatches, "mixture: invalid sweep count")
+}
@@ -0,0 +1,325 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mixture
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/kmeans"
+
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// Variational implements clustering of ℝⁿ data by fitting a Gaussian mixture model
+// using variational Bayesian inference. The model is fitted with an upper bound on
+// the number of components and a symmetric Dirichlet prior on the mixing weights.
+// With a small Dirichlet concentration, components that are not supported by the
+// data are driven to negligible weight, so the number of components is determined
+// by the data. The prior on component parameters is weak, equivalent to a small
+// fraction of a single observation, with an expected variance equal to the data
+// variance.
+//
+// Reference:
+//
+//	Bishop CM. Pattern Recognition and Machine Learning. Springer (2006), §10.2.
+type Variational struct {
+	maxK    int
+	alpha0  float64
+	tol     float64
+	maxIter int
+
+	values values
+	prior  prior
+
+	// Variational posterior parameters for each component.
+	alpha []float64
+	beta  []float64
+	m     [][]float64
+	a     []float64
+	b     [][]float64
+
+	resp    [][]float64
+	keep    []int
+	centers []center
+	iter    int
+
+	rnd *rand.Rand // rnd is the source of random choices; nil uses math/rand.
+}
+
+// NewVariational creates a new variational Bayesian Gaussian mixture Clusterer
+// populated with data from an Interface value, data. At most maxK components are
+// fitted, with a Dirichlet concentration of alpha0 for each component. Values of
+// alpha0 less than one favor fewer components. Cluster iterates until the
+// expected weighted count of no component changes by more than tol, or until
+// maxIter iterations have been made.
+func NewVariational(data cluster.Interface, maxK int, alpha0, tol float64, maxIter int) (*Variational, error) {
+	if maxK < 1 {
+		return nil, errors.New("mixture: invalid maximum k")
+	}
+	if alpha0 <= 0 {
+		return nil, errors.New("mixture: invalid concentration")
+	}
+	va, pr, err := convert(data, 1e-3, 1e-2, 1)
+	if err != nil {
+		return nil, err
+	}
+	if maxK > len(va) {
+		maxK = len(va)
+	}
+	return &Variational{
+		maxK:    maxK,
+		alpha0:  alpha0,
+		tol:     tol,
+		maxIter: maxIter,
+		values:  va,
+		prior:   pr,
+	}, nil
+}
+
+// SetRand sets the source of the random choices made when seeding the initial
+// k-means partition to rnd. If rnd is nil, the global math/rand source is used.
+func (g *Variational) SetRand(rnd *rand.Rand) { g.rnd = rnd }
+
+// Cluster fits the mixture model and assigns each value to the component with the
+// greatest posterior responsibility. Responsibilities are initialized from a
+// k-means++ seeded k-means partition of the data into the maximum number of
+// components.
+func (g *Variational) Cluster() error {
+	return g.ClusterContext(context.Background())
+}
+
+// ClusterContext fits the mixture model as for Cluster, checking ctx during the
+// initial k-means partition and before each iteration. If ctx is done during the
+// initial partition, ClusterContext returns ctx.Err() without altering the model.
+// Otherwise it returns ctx.Err() after assigning values using the posterior of the
+// last completed iteration.
+func (g *Variational) ClusterContext(ctx context.Context) error {
+	k := g.maxK
+	n := len(g.values)
+	dims := len(g.prior.m)
+
+	km, err := kmeans.New(g.values, kmeans.WithRand(g.rnd))
+	if err != nil {
+		return err
+	}
+	km.Seed(k)
+	err = km.ClusterContext(ctx)
+	if err != nil {
+		return err
+	}
+	g.resp = make([][]float64, n)
+	for i, v := range km.Values() {
+		g.resp[i] = make([]float64, k)
+		g.resp[i][v.Cluster()] = 1
+	}
+
+	g.alpha = make([]float64, k)
+	g.beta = make([]float64, k)
+	g.a = make([]float64, k)
+	g.m = make([][]float64, k)
+	g.b = make([][]float64, k)
+	for j := range g.m {
+		g.m[j] = make([]float64, dims)
+		g.b[j] = make([]float64, dims)
+	}
+
+	nk := make([]float64, k)
+	prev := make([]float64, k)
+	mean := make([][]float64, k)
+	for j := range mean {
+		mean[j] = make([]float64, dims)
+	}
+	lnRho := make([]float64, k)
+	for g.iter = 0; g.iter < g.maxIter; {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		// Update the posterior from the responsibilities.
+		for j := range nk {
+			nk[j] = 0
+			for d := range mean[j] {
+				mean[j][d] = 0
+			}
+		}
+		for i, v := range g.values {
+			for j, r := range g.resp[i] {
+				r *= v.w
+				nk[j] += r
+				for d, x := range v.point {
+					mean[j][d] += r * x
+				}
+			}
+		}
+		pr := g.prior
+		for j := range nk {
+			if nk[j] > 0 {
+				for d := range mean[j] {
+					mean[j][d] /= nk[j]
+				}
+			}
+			g.alpha[j] = g.alpha0 + nk[j]
+			g.beta[j] = pr.beta + nk[j]
+			g.a[j] = pr.a + nk[j]/2
+			for d := range g.m[j] {
+				g.m[j][d] = (pr.beta*pr.m[d] + nk[j]*mean[j][d]) / g.beta[j]
+				dm := mean[j][d] - pr.m[d]
+				g.b[j][d] = pr.b[d] + 0.5*pr.beta*nk[j]*dm*dm/g.beta[j]
+			}
+		}
+		for i, v := range g.values {
+			for j, r := range g.resp[i] {
+				r *= v.w
+				for d, x := range v.point {
+					dx := x - mean[j][d]
+					g.b[j][d] += 0.5 * r * dx * dx
+				}
+			}
+		}
+		g.iter++
+
+		var change float64
+		for j := range nk {
+			change = math.Max(change, math.Abs(nk[j]-prev[j]))
+		}
+		copy(prev, nk)
+		if g.iter > 1 && change <= g.tol {
+			break
+		}
+
+		// Update the responsibilities from the posterior.
+		var sa float64
+		for _, a := range g.alpha {
+			sa += a
+		}
+		dsa := digamma(sa)
+		for i, v := range g.values {
+			max := math.Inf(-1)
+			for j := range lnRho {
+				lnRho[j] = g.logRho(v.point, j, dsa)
+				max = math.Max(max, lnRho[j])
+			}
+			var sum float64
+			for j, l := range lnRho {
+				g.resp[i][j] = math.Exp(l - max)
+				sum += g.resp[i][j]
+			}
+			for j := range g.resp[i] {
+				g.resp[i][j] /= sum
+			}
+		}
+	}
+
+	g.centers, g.keep = label(g.values, g.resp, g.m)
+	return err
+}
+
+// logRho returns the unnormalised log responsibility of component j for x, where
+// dsa is the digamma function of the sum of the Dirichlet concentrations.
+func (g *Variational) logRho(x []float64, j int, dsa float64) float64 {
+	l := digamma(g.alpha[j]) - dsa
+	for d, v := range x {
+		dx := v - g.m[j][d]
+		l += 0.5 * (digamma(g.a[j]) - math.Log(g.b[j][d]) - 1/g.beta[j] - g.a[j]/g.b[j][d]*dx*dx)
+	}
+	return l
+}
+
+// Assign returns the index of the component returned by Centers with the greatest
+// posterior probability of membership for x. If Cluster has not been called,
+// Assign returns -1.
+func (g *Variational) Assign(x []float64) int {
+	var sa float64
+	for _, a := range g.alpha {
+		sa += a
+	}
+	dsa := digamma(sa)
+	c, max := -1, math.Inf(-1)
+	for i, j := range g.keep {
+		if l := g.logRho(x, j, dsa); l > max {
+			c, max = i, l
+		}
+	}
+	return c
+}
+
+// Iterations returns the number of iterations made by the last call to Cluster.
+func (g *Variational) Iterations() int { return g.iter }
+
+// Effective returns the number of components that have at least one value
+// assigned to them.
+func (g *Variational) Effective() int { return len(g.centers) }
+
+// Weights returns the posterior mean mixing weight of each of the components
+// returned by Centers. Weights do not sum to one if the mixing weight of pruned
+// components is not negligible.
+func (g *Variational) Weights() []float64 {
+	var sa float64
+	for _, a := range g.alpha {
+		sa += a
+	}
+	w := make([]float64, len(g.keep))
+	for i, j := range g.keep {
+		w[i] = g.alpha[j] / sa
+	}
+	return w
+}
+
+// Variances returns the posterior mean variance of each dimension of each of the
+// components returned by Centers.
+func (g *Variational) Variances() [][]float64 {
+	v := make([][]float64, len(g.keep))
+	for i, j := range g.keep {
+		v[i] = make([]float64, len(g.b[j]))
+		for d, b := range g.b[j] {
+			v[i][d] = b / g.a[j]
+		}
+	}
+	return v
+}
+
+// Responsibility returns the posterior probability of membership of the ith value
+// in each of the components returned by Centers.
+func (g *Variational) Responsibility(i int) []float64 {
+	r := make([]float64, len(g.keep))
+	for c, j := range g.keep {
+		r[c] = g.resp[i][j]
+	}
+	return r
+}
+
+// Memberships returns the posterior probability of membership of each value in each
+// of the components returned by Centers. The ith row of the returned matrix holds
+// the result of Responsibility(i). Returns nil if Cluster has not been called.
+func (g *Variational) Memberships() [][]float64 {
+	if g.centers == nil {
+		return nil
+	}
+	m := make([][]float64, len(g.values))
+	for i := range m {
+		m[i] = g.Responsibility(i)
+	}
+	return m
+}
+
+// Centers returns the occupied components determined by a previous call to
+// Cluster. The location of each center is the posterior mean of the component.
+func (g *Variational) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(g.centers))
+	for i := range g.centers {
+		cs[i] = &g.centers[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Variational.
+func (g *Variational) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(g.values))
+	for i := range g.values {
+		vs[i] = &g.values[i]
+	}
+	return vs
+}
@@ -0,0 +1,50 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mst
+
+import (
+	"github.com/biogo/store/kdtree"
+)
+
+// node is an indexed point satisfying the kdtree.Comparable interface.
+type node struct {
+	Point []float64
+	ID    int
+}
+
+func (p *node) Clone() kdtree.Comparable {
+	return &node{Point: append([]float64(nil), p.Point...), ID: p.ID}
+}
+func (p *node) Compare(c kdtree.Comparable, d kdtree.Dim) float64 {
+	q := c.(*node)
+	return p.Point[d] - q.Point[d]
+}
+func (p *node) Dims() int { return len(p.Point) }
+func (p *node) Distance(c kdtree.Comparable) float64 {
+	q := c.(*node)
+	return sqDist(p.Point, q.Point)
+}
+
+// nodes is a collection of node values that satisfies the kdtree.Interface.
+type nodes []*node
+
+func (p nodes) Index(i int) kdtree.Comparable         { return p[i] }
+func (p nodes) Len() int                              { return len(p) }
+func (p nodes) Pivot(d kdtree.Dim) int                { return plane{nodes: p, Dim: d}.Pivot() }
+func (p nodes) Slice(start, end int) kdtree.Interface { return p[start:end] }
+
+// plane wraps a nodes type allowing it to be pivoted on a dimension.
+type plane struct {
+	kdtree.Dim
+	nodes
+}
+
+func (p plane) Less(i, j int) bool { return p.nodes[i].Point[p.Dim] < p.nodes[j].Point[p.Dim] }
+func (p plane) Pivot() int         { return kdtree.Partition(p, kdtree.MedianOfRandoms(p, kdtree.Randoms)) }
+func (p plane) Slice(start, end int) kdtree.SortSlicer {
+	p.nodes = p.nodes[start:end]
+	return p
+}
+func (p plane) Swap(i, j int) { p.nodes[i], p.nodes[j] = p.nodes[j], p.nodes[i] }
@@ -0,0 +1,255 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mst provides minimum spanning tree construction for use by linkage and
+// density based clustering algorithms.
+package mst
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/store/kdtree"
+
+	"errors"
+	"math"
+	"sort"
+)
+
+// Edge is an undirected weighted edge between elements U and V.
+type Edge struct {
+	U, V   int
+	Weight float64
+}
+
+// Tree is a minimum spanning tree over n elements, represented by its n-1 edges in
+// order of increasing weight.
+type Tree []Edge
+
+type byWeight Tree
+
+func (t byWeight) Len() int           { return len(t) }
+func (t byWeight) Less(i, j int) bool { return t[i].Weight < t[j].Weight }
+func (t byWeight) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+
+// Prim returns the minimum spanning tree over n elements using Prim's algorithm
+// with the pairwise distances provided by dist. Prim makes O(n²) calls to dist and
+// is suitable for arbitrary distance functions, including cached or precomputed
+// distances.
+func Prim(n int, dist func(i, j int) float64) Tree {
+	if n < 2 {
+		return nil
+	}
+	var (
+		in   = make([]bool, n)
+		best = make([]float64, n)
+		from = make([]int, n)
+		t    = make(Tree, 0, n-1)
+	)
+	for i := range best {
+		best[i] = math.Inf(1)
+	}
+	u := 0
+	in[u] = true
+	for len(t) < n-1 {
+		next := -1
+		for v := range best {
+			if in[v] {
+				continue
+			}
+			if d := dist(u, v); d < best[v] {
+				best[v] = d
+				from[v] = u
+			}
+			if next < 0 || best[v] < best[next] {
+				next = v
+			}
+		}
+		in[next] = true
+		t = append(t, Edge{U: from[next], V: next, Weight: best[next]})
+		u = next
+	}
+	sort.Stable(byWeight(t))
+	return t
+}
+
+// Boruvka returns the Euclidean minimum spanning tree of data using Borůvka's
+// algorithm with kd-tree accelerated nearest neighbor queries. If core is not nil,
+// it must hold a non-negative core distance for each element of data and edges
+// are weighted by the mutual reachability distance max(core[u], core[v], d(u, v)).
+func Boruvka(data cluster.Interface, core []float64) (Tree, error) {
+	n := data.Len()
+	if n == 0 {
+		return nil, &cluster.Error{Pkg: "mst", Err: cluster.ErrEmptyData}
+	}
+	if core != nil && len(core) != n {
+		return nil, errors.New("mst: core distance length mismatch")
+	}
+	dim := len(data.Values(0))
+	nds := make(nodes, n)
+	q := make([]*node, n)
+	for i := range nds {
+		p := append([]float64(nil), data.Values(i)...)
+		if len(p) != dim {
+			return nil, &cluster.Error{Pkg: "mst", Err: cluster.ErrDimensionMismatch}
+		}
+		nds[i] = &node{Point: p, ID: i}
+		q[i] = nds[i]
+	}
+	tree := kdtree.New(nds, false)
+
+	c := newComponents(n)
+	t := make(Tree, 0, n-1)
+	best := make([]Edge, n)
+	keep := &otherKeeper{comp: c, core: core}
+	for len(t) < n-1 {
+		for i := range best {
+			best[i] = Edge{U: -1, Weight: math.Inf(1)}
+		}
+		for _, p := range q {
+			r := c.find(p.ID)
+			keep.reset(p.ID, best[r].Weight)
+			tree.NearestSet(keep, p)
+			if len(keep.Heap) == 0 || keep.Heap[0].Comparable == nil {
+				continue
+			}
+			nb := keep.Heap[0].Comparable.(*node)
+			if d := keep.Heap[0].Dist; d < best[r].Weight {
+				best[r] = Edge{U: p.ID, V: nb.ID, Weight: d}
+			}
+		}
+		for _, e := range best {
+			if e.U < 0 || !c.union(e.U, e.V) {
+				continue
+			}
+			e.Weight = math.Sqrt(e.Weight)
+			t = append(t, e)
+		}
+	}
+	sort.Stable(byWeight(t))
+	return t, nil
+}
+
+func sqDist(a, b []float64) float64 {
+	var sum float64
+	for i, v := range a {
+		d := v - b[i]
+		sum += d * d
+	}
+	return sum
+}
+
+// otherKeeper is a kdtree.Keeper that retains the single nearest point that is not
+// in the same component as the query. Distances are squared so that kd-tree pruning
+// remains valid; when core distances are used, the squared mutual reachability
+// distance is never less than the squared Euclidean distance.
+type otherKeeper struct {
+	kdtree.Heap
+	comp  *components
+	core  []float64
+	query int
+	root  int
+}
+
+func (k *otherKeeper) reset(q int, bound float64) {
+	k.query = q
+	k.root = k.comp.find(q)
+	k.Heap = append(k.Heap[:0], kdtree.ComparableDist{Dist: bound})
+}
+
+func (k *otherKeeper) Keep(c kdtree.ComparableDist) {
+	p := c.Comparable.(*node)
+	if k.comp.find(p.ID) == k.root {
+		return
+	}
+	if k.core != nil {
+		c.Dist = math.Max(c.Dist, math.Max(k.core[k.query]*k.core[k.query], k.core[p.ID]*k.core[p.ID]))
+	}
+	if c.Dist < k.Heap[0].Dist {
+		k.Heap[0] = c
+	}
+}
+
+// components is a union-find structure over element indices.
+type components struct {
+	parent []int
+	rank   []int
+}
+
+func newComponents(n int) *components {
+	c := &components{parent: make([]int, n), rank: make([]int, n)}
+	for i := range c.parent {
+		c.parent[i] = i
+	}
+	return c
+}
+
+func (c *components) find(i int) int {
+	for c.parent[i] != i {
+		c.parent[i] = c.parent[c.parent[i]]
+		i = c.parent[i]
+	}
+	return i
+}
+
+// union merges the components holding i and j, returning false if they were
+// already in the same component.
+func (c *components) union(i, j int) bool {
+	ri, rj := c.find(i), c.find(j)
+	if ri == rj {
+		return false
+	}
+	switch {
+	case c.rank[ri] < c.rank[rj]:
+		ri, rj = rj, ri
+	case c.rank[ri] == c.rank[rj]:
+		c.rank[ri]++
+	}
+	c.parent[rj] = ri
+	return true
+}
+
+// Cut returns cluster labels for the n = len(t)+1 elements spanned by t after
+// removing all edges with a weight greater than threshold. Labels are numbered
+// from zero in order of the lowest index element of each cluster.
+func (t Tree) Cut(threshold float64) []int {
+	c := newComponents(len(t) + 1)
+	for _, e := range t {
+		if e.Weight <= threshold {
+			c.union(e.U, e.V)
+		}
+	}
+	return c.labels()
+}
+
+// CutK returns cluster labels for the n = len(t)+1 elements spanned by t after
+// removing the k-1 heaviest edges of the tree, giving k clusters separated by the
+// largest gaps. Labels are numbered as for Cut.
+func (t Tree) CutK(k int) []int {
+	c := newComponents(len(t) + 1)
+	if k < 1 {
+		k = 1
+	}
+	m := len(t) - (k - 1)
+	if m < 0 {
+		m = 0
+	}
+	for _, e := range t[:m] {
+		c.union(e.U, e.V)
+	}
+	return c.labels()
+}
+
+func (c *components) labels() []int {
+	l := make([]int, len(c.parent))
+	id := make(map[int]int)
+	for i := range l {
+		r := c.find(i)
+		lab, ok := id[r]
+		if !ok {
+			lab = len(id)
+			id[r] = lab
+		}
+		l[i] = lab
+	}
+	return l
+}
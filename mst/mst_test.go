@@ -0,0 +1,82 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mst_test
+
+import (
+	"github.com/biogo/cluster/mst"
+
+	"math"
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type points [][]float64
+
+func (p points) Len() int               { return len(p) }
+func (p points) Values(i int) []float64 { return p[i] }
+
+func (p points) dist(i, j int) float64 {
+	var ss float64
+	for k := range p[i] {
+		d := p[i][k] - p[j][k]
+		ss += d * d
+	}
+	return math.Sqrt(ss)
+}
+
+func weight(t mst.Tree) float64 {
+	var w float64
+	for _, e := range t {
+		w += e.Weight
+	}
+	return w
+}
+
+func (s *S) TestPrimBoruvka(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	p := make(points, 300)
+	for i := range p {
+		p[i] = []float64{rnd.Float64(), rnd.Float64(), rnd.Float64()}
+	}
+	prim := mst.Prim(len(p), p.dist)
+	c.Assert(len(prim), check.Equals, len(p)-1)
+	bor, err := mst.Boruvka(p, nil)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(len(bor), check.Equals, len(p)-1)
+	c.Check(math.Abs(weight(prim)-weight(bor)) < 1e-9, check.Equals, true)
+	for i := 1; i < len(bor); i++ {
+		c.Check(bor[i-1].Weight <= bor[i].Weight, check.Equals, true)
+	}
+
+	core := make([]float64, len(p))
+	for i := range core {
+		core[i] = rnd.Float64() / 10
+	}
+	mrd := func(i, j int) float64 {
+		return math.Max(p.dist(i, j), math.Max(core[i], core[j]))
+	}
+	prim = mst.Prim(len(p), mrd)
+	bor, err = mst.Boruvka(p, core)
+	c.Assert(err, check.Equals, nil)
+	c.Check(math.Abs(weight(prim)-weight(bor)) < 1e-9, check.Equals, true)
+}
+
+func (s *S) TestCut(c *check.C) {
+	p := points{{0}, {1}, {2}, {10}, {11}, {30}}
+	t := mst.Prim(len(p), p.dist)
+	c.Check(t.Cut(1), check.DeepEquals, []int{0, 0, 0, 1, 1, 2})
+	c.Check(t.Cut(0.5), check.DeepEquals, []int{0, 1, 2, 3, 4, 5})
+	c.Check(t.CutK(2), check.DeepEquals, []int{0, 0, 0, 0, 0, 1})
+	c.Check(t.CutK(3), check.DeepEquals, []int{0, 0, 0, 1, 1, 2})
+	c.Check(t.CutK(1), check.DeepEquals, []int{0, 0, 0, 0, 0, 0})
+}
@@ -0,0 +1,288 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package optics implements the OPTICS density-based cluster ordering for ℝⁿ data.
+//
+// Reference:
+//
+//	Ankerst M, Breunig MM, Kriegel H-P, Sander J. OPTICS: ordering points to identify
+//	the clustering structure. Proceedings of the ACM SIGMOD International Conference
+//	on Management of Data 49-60 (1999).
+package optics
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/spatial"
+
+	"container/heap"
+	"errors"
+	"math"
+)
+
+// Noise is the cluster label of values that are not assigned to any cluster.
+const Noise = -1
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	w       float64
+	cluster int
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+type center struct {
+	point
+	w       float64
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// values is a collection of value that satisfies cluster.Interface.
+type values []value
+
+func (v values) Len() int               { return len(v) }
+func (v values) Values(i int) []float64 { return v[i].point }
+
+// Reach is an element of a reachability plot.
+type Reach struct {
+	Index        int     // Index is the index of the value in the data.
+	Reachability float64 // Reachability is the reachability distance of the value, or +Inf if undefined.
+	Core         float64 // Core is the core distance of the value, or +Inf if undefined.
+}
+
+// OPTICS implements data clustering using the OPTICS algorithm.
+type OPTICS struct {
+	eps    float64
+	minPts int
+	index  spatial.Index
+
+	dims    int
+	values  values
+	order   []Reach
+	centers []center
+}
+
+// New creates a new OPTICS Clusterer object populated with data from an Interface
+// value, data. The ordering is generated with a maximum neighborhood radius of eps
+// and a value is a core value if the total weight of values within this radius of
+// it, including itself, is at least minPts. The core distance of a value is the
+// distance at which this weight is reached. Values that do not satisfy
+// cluster.Weighter are given a weight of 1.
+func New(data cluster.Interface, eps float64, minPts int) (*OPTICS, error) {
+	if eps <= 0 {
+		return nil, errors.New("optics: invalid eps")
+	}
+	if minPts < 1 {
+		return nil, errors.New("optics: invalid minPts")
+	}
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "optics", Err: cluster.ErrEmptyData}
+	}
+	va := make(values, data.Len())
+	dim := len(data.Values(0))
+	w, isWeighter := data.(cluster.Weighter)
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dim {
+			return nil, &cluster.Error{Pkg: "optics", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...), w: 1, cluster: Noise}
+		if isWeighter {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return &OPTICS{
+		eps:    eps,
+		minPts: minPts,
+		dims:   dim,
+		values: va,
+	}, nil
+}
+
+// SetIndex sets the spatial index used for neighborhood queries. If no index is
+// set, a kd-tree is used.
+func (op *OPTICS) SetIndex(idx spatial.Index) { op.index = idx }
+
+// SetMetric sets the metric used for neighborhood queries. It is equivalent to
+// calling SetIndex with a VPTree using m, so m must satisfy the triangle inequality.
+func (op *OPTICS) SetMetric(m cluster.Metric) { op.index = spatial.NewVPTree(m) }
+
+// seed is an entry in the OPTICS seed list.
+type seed struct {
+	index int
+	reach float64
+}
+
+// seeds is a min-heap of seed ordered by reachability and then index. Seeds are
+// not updated in place; stale entries are skipped when popped.
+type seeds []seed
+
+func (s seeds) Len() int { return len(s) }
+func (s seeds) Less(i, j int) bool {
+	if s[i].reach != s[j].reach {
+		return s[i].reach < s[j].reach
+	}
+	return s[i].index < s[j].index
+}
+func (s seeds) Swap(i, j int)       { s[i], s[j] = s[j], s[i] }
+func (s *seeds) Push(x interface{}) { *s = append(*s, x.(seed)) }
+func (s *seeds) Pop() interface{} {
+	old := *s
+	x := old[len(old)-1]
+	*s = old[:len(old)-1]
+	return x
+}
+
+// Cluster calculates the cluster ordering of the data and extracts the clusters
+// obtained by a DBSCAN clustering with radius eps.
+func (op *OPTICS) Cluster() error {
+	if op.index == nil {
+		op.index = spatial.NewKDTree()
+	}
+	op.index.Build(op.values)
+
+	n := len(op.values)
+	var (
+		hits      []spatial.Neighbor
+		processed = make([]bool, n)
+		reach     = make([]float64, n)
+		queue     seeds
+	)
+	for i := range reach {
+		reach[i] = math.Inf(1)
+	}
+	op.order = op.order[:0]
+	for i := range op.values {
+		if processed[i] {
+			continue
+		}
+		heap.Push(&queue, seed{index: i, reach: reach[i]})
+		for queue.Len() != 0 {
+			s := heap.Pop(&queue).(seed)
+			j := s.index
+			if processed[j] || s.reach > reach[j] {
+				continue
+			}
+			processed[j] = true
+
+			hits = op.index.RangeSet(hits[:0], op.values[j].point, op.eps)
+			core := op.core(hits)
+			op.order = append(op.order, Reach{Index: j, Reachability: reach[j], Core: core})
+			if math.IsInf(core, 1) {
+				continue
+			}
+			for _, h := range hits {
+				if processed[h.Index] {
+					continue
+				}
+				r := math.Max(core, h.Dist)
+				if r < reach[h.Index] {
+					reach[h.Index] = r
+					heap.Push(&queue, seed{index: h.Index, reach: r})
+				}
+			}
+		}
+	}
+
+	return op.Extract(op.eps)
+}
+
+// core returns the core distance of the value with the eps-neighborhood hits,
+// which are ordered by distance, or +Inf if the total weight of hits is less than
+// minPts.
+func (op *OPTICS) core(hits []spatial.Neighbor) float64 {
+	var w float64
+	for _, h := range hits {
+		w += op.values[h.Index].w
+		if w >= float64(op.minPts) {
+			return h.Dist
+		}
+	}
+	return math.Inf(1)
+}
+
+// Reachability returns the reachability plot determined by a previous call to
+// Cluster. The elements of the returned slice are in cluster order.
+func (op *OPTICS) Reachability() []Reach { return op.order }
+
+// Extract relabels the values with the clusters obtained by a DBSCAN clustering
+// with radius eps, which must be no greater than the eps used to generate the
+// ordering. Extract may be called repeatedly to explore the clustering at a range
+// of density levels. Border values are assigned as in the original description
+// of OPTICS, and so may differ from those of a DBSCAN clustering.
+func (op *OPTICS) Extract(eps float64) error {
+	if op.order == nil {
+		return errors.New("optics: no ordering")
+	}
+	if eps <= 0 || eps > op.eps {
+		return errors.New("optics: invalid eps")
+	}
+	op.centers = op.centers[:0]
+	c := Noise
+	for _, r := range op.order {
+		v := &op.values[r.Index]
+		if r.Reachability > eps {
+			if r.Core <= eps {
+				c = len(op.centers)
+				op.centers = append(op.centers, center{point: make(point, op.dims)})
+				v.cluster = c
+			} else {
+				v.cluster = Noise
+			}
+		} else {
+			v.cluster = c
+		}
+	}
+
+	for i, v := range op.values {
+		if v.cluster == Noise {
+			continue
+		}
+		c := &op.centers[v.cluster]
+		c.indices = append(c.indices, i)
+		for j, x := range v.point {
+			c.point[j] += x * v.w
+		}
+		c.w += v.w
+	}
+	for i := range op.centers {
+		c := &op.centers[i]
+		if c.w == 0 {
+			continue
+		}
+		inv := 1 / c.w
+		for j := range c.point {
+			c.point[j] *= inv
+		}
+	}
+
+	return nil
+}
+
+// Centers returns the centers of the clusters determined by the most recent
+// extraction. The location of each center is the weighted mean of its members.
+// Noise values are not members of any center.
+func (op *OPTICS) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(op.centers))
+	for i := range op.centers {
+		cs[i] = &op.centers[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the OPTICS. Values labelled as noise
+// have a Cluster of Noise.
+func (op *OPTICS) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(op.values))
+	for i := range op.values {
+		vs[i] = &op.values[i]
+	}
+	return vs
+}
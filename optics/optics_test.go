@@ -0,0 +1,103 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package optics_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/optics"
+
+	"math"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+// Two dense groups {0, 1, 2} and {3, 4, 5} that are close relative to the sparse
+// group {6, 7, 8}, and an outlier.
+var data = Points{
+	{0}, {0.1}, {0.2},
+	{1}, {1.1}, {1.2},
+	{5}, {6}, {7},
+	{20},
+}
+
+func members(cens []cluster.Center) []cluster.Indices {
+	var m []cluster.Indices
+	for _, c := range cens {
+		m = append(m, c.Members())
+	}
+	return m
+}
+
+func (s *S) TestOPTICS(c *check.C) {
+	op, err := optics.New(data, 2, 2)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(op.Cluster(), check.Equals, nil)
+
+	r := op.Reachability()
+	c.Assert(len(r), check.Equals, len(data))
+	var order []int
+	for _, e := range r {
+		order = append(order, e.Index)
+	}
+	c.Check(order, check.DeepEquals, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	c.Check(math.IsInf(r[0].Reachability, 1), check.Equals, true)
+	c.Check(math.Abs(r[3].Reachability-0.8) < 1e-12, check.Equals, true)
+	c.Check(math.IsInf(r[9].Core, 1), check.Equals, true)
+
+	c.Check(members(op.Centers()), check.DeepEquals, []cluster.Indices{{0, 1, 2, 3, 4, 5}, {6, 7, 8}})
+	c.Check(op.Values()[9].Cluster(), check.Equals, optics.Noise)
+
+	c.Assert(op.Extract(0.5), check.Equals, nil)
+	c.Check(members(op.Centers()), check.DeepEquals, []cluster.Indices{{0, 1, 2}, {3, 4, 5}})
+	for _, i := range []int{6, 7, 8, 9} {
+		c.Check(op.Values()[i].Cluster(), check.Equals, optics.Noise)
+	}
+
+	c.Check(op.Extract(3), check.ErrorMatches, "optics: invalid eps")
+}
+
+type Weighted struct {
+	Points
+	w []float64
+}
+
+func (w Weighted) Weight(i int) float64 { return w.w[i] }
+
+func (s *S) TestWeightedCore(c *check.C) {
+	op, err := optics.New(Weighted{Points: Points{{0}, {1}, {1.5}, {10}}, w: []float64{3, 1, 1, 4}}, 2, 4)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(op.Cluster(), check.Equals, nil)
+	core := make(map[int]float64)
+	for _, r := range op.Reachability() {
+		core[r.Index] = r.Core
+	}
+	c.Check(core[0], check.Equals, 1.)
+	c.Check(core[1], check.Equals, 1.)
+	c.Check(core[2], check.Equals, 1.5)
+	c.Check(core[3], check.Equals, 0.)
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := optics.New(Points{}, 1, 1)
+	c.Check(err, check.ErrorMatches, "optics: no data")
+	_, err = optics.New(data, 0, 1)
+	c.Check(err, check.ErrorMatches, "optics: invalid eps")
+	_, err = optics.New(data, 1, 0)
+	c.Check(err, check.ErrorMatches, "optics: invalid minPts")
+	op, _ := optics.New(data, 1, 1)
+	c.Check(op.Extract(1), check.ErrorMatches, "optics: no ordering")
+}
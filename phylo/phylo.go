@@ -0,0 +1,234 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package phylo provides construction of trees with branch lengths from distance
+// matrices using the UPGMA and neighbor-joining methods.
+package phylo
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Distances is a symmetric matrix of finite pairwise distances between n elements.
+type Distances interface {
+	Len() int                  // Return the number of elements.
+	Distance(i, j int) float64 // Return the distance between elements i and j.
+}
+
+// Matrix is a dense Distances.
+type Matrix [][]float64
+
+// Len returns the number of elements in the matrix.
+func (m Matrix) Len() int { return len(m) }
+
+// Distance returns the distance between elements i and j.
+func (m Matrix) Distance(i, j int) float64 { return m[i][j] }
+
+// Node is a node in a tree. Leaf nodes have no children and refer to an element
+// of the input Distances.
+type Node struct {
+	// Leaf is the index of the element represented by a leaf node,
+	// or -1 for internal nodes.
+	Leaf int
+
+	// Length is the length of the branch to the node's parent.
+	Length float64
+
+	Children []*Node
+}
+
+// Leaves returns the indices of the leaves below n in depth-first order.
+func (n *Node) Leaves() []int {
+	if n.Leaf >= 0 {
+		return []int{n.Leaf}
+	}
+	var l []int
+	for _, c := range n.Children {
+		l = append(l, c.Leaves()...)
+	}
+	return l
+}
+
+// Newick returns the Newick format representation of the tree rooted at n. If
+// names is not nil, leaves are labelled with names[Leaf], otherwise leaves are
+// labelled with their index.
+func (n *Node) Newick(names []string) string {
+	var b strings.Builder
+	n.newick(&b, names)
+	b.WriteByte(';')
+	return b.String()
+}
+
+func (n *Node) newick(b *strings.Builder, names []string) {
+	if n.Leaf >= 0 {
+		if names != nil {
+			b.WriteString(names[n.Leaf])
+		} else {
+			b.WriteString(strconv.Itoa(n.Leaf))
+		}
+	} else {
+		b.WriteByte('(')
+		for i, c := range n.Children {
+			if i != 0 {
+				b.WriteByte(',')
+			}
+			c.newick(b, names)
+			fmt.Fprintf(b, ":%v", c.Length)
+		}
+		b.WriteByte(')')
+	}
+}
+
+// matrix returns a mutable copy of d and a set of leaf nodes for its elements.
+// The distances in d must be finite and symmetric.
+func matrix(d Distances) ([][]float64, []*Node, error) {
+	n := d.Len()
+	if n == 0 {
+		return nil, nil, &cluster.Error{Pkg: "phylo", Err: cluster.ErrEmptyData}
+	}
+	m := make([][]float64, n)
+	nodes := make([]*Node, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		for j := range m[i] {
+			m[i][j] = d.Distance(i, j)
+			if math.IsInf(m[i][j], 0) || math.IsNaN(m[i][j]) {
+				return nil, nil, errors.New("phylo: non-finite distance")
+			}
+		}
+		nodes[i] = &Node{Leaf: i}
+	}
+	for i := range m {
+		for j := 0; j < i; j++ {
+			if m[i][j] != m[j][i] {
+				return nil, nil, errors.New("phylo: asymmetric distances")
+			}
+		}
+	}
+	return m, nodes, nil
+}
+
+// UPGMA returns the rooted ultrametric tree constructed from d using the unweighted
+// pair group method with arithmetic mean. Ties are broken in favour of the pair of
+// clusters with the lowest indices.
+func UPGMA(d Distances) (*Node, error) {
+	m, nodes, err := matrix(d)
+	if err != nil {
+		return nil, err
+	}
+	n := len(m)
+	var (
+		active = make([]bool, n)
+		size   = make([]int, n)
+		height = make([]float64, n)
+	)
+	for i := range active {
+		active[i] = true
+		size[i] = 1
+	}
+	for r := n; r > 1; r-- {
+		bi, bj := -1, -1
+		min := math.Inf(1)
+		for i := range m {
+			if !active[i] {
+				continue
+			}
+			for j := i + 1; j < n; j++ {
+				if active[j] && m[i][j] < min {
+					bi, bj, min = i, j, m[i][j]
+				}
+			}
+		}
+		h := min / 2
+		nodes[bi].Length = h - height[bi]
+		nodes[bj].Length = h - height[bj]
+		nodes[bi] = &Node{Leaf: -1, Children: []*Node{nodes[bi], nodes[bj]}}
+		for k := range m {
+			if !active[k] || k == bi || k == bj {
+				continue
+			}
+			v := (m[bi][k]*float64(size[bi]) + m[bj][k]*float64(size[bj])) / float64(size[bi]+size[bj])
+			m[bi][k], m[k][bi] = v, v
+		}
+		size[bi] += size[bj]
+		height[bi] = h
+		active[bj] = false
+		nodes[bj] = nil
+	}
+	return nodes[0], nil
+}
+
+// NeighborJoining returns the unrooted tree constructed from d using the
+// neighbor-joining method of Saitou and Nei. The tree is returned rooted at the
+// final internal node, which has three children when d has at least three elements.
+// Branch lengths may be negative when d is not additive.
+func NeighborJoining(d Distances) (*Node, error) {
+	m, nodes, err := matrix(d)
+	if err != nil {
+		return nil, err
+	}
+	n := len(m)
+	switch n {
+	case 1:
+		return nodes[0], nil
+	case 2:
+		nodes[0].Length = m[0][1] / 2
+		nodes[1].Length = m[0][1] / 2
+		return &Node{Leaf: -1, Children: nodes}, nil
+	}
+
+	active := make([]int, n)
+	for i := range active {
+		active[i] = i
+	}
+	sum := make([]float64, n)
+	for r := n; r > 3; r-- {
+		for _, i := range active {
+			sum[i] = 0
+			for _, k := range active {
+				sum[i] += m[i][k]
+			}
+		}
+		bi, bj := -1, -1
+		min := math.Inf(1)
+		for a, i := range active {
+			for _, j := range active[a+1:] {
+				q := float64(r-2)*m[i][j] - sum[i] - sum[j]
+				if q < min {
+					bi, bj, min = i, j, q
+				}
+			}
+		}
+		dij := m[bi][bj]
+		nodes[bi].Length = dij/2 + (sum[bi]-sum[bj])/float64(2*(r-2))
+		nodes[bj].Length = dij - nodes[bi].Length
+		nodes[bi] = &Node{Leaf: -1, Children: []*Node{nodes[bi], nodes[bj]}}
+		for _, k := range active {
+			if k == bi || k == bj {
+				continue
+			}
+			v := (m[bi][k] + m[bj][k] - dij) / 2
+			m[bi][k], m[k][bi] = v, v
+		}
+		nodes[bj] = nil
+		for a, k := range active {
+			if k == bj {
+				active = append(active[:a], active[a+1:]...)
+				break
+			}
+		}
+	}
+
+	i, j, k := active[0], active[1], active[2]
+	nodes[i].Length = (m[i][j] + m[i][k] - m[j][k]) / 2
+	nodes[j].Length = (m[i][j] + m[j][k] - m[i][k]) / 2
+	nodes[k].Length = (m[i][k] + m[j][k] - m[i][j]) / 2
+	return &Node{Leaf: -1, Children: []*Node{nodes[i], nodes[j], nodes[k]}}, nil
+}
@@ -0,0 +1,113 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package phylo_test
+
+import (
+	"github.com/biogo/cluster/phylo"
+
+	"math"
+	"sort"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+var names = []string{"a", "b", "c", "d", "e"}
+
+// paths returns the path lengths between all pairs of leaves of the tree rooted at n.
+func paths(n *phylo.Node, size int) [][]float64 {
+	d := make([][]float64, size)
+	for i := range d {
+		d[i] = make([]float64, size)
+	}
+	// depth holds the distance from n to each leaf below it.
+	var walk func(n *phylo.Node) map[int]float64
+	walk = func(n *phylo.Node) map[int]float64 {
+		if n.Leaf >= 0 {
+			return map[int]float64{n.Leaf: 0}
+		}
+		var subs []map[int]float64
+		for _, c := range n.Children {
+			s := walk(c)
+			for l := range s {
+				s[l] += c.Length
+			}
+			subs = append(subs, s)
+		}
+		all := make(map[int]float64)
+		for a, s := range subs {
+			for _, t := range subs[a+1:] {
+				for i, di := range s {
+					for j, dj := range t {
+						d[i][j] = di + dj
+						d[j][i] = di + dj
+					}
+				}
+			}
+			for l, v := range s {
+				all[l] = v
+			}
+		}
+		return all
+	}
+	walk(n)
+	return d
+}
+
+func (s *S) TestUPGMA(c *check.C) {
+	m := phylo.Matrix{
+		{0, 17, 21, 31, 23},
+		{17, 0, 30, 34, 21},
+		{21, 30, 0, 28, 39},
+		{31, 34, 28, 0, 43},
+		{23, 21, 39, 43, 0},
+	}
+	t, err := phylo.UPGMA(m)
+	c.Assert(err, check.Equals, nil)
+	c.Check(t.Newick(names), check.Equals, "(((a:8.5,b:8.5):2.5,e:11):5.5,(c:14,d:14):2.5);")
+	l := t.Leaves()
+	sort.Ints(l)
+	c.Check(l, check.DeepEquals, []int{0, 1, 2, 3, 4})
+}
+
+func (s *S) TestNeighborJoining(c *check.C) {
+	m := phylo.Matrix{
+		{0, 5, 9, 9, 8},
+		{5, 0, 10, 10, 9},
+		{9, 10, 0, 8, 7},
+		{9, 10, 8, 0, 3},
+		{8, 9, 7, 3, 0},
+	}
+	t, err := phylo.NeighborJoining(m)
+	c.Assert(err, check.Equals, nil)
+	c.Check(len(t.Children), check.Equals, 3)
+
+	// The matrix is additive, so the tree reproduces it exactly.
+	got := paths(t, len(m))
+	for i := range m {
+		for j := range m {
+			c.Check(math.Abs(got[i][j]-m[i][j]) < 1e-12, check.Equals, true, check.Commentf("%d-%d", i, j))
+		}
+	}
+	c.Check(t.Newick(nil), check.Equals, "(((0:2,1:3):3,2:4):2,3:2,4:1);")
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := phylo.UPGMA(phylo.Matrix{})
+	c.Check(err, check.ErrorMatches, "phylo: no data")
+	_, err = phylo.NeighborJoining(phylo.Matrix{{0, 1}, {2, 0}})
+	c.Check(err, check.ErrorMatches, "phylo: asymmetric distances")
+	inf := math.Inf(1)
+	_, err = phylo.UPGMA(phylo.Matrix{{0, inf, inf}, {inf, 0, inf}, {inf, inf, 0}})
+	c.Check(err, check.ErrorMatches, "phylo: non-finite distance")
+	_, err = phylo.NeighborJoining(phylo.Matrix{{0, 1, math.NaN()}, {1, 0, 1}, {math.NaN(), 1, 0}})
+	c.Check(err, check.ErrorMatches, "phylo: non-finite distance")
+}
@@ -0,0 +1,139 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocess
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+	"sort"
+)
+
+// FeatureFilter is a cluster.Interface that presents a subset of the dimensions of
+// an underlying Interface. Near-constant dimensions carry no information about
+// cluster structure but still contribute to distances, so removing them prior to
+// clustering can improve results.
+type FeatureFilter struct {
+	data cluster.Interface
+	w    cluster.Weighter
+	cols []int
+}
+
+// NewVarianceFilter returns a FeatureFilter that retains the dimensions of data with
+// a variance of at least min.
+func NewVarianceFilter(data cluster.Interface, min float64) (*FeatureFilter, error) {
+	return newFilter(data, min, variance)
+}
+
+// NewMADFilter returns a FeatureFilter that retains the dimensions of data with a
+// median absolute deviation of at least min.
+func NewMADFilter(data cluster.Interface, min float64) (*FeatureFilter, error) {
+	return newFilter(data, min, mad)
+}
+
+func newFilter(data cluster.Interface, min float64, stat func([]float64) float64) (*FeatureFilter, error) {
+	cols, err := columns(data)
+	if err != nil {
+		return nil, err
+	}
+	var keep []int
+	for j, col := range cols {
+		if stat(col) >= min {
+			keep = append(keep, j)
+		}
+	}
+	if len(keep) == 0 {
+		return nil, errors.New("preprocess: no features retained")
+	}
+	w, _ := data.(cluster.Weighter)
+	return &FeatureFilter{data: data, w: w, cols: keep}, nil
+}
+
+// columns returns the values of data in column-major order.
+func columns(data cluster.Interface) ([][]float64, error) {
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "preprocess", Err: cluster.ErrEmptyData}
+	}
+	dim := len(data.Values(0))
+	cols := make([][]float64, dim)
+	for j := range cols {
+		cols[j] = make([]float64, data.Len())
+	}
+	for i := 0; i < data.Len(); i++ {
+		vec := data.Values(i)
+		if len(vec) != dim {
+			return nil, &cluster.Error{Pkg: "preprocess", Err: cluster.ErrDimensionMismatch}
+		}
+		for j, v := range vec {
+			cols[j][i] = v
+		}
+	}
+	return cols, nil
+}
+
+func mean(x []float64) float64 {
+	var sum float64
+	for _, v := range x {
+		sum += v
+	}
+	return sum / float64(len(x))
+}
+
+func variance(x []float64) float64 {
+	m := mean(x)
+	var ss float64
+	for _, v := range x {
+		d := v - m
+		ss += d * d
+	}
+	return ss / float64(len(x))
+}
+
+// median returns the median of x. The order of elements in x is altered.
+func median(x []float64) float64 {
+	sort.Float64s(x)
+	n := len(x)
+	if n%2 == 1 {
+		return x[n/2]
+	}
+	return (x[n/2-1] + x[n/2]) / 2
+}
+
+func mad(x []float64) float64 {
+	d := append([]float64(nil), x...)
+	m := median(d)
+	for i, v := range x {
+		d[i] = math.Abs(v - m)
+	}
+	return median(d)
+}
+
+// Columns returns the indices of the dimensions of the underlying data that are
+// retained by the filter. Dimension j of the filtered data corresponds to
+// dimension Columns()[j] of the underlying data.
+func (f *FeatureFilter) Columns() []int { return f.cols }
+
+// Len returns the number of elements in the underlying data.
+func (f *FeatureFilter) Len() int { return f.data.Len() }
+
+// Values returns the retained dimensions of element i of the underlying data.
+func (f *FeatureFilter) Values(i int) []float64 {
+	v := f.data.Values(i)
+	y := make([]float64, len(f.cols))
+	for k, j := range f.cols {
+		y[k] = v[j]
+	}
+	return y
+}
+
+// Weight returns the weight of element i of the underlying data, or 1 if the
+// underlying data does not satisfy cluster.Weighter.
+func (f *FeatureFilter) Weight(i int) float64 {
+	if f.w == nil {
+		return 1
+	}
+	return f.w.Weight(i)
+}
@@ -0,0 +1,126 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocess
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// PCA is a cluster.Interface that presents the values of an underlying Interface
+// projected onto their leading principal components. In high dimensions, such
+// as for k-mer count profiles, distances concentrate and spatial indexes lose
+// their ability to prune, so reducing the dimension before clustering improves
+// both speed and the quality of neighbor queries. Projected values are
+// calculated on demand when Values is called.
+type PCA struct {
+	data cluster.Interface
+	w    cluster.Weighter
+	mean []float64
+	vecs [][]float64
+	vars []float64
+}
+
+// NewPCA returns a PCA of data onto its first d principal components, found by
+// singular value decomposition of the centered data. The value of d must not be
+// greater than the number of elements or the dimension of data.
+func NewPCA(data cluster.Interface, d int) (*PCA, error) {
+	cols, err := columns(data)
+	if err != nil {
+		return nil, err
+	}
+	n, dim := data.Len(), len(cols)
+	if d < 1 || d > n || d > dim {
+		return nil, errors.New("preprocess: invalid dimension")
+	}
+
+	m := make([]float64, dim)
+	a := mat.NewDense(n, dim, nil)
+	for j, col := range cols {
+		m[j] = mean(col)
+		for i, v := range col {
+			a.Set(i, j, v-m[j])
+		}
+	}
+	var svd mat.SVD
+	if !svd.Factorize(a, mat.SVDThin) {
+		return nil, errors.New("preprocess: decomposition failed")
+	}
+	var v mat.Dense
+	svd.VTo(&v)
+	sv := svd.Values(nil)
+
+	vecs := make([][]float64, d)
+	vars := make([]float64, d)
+	for k := range vecs {
+		vecs[k] = mat.Col(nil, k, &v)
+		vars[k] = sv[k] * sv[k] / float64(n)
+	}
+	w, _ := data.(cluster.Weighter)
+	return &PCA{data: data, w: w, mean: m, vecs: vecs, vars: vars}, nil
+}
+
+// Mean returns the mean of the underlying data.
+func (p *PCA) Mean() []float64 { return p.mean }
+
+// Components returns the principal component vectors in order of decreasing
+// variance. Dimension k of the projected data is the projection of the centered
+// underlying data onto Components()[k].
+func (p *PCA) Components() [][]float64 { return p.vecs }
+
+// Variances returns the variance of the underlying data along each principal
+// component.
+func (p *PCA) Variances() []float64 { return p.vars }
+
+// Len returns the number of elements in the underlying data.
+func (p *PCA) Len() int { return p.data.Len() }
+
+// Values returns the projection of element i of the underlying data.
+func (p *PCA) Values(i int) []float64 {
+	v := p.data.Values(i)
+	y := make([]float64, len(p.vecs))
+	for k, vec := range p.vecs {
+		for j, x := range v {
+			y[k] += vec[j] * (x - p.mean[j])
+		}
+	}
+	return y
+}
+
+// Weight returns the weight of element i of the underlying data, or 1 if the
+// underlying data does not satisfy cluster.Weighter.
+func (p *PCA) Weight(i int) float64 {
+	if p.w == nil {
+		return 1
+	}
+	return p.w.Weight(i)
+}
+
+// Inverse returns the point in the space of the underlying data corresponding to
+// the projected point y. Components of the underlying data that are orthogonal to
+// the retained principal components are not recovered.
+func (p *PCA) Inverse(y []float64) []float64 {
+	x := append([]float64(nil), p.mean...)
+	for k, vec := range p.vecs {
+		for j, v := range vec {
+			x[j] += y[k] * v
+		}
+	}
+	return x
+}
+
+// InverseCenters returns the locations of the centers found by clustering the
+// projected data in the space of the underlying data.
+func (p *PCA) InverseCenters(c cluster.Clusterer) [][]float64 {
+	cens := c.Centers()
+	x := make([][]float64, len(cens))
+	for i, cen := range cens {
+		x[i] = p.Inverse(cen.V())
+	}
+	return x
+}
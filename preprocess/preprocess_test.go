@@ -0,0 +1,288 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocess_test
+
+import (
+	"github.com/biogo/cluster/ckmeans"
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/preprocess"
+
+	"math"
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type matrix [][]float64
+
+func (m matrix) Len() int               { return len(m) }
+func (m matrix) Values(i int) []float64 { return m[i] }
+
+type weighted struct {
+	matrix
+	w []float64
+}
+
+func (m weighted) Weight(i int) float64 { return m.w[i] }
+
+func dist(a, b []float64) float64 {
+	var ss float64
+	for i := range a {
+		d := a[i] - b[i]
+		ss += d * d
+	}
+	return math.Sqrt(ss)
+}
+
+func (s *S) TestRandomProjection(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	m := make(matrix, 20)
+	for i := range m {
+		m[i] = make([]float64, 2000)
+		for j := range m[i] {
+			m[i][j] = rnd.NormFloat64()
+		}
+	}
+	p, err := preprocess.NewRandomProjection(m, 500, rnd)
+	c.Assert(err, check.Equals, nil)
+	c.Check(p.Len(), check.Equals, len(m))
+	c.Check(len(p.Values(0)), check.Equals, 500)
+	for i := range m {
+		for j := 0; j < i; j++ {
+			r := dist(p.Values(i), p.Values(j)) / dist(m[i], m[j])
+			c.Check(math.Abs(r-1) < 0.2, check.Equals, true, check.Commentf("distortion %f for %d,%d", r, i, j))
+		}
+	}
+	c.Check(p.Weight(0), check.Equals, 1.)
+
+	w := weighted{matrix: m, w: make([]float64, len(m))}
+	w.w[3] = 7
+	p, err = preprocess.NewRandomProjection(w, 10, rnd)
+	c.Assert(err, check.Equals, nil)
+	c.Check(p.Weight(3), check.Equals, 7.)
+
+	_, err = preprocess.NewRandomProjection(matrix{}, 10, nil)
+	c.Check(err, check.ErrorMatches, "preprocess: no data")
+	_, err = preprocess.NewRandomProjection(m, 0, nil)
+	c.Check(err, check.ErrorMatches, "preprocess: invalid dimension")
+}
+
+func (s *S) TestFeatureFilter(c *check.C) {
+	m := matrix{
+		{1, 5, 0, 10},
+		{2, 5, 0, 10},
+		{3, 5, 0, 100},
+		{4, 5.1, 0, 10},
+		{5, 5, 0, 10},
+	}
+	f, err := preprocess.NewVarianceFilter(m, 0.01)
+	c.Assert(err, check.Equals, nil)
+	c.Check(f.Columns(), check.DeepEquals, []int{0, 3})
+	c.Check(f.Len(), check.Equals, len(m))
+	c.Check(f.Values(2), check.DeepEquals, []float64{3, 100})
+	c.Check(f.Weight(2), check.Equals, 1.)
+
+	f, err = preprocess.NewMADFilter(m, 0.01)
+	c.Assert(err, check.Equals, nil)
+	c.Check(f.Columns(), check.DeepEquals, []int{0})
+	c.Check(f.Values(3), check.DeepEquals, []float64{4})
+
+	_, err = preprocess.NewVarianceFilter(m, 1e6)
+	c.Check(err, check.ErrorMatches, "preprocess: no features retained")
+	_, err = preprocess.NewMADFilter(matrix{{1, 2}, {1}}, 0)
+	c.Check(err, check.ErrorMatches, "preprocess: mismatched dimensions")
+}
+
+func (s *S) TestScalers(c *check.C) {
+	m := matrix{{0, 5}, {1, 5}, {2, 5}, {3, 5}}
+	for _, t := range []struct {
+		new        func(data cluster.Interface) (*preprocess.Scaler, error)
+		loc, scale []float64
+	}{
+		{new: preprocess.NewZScore, loc: []float64{1.5, 5}, scale: []float64{math.Sqrt(1.25), 1}},
+		{new: preprocess.NewMinMax, loc: []float64{0, 5}, scale: []float64{3, 1}},
+		{new: preprocess.NewRobust, loc: []float64{1.5, 5}, scale: []float64{1, 1}},
+	} {
+		sc, err := t.new(m)
+		c.Assert(err, check.Equals, nil)
+		c.Check(sc.Location(), check.DeepEquals, t.loc)
+		c.Check(sc.Scale(), check.DeepEquals, t.scale)
+		c.Check(sc.Len(), check.Equals, len(m))
+		for i := range m {
+			v := sc.Values(i)
+			c.Check(v[0], check.Equals, (m[i][0]-t.loc[0])/t.scale[0])
+			c.Check(v[1], check.Equals, 0.)
+			c.Check(sc.Inverse(v), check.DeepEquals, m[i])
+		}
+	}
+
+	w := weighted{matrix: matrix{{0}, {1}, {10}, {11}}, w: []float64{1, 2, 3, 4}}
+	sc, err := preprocess.NewMinMax(w)
+	c.Assert(err, check.Equals, nil)
+	c.Check(sc.Weight(2), check.Equals, 3.)
+	km, err := ckmeans.New(sc, 2)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	cens := sc.InverseCenters(km)
+	c.Assert(len(cens), check.Equals, 2)
+	c.Check(math.Abs(cens[0][0]-2./3) < 1e-12, check.Equals, true)
+	c.Check(math.Abs(cens[1][0]-(30+44)/7.) < 1e-12, check.Equals, true)
+
+	_, err = preprocess.NewZScore(matrix{})
+	c.Check(err, check.ErrorMatches, "preprocess: no data")
+}
+
+// blobs returns n elements around each of the origin and the point with all of
+// dim coordinates equal to 10, drawn using rnd.
+func blobs(rnd *rand.Rand, n, dim int) matrix {
+	m := make(matrix, 2*n)
+	for i := range m {
+		m[i] = make([]float64, dim)
+		for j := range m[i] {
+			m[i][j] = float64(10*(i/n)) + rnd.NormFloat64()
+		}
+	}
+	return m
+}
+
+func (s *S) TestPCA(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var m matrix
+	for i := 0; i < 100; i++ {
+		t := rnd.NormFloat64()
+		m = append(m, []float64{1 + t + 0.01*rnd.NormFloat64(), 2*t + 0.01*rnd.NormFloat64()})
+	}
+	p, err := preprocess.NewPCA(m, 1)
+	c.Assert(err, check.Equals, nil)
+	c.Check(p.Len(), check.Equals, len(m))
+	c.Check(len(p.Values(0)), check.Equals, 1)
+	v := p.Components()[0]
+	c.Check(math.Abs(math.Abs(v[0])-1/math.Sqrt(5)) < 1e-3, check.Equals, true, check.Commentf("%v", v))
+	c.Check(math.Abs(math.Abs(v[1])-2/math.Sqrt(5)) < 1e-3, check.Equals, true, check.Commentf("%v", v))
+	x := p.Inverse(p.Values(7))
+	c.Check(dist(x, m[7]) < 0.05, check.Equals, true)
+
+	p, err = preprocess.NewPCA(m, 2)
+	c.Assert(err, check.Equals, nil)
+	vars := p.Variances()
+	c.Check(vars[0] > 1000*vars[1], check.Equals, true)
+	c.Check(dist(p.Inverse(p.Values(7)), m[7]) < 1e-12, check.Equals, true)
+
+	m = blobs(rand.New(rand.NewSource(1)), 50, 20)
+	p, err = preprocess.NewPCA(m, 1)
+	c.Assert(err, check.Equals, nil)
+	km, err := ckmeans.New(p, 2)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	for _, cen := range p.InverseCenters(km) {
+		x := math.Round(cen[0]/10) * 10
+		for _, v := range cen {
+			c.Check(math.Abs(v-x) < 1, check.Equals, true)
+		}
+	}
+
+	_, err = preprocess.NewPCA(m, 21)
+	c.Check(err, check.ErrorMatches, "preprocess: invalid dimension")
+}
+
+func (s *S) TestRandomProjectionInverse(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	m := blobs(rnd, 50, 20)
+	w := weighted{matrix: m, w: make([]float64, len(m))}
+	for i := range w.w {
+		w.w[i] = 1
+	}
+	w.w[0] = 0
+	m[0][0] = 1e6
+	p, err := preprocess.NewRandomProjection(w, 1, rnd)
+	c.Assert(err, check.Equals, nil)
+	km, err := ckmeans.New(p, 2)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	for i, cen := range p.InverseCenters(km) {
+		want := weightedMean(m, km.Centers()[i].Members(), w.w)
+		c.Check(dist(cen, want) < 1e-9, check.Equals, true)
+		x := math.Round(cen[1]/10) * 10
+		for _, v := range cen {
+			c.Check(math.Abs(v-x) < 1, check.Equals, true)
+		}
+	}
+}
+
+// weightedMean returns the weighted mean of the elements of m indexed by idx.
+func weightedMean(m matrix, idx []int, w []float64) []float64 {
+	x := make([]float64, len(m[0]))
+	var sw float64
+	for _, i := range idx {
+		for j, v := range m[i] {
+			x[j] += w[i] * v
+		}
+		sw += w[i]
+	}
+	for j := range x {
+		x[j] /= sw
+	}
+	return x
+}
+
+func (s *S) TestWhitener(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var m matrix
+	for i := 0; i < 200; i++ {
+		a, b, u := rnd.NormFloat64(), rnd.NormFloat64(), rnd.NormFloat64()
+		m = append(m, []float64{5 + 3*a, -2 + 2*a + 0.5*b, 1 + b + 0.5*u})
+	}
+	wh, err := preprocess.NewWhitener(m, 0)
+	c.Assert(err, check.Equals, nil)
+	c.Check(wh.Len(), check.Equals, len(m))
+
+	y := make(matrix, len(m))
+	for i := range y {
+		y[i] = wh.Values(i)
+		c.Check(dist(wh.Inverse(y[i]), m[i]) < 1e-9, check.Equals, true)
+	}
+	for j := 0; j < 3; j++ {
+		for k := 0; k < 3; k++ {
+			var mj, mk, s float64
+			for _, v := range y {
+				mj += v[j]
+				mk += v[k]
+				s += v[j] * v[k]
+			}
+			n := float64(len(y))
+			cov := s/n - mj/n*mk/n
+			want := 0.
+			if j == k {
+				want = 1
+			}
+			c.Check(math.Abs(cov-want) < 1e-9, check.Equals, true, check.Commentf("cov[%d][%d]=%f", j, k, cov))
+		}
+	}
+
+	w := weighted{matrix: matrix{{0}, {1}, {10}, {11}}, w: []float64{1, 1, 1, 1}}
+	wh, err = preprocess.NewWhitener(w, 0)
+	c.Assert(err, check.Equals, nil)
+	c.Check(wh.Weight(0), check.Equals, 1.)
+	km, err := ckmeans.New(wh, 2)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(km.Cluster(), check.Equals, nil)
+	cens := wh.InverseCenters(km)
+	c.Check(math.Abs(cens[0][0]-0.5) < 1e-12, check.Equals, true)
+	c.Check(math.Abs(cens[1][0]-10.5) < 1e-12, check.Equals, true)
+
+	_, err = preprocess.NewWhitener(matrix{{1, 2}, {2, 4}, {3, 6}}, 0)
+	c.Check(err, check.ErrorMatches, "preprocess: singular covariance")
+	_, err = preprocess.NewWhitener(matrix{{1, 2}, {2, 4}, {3, 6}}, 1e-6)
+	c.Check(err, check.Equals, nil)
+	_, err = preprocess.NewWhitener(m, -1)
+	c.Check(err, check.ErrorMatches, "preprocess: invalid regularization")
+}
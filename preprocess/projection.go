@@ -0,0 +1,114 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package preprocess provides wrappers that transform a cluster.Interface before clustering.
+package preprocess
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// entry is a non-zero element of a sparse projection matrix row.
+type entry struct {
+	col int
+	v   float64
+}
+
+// RandomProjection is a cluster.Interface that maps the values of an underlying
+// Interface into a lower dimensional space using a sparse Johnson-Lindenstrauss
+// random projection as described by Achlioptas. Projected values are calculated
+// on demand when Values is called.
+type RandomProjection struct {
+	data cluster.Interface
+	w    cluster.Weighter
+	rows [][]entry
+}
+
+// NewRandomProjection returns a RandomProjection of data into d dimensions. Each
+// element of the projection matrix is ±√(3/d) with probability 1/6 each and zero
+// otherwise, so pairwise Euclidean distances are preserved in expectation. The
+// projection matrix is drawn from rnd, or from the global math/rand source if rnd
+// is nil.
+func NewRandomProjection(data cluster.Interface, d int, rnd *rand.Rand) (*RandomProjection, error) {
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "preprocess", Err: cluster.ErrEmptyData}
+	}
+	if d < 1 {
+		return nil, errors.New("preprocess: invalid dimension")
+	}
+	dim := len(data.Values(0))
+	intn := rand.Intn
+	if rnd != nil {
+		intn = rnd.Intn
+	}
+	s := math.Sqrt(3 / float64(d))
+	rows := make([][]entry, d)
+	for i := range rows {
+		for j := 0; j < dim; j++ {
+			switch intn(6) {
+			case 0:
+				rows[i] = append(rows[i], entry{col: j, v: s})
+			case 1:
+				rows[i] = append(rows[i], entry{col: j, v: -s})
+			}
+		}
+	}
+	w, _ := data.(cluster.Weighter)
+	return &RandomProjection{data: data, w: w, rows: rows}, nil
+}
+
+// Len returns the number of elements in the underlying data.
+func (p *RandomProjection) Len() int { return p.data.Len() }
+
+// Values returns the projection of element i of the underlying data.
+func (p *RandomProjection) Values(i int) []float64 {
+	v := p.data.Values(i)
+	y := make([]float64, len(p.rows))
+	for k, row := range p.rows {
+		for _, e := range row {
+			y[k] += e.v * v[e.col]
+		}
+	}
+	return y
+}
+
+// Weight returns the weight of element i of the underlying data, or 1 if the
+// underlying data does not satisfy cluster.Weighter.
+func (p *RandomProjection) Weight(i int) float64 {
+	if p.w == nil {
+		return 1
+	}
+	return p.w.Weight(i)
+}
+
+// InverseCenters returns the weighted mean of the underlying values of the members
+// of each of the centers found by clustering the projected data. A random
+// projection is not invertible, so the locations of the centers themselves can
+// not be mapped back to the space of the underlying data.
+func (p *RandomProjection) InverseCenters(c cluster.Clusterer) [][]float64 {
+	cens := c.Centers()
+	x := make([][]float64, len(cens))
+	for i, cen := range cens {
+		var sw float64
+		for _, j := range cen.Members() {
+			v := p.data.Values(j)
+			if x[i] == nil {
+				x[i] = make([]float64, len(v))
+			}
+			w := p.Weight(j)
+			for k, f := range v {
+				x[i][k] += w * f
+			}
+			sw += w
+		}
+		for k := range x[i] {
+			x[i][k] /= sw
+		}
+	}
+	return x
+}
@@ -0,0 +1,120 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocess
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"math"
+)
+
+// Scaler is a cluster.Interface that presents the values of an underlying Interface
+// with each dimension j transformed to (x-Location()[j])/Scale()[j]. Dimensions
+// with differing scales contribute unequally to Euclidean distances, so that the
+// dimensions with the largest spread dominate a clustering unless they are scaled.
+// Dimensions with zero spread are centered but not scaled. Values calculated by
+// clustering the scaled data, such as centers, may be returned to the units of
+// the underlying data using Inverse.
+type Scaler struct {
+	data       cluster.Interface
+	w          cluster.Weighter
+	loc, scale []float64
+}
+
+// NewZScore returns a Scaler that standardises each dimension of data to zero mean
+// and unit standard deviation.
+func NewZScore(data cluster.Interface) (*Scaler, error) {
+	return newScaler(data, func(x []float64) (loc, scale float64) {
+		return mean(x), math.Sqrt(variance(x))
+	})
+}
+
+// NewMinMax returns a Scaler that maps each dimension of data onto [0, 1].
+func NewMinMax(data cluster.Interface) (*Scaler, error) {
+	return newScaler(data, func(x []float64) (loc, scale float64) {
+		min, max := x[0], x[0]
+		for _, v := range x[1:] {
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+		return min, max - min
+	})
+}
+
+// NewRobust returns a Scaler that centers each dimension of data on its median and
+// scales it by its median absolute deviation. Robust scaling is insensitive to
+// outlying values.
+func NewRobust(data cluster.Interface) (*Scaler, error) {
+	return newScaler(data, func(x []float64) (loc, scale float64) {
+		return median(append([]float64(nil), x...)), mad(x)
+	})
+}
+
+func newScaler(data cluster.Interface, stat func([]float64) (loc, scale float64)) (*Scaler, error) {
+	cols, err := columns(data)
+	if err != nil {
+		return nil, err
+	}
+	loc := make([]float64, len(cols))
+	scale := make([]float64, len(cols))
+	for j, col := range cols {
+		loc[j], scale[j] = stat(col)
+		if scale[j] == 0 {
+			scale[j] = 1
+		}
+	}
+	w, _ := data.(cluster.Weighter)
+	return &Scaler{data: data, w: w, loc: loc, scale: scale}, nil
+}
+
+// Location returns the location subtracted from each dimension.
+func (s *Scaler) Location() []float64 { return s.loc }
+
+// Scale returns the divisor applied to each dimension after subtraction of its
+// location.
+func (s *Scaler) Scale() []float64 { return s.scale }
+
+// Len returns the number of elements in the underlying data.
+func (s *Scaler) Len() int { return s.data.Len() }
+
+// Values returns the scaled values of element i of the underlying data.
+func (s *Scaler) Values(i int) []float64 {
+	v := s.data.Values(i)
+	y := make([]float64, len(v))
+	for j, x := range v {
+		y[j] = (x - s.loc[j]) / s.scale[j]
+	}
+	return y
+}
+
+// Weight returns the weight of element i of the underlying data, or 1 if the
+// underlying data does not satisfy cluster.Weighter.
+func (s *Scaler) Weight(i int) float64 {
+	if s.w == nil {
+		return 1
+	}
+	return s.w.Weight(i)
+}
+
+// Inverse returns the point in the units of the underlying data corresponding to
+// the scaled point p.
+func (s *Scaler) Inverse(p []float64) []float64 {
+	x := make([]float64, len(p))
+	for j, y := range p {
+		x[j] = y*s.scale[j] + s.loc[j]
+	}
+	return x
+}
+
+// InverseCenters returns the locations of the centers found by clustering the
+// scaled data in the units of the underlying data.
+func (s *Scaler) InverseCenters(c cluster.Clusterer) [][]float64 {
+	cens := c.Centers()
+	x := make([][]float64, len(cens))
+	for i, cen := range cens {
+		x[i] = s.Inverse(cen.V())
+	}
+	return x
+}
@@ -0,0 +1,142 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package preprocess
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Whitener is a cluster.Interface that presents the values of an underlying
+// Interface decorrelated and scaled to unit variance in every direction. Clusterers
+// with isotropic kernels or Euclidean distances, such as mean shift, respond
+// poorly to elongated correlated clusters; after whitening, the covariance of the
+// data is the identity. The zero-phase (ZCA) transform is used, so whitened
+// dimensions remain as close as possible to the corresponding dimensions of the
+// underlying data. Whitened values are calculated on demand when Values is
+// called.
+type Whitener struct {
+	data cluster.Interface
+	w    cluster.Weighter
+	mean []float64
+	fwd  [][]float64
+	inv  [][]float64
+}
+
+// NewWhitener returns a Whitener for data. The regularization eps is added to each
+// eigenvalue of the covariance of data before inversion, limiting the amplification
+// of directions with little variance. NewWhitener returns an error if the
+// regularized covariance is singular or nearly so.
+func NewWhitener(data cluster.Interface, eps float64) (*Whitener, error) {
+	cols, err := columns(data)
+	if err != nil {
+		return nil, err
+	}
+	if eps < 0 {
+		return nil, errors.New("preprocess: invalid regularization")
+	}
+	n, dim := data.Len(), len(cols)
+
+	m := make([]float64, dim)
+	for j, col := range cols {
+		m[j] = mean(col)
+	}
+	cov := mat.NewSymDense(dim, nil)
+	for j := 0; j < dim; j++ {
+		for k := j; k < dim; k++ {
+			var s float64
+			for i, v := range cols[j] {
+				s += (v - m[j]) * (cols[k][i] - m[k])
+			}
+			cov.SetSym(j, k, s/float64(n))
+		}
+	}
+	var eig mat.EigenSym
+	if !eig.Factorize(cov, true) {
+		return nil, errors.New("preprocess: decomposition failed")
+	}
+	var vecs mat.Dense
+	eig.VectorsTo(&vecs)
+	vals := eig.Values(nil)
+
+	fwd := make([][]float64, dim)
+	inv := make([][]float64, dim)
+	for j := range fwd {
+		fwd[j] = make([]float64, dim)
+		inv[j] = make([]float64, dim)
+	}
+	// Eigenvalues are in ascending order.
+	tol := 1e-12 * (vals[len(vals)-1] + eps)
+	for l, v := range vals {
+		v += eps
+		if v <= tol {
+			return nil, errors.New("preprocess: singular covariance")
+		}
+		s := math.Sqrt(v)
+		for j := 0; j < dim; j++ {
+			for k := 0; k < dim; k++ {
+				e := vecs.At(j, l) * vecs.At(k, l)
+				fwd[j][k] += e / s
+				inv[j][k] += e * s
+			}
+		}
+	}
+	w, _ := data.(cluster.Weighter)
+	return &Whitener{data: data, w: w, mean: m, fwd: fwd, inv: inv}, nil
+}
+
+// Mean returns the mean of the underlying data.
+func (wh *Whitener) Mean() []float64 { return wh.mean }
+
+// Len returns the number of elements in the underlying data.
+func (wh *Whitener) Len() int { return wh.data.Len() }
+
+// Values returns the whitened values of element i of the underlying data.
+func (wh *Whitener) Values(i int) []float64 {
+	v := wh.data.Values(i)
+	y := make([]float64, len(v))
+	for j, row := range wh.fwd {
+		for k, x := range v {
+			y[j] += row[k] * (x - wh.mean[k])
+		}
+	}
+	return y
+}
+
+// Weight returns the weight of element i of the underlying data, or 1 if the
+// underlying data does not satisfy cluster.Weighter.
+func (wh *Whitener) Weight(i int) float64 {
+	if wh.w == nil {
+		return 1
+	}
+	return wh.w.Weight(i)
+}
+
+// Inverse returns the point in the units of the underlying data corresponding to
+// the whitened point y.
+func (wh *Whitener) Inverse(y []float64) []float64 {
+	x := append([]float64(nil), wh.mean...)
+	for j, row := range wh.inv {
+		for k, v := range y {
+			x[j] += row[k] * v
+		}
+	}
+	return x
+}
+
+// InverseCenters returns the locations of the centers found by clustering the
+// whitened data in the units of the underlying data.
+func (wh *Whitener) InverseCenters(c cluster.Clusterer) [][]float64 {
+	cens := c.Centers()
+	x := make([][]float64, len(cens))
+	for i, cen := range cens {
+		x[i] = wh.Inverse(cen.V())
+	}
+	return x
+}
@@ -0,0 +1,181 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package qt implements quality threshold clustering.
+//
+// Reference:
+//
+//	Heyer LJ, Kruglyak S, Yooseph S. Exploring expression data: identification and
+//	analysis of coexpressed genes. Genome Research 9(11):1106-1115 (1999).
+package qt
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/distcache"
+
+	"errors"
+	"math"
+	"sort"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	cluster int
+}
+
+func (v *value) Cluster() int { return v.cluster }
+
+type center struct {
+	point
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// values is a collection of value that satisfies cluster.Interface.
+type values []value
+
+func (v values) Len() int               { return len(v) }
+func (v values) Values(i int) []float64 { return v[i].point }
+
+// QT implements quality threshold clustering. A candidate cluster is grown from
+// each unclustered value by repeatedly adding the value that least increases the
+// candidate's diameter, the greatest distance between any two members, until no
+// value can be added without the diameter exceeding the threshold. The largest
+// candidate is accepted as a cluster and the process is repeated on the remaining
+// values. Every cluster found has a diameter no greater than the threshold.
+type QT struct {
+	diameter float64
+	metric   cluster.Metric
+	values   values
+	centers  []center
+}
+
+// New creates a new QT Clusterer object populated with data from an Interface
+// value, data. Clusters will have a diameter of at most diameter under the metric
+// m. If m is nil, Euclidean distance is used.
+func New(data cluster.Interface, diameter float64, m cluster.Metric) (*QT, error) {
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "qt", Err: cluster.ErrEmptyData}
+	}
+	if diameter < 0 {
+		return nil, errors.New("qt: invalid diameter")
+	}
+	if m == nil {
+		m = cluster.Euclidean
+	}
+	dim := len(data.Values(0))
+	va := make(values, data.Len())
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dim {
+			return nil, &cluster.Error{Pkg: "qt", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...)}
+	}
+	return &QT{diameter: diameter, metric: m, values: va}, nil
+}
+
+// Cluster runs a clustering of the data. Cluster makes O(n²) distance calculations
+// and takes O(n³) time for each cluster found.
+func (q *QT) Cluster() error {
+	n := len(q.values)
+	dm := distcache.NewMatrix(q.values, q.metric)
+
+	remaining := make([]int, n)
+	for i := range remaining {
+		remaining[i] = i
+	}
+	q.centers = q.centers[:0]
+	// far holds the greatest distance from each remaining value to the
+	// members of the candidate being grown.
+	far := make([]float64, n)
+	in := make([]bool, n)
+	for len(remaining) != 0 {
+		var best []int
+		for _, seed := range remaining {
+			cand := []int{seed}
+			for _, i := range remaining {
+				far[i] = dm.Distance(seed, i)
+				in[i] = false
+			}
+			in[seed] = true
+			for {
+				next, min := -1, math.Inf(1)
+				for _, i := range remaining {
+					if !in[i] && far[i] <= q.diameter && far[i] < min {
+						next, min = i, far[i]
+					}
+				}
+				if next < 0 {
+					break
+				}
+				in[next] = true
+				cand = append(cand, next)
+				for _, i := range remaining {
+					if !in[i] {
+						far[i] = math.Max(far[i], dm.Distance(next, i))
+					}
+				}
+			}
+			if len(cand) > len(best) {
+				best = cand
+			}
+		}
+
+		sort.Ints(best)
+		c := len(q.centers)
+		cen := center{point: make(point, len(q.values[0].point)), indices: best}
+		for _, i := range best {
+			q.values[i].cluster = c
+			for j, x := range q.values[i].point {
+				cen.point[j] += x
+			}
+		}
+		inv := 1 / float64(len(best))
+		for j := range cen.point {
+			cen.point[j] *= inv
+		}
+		q.centers = append(q.centers, cen)
+
+		for _, i := range remaining {
+			in[i] = false
+		}
+		for _, i := range best {
+			in[i] = true
+		}
+		r := remaining[:0]
+		for _, i := range remaining {
+			if !in[i] {
+				r = append(r, i)
+			}
+		}
+		remaining = r
+	}
+
+	return nil
+}
+
+// Centers returns the centers determined by a previous call to Cluster, in order
+// of acceptance. The location of each center is the mean of its members.
+func (q *QT) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(q.centers))
+	for i := range q.centers {
+		cs[i] = &q.centers[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the QT.
+func (q *QT) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(q.values))
+	for i := range q.values {
+		vs[i] = &q.values[i]
+	}
+	return vs
+}
@@ -0,0 +1,82 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package qt_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/qt"
+
+	"math"
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+func dist(a, b []float64) float64 {
+	var ss float64
+	for i, v := range a {
+		d := v - b[i]
+		ss += d * d
+	}
+	return math.Sqrt(ss)
+}
+
+func (s *S) TestQT(c *check.C) {
+	pts := Points{{0}, {1}, {2}, {3}, {10}, {10.5}, {20}}
+	q, err := qt.New(pts, 2, nil)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(q.Cluster(), check.Equals, nil)
+	var got []cluster.Indices
+	for _, cen := range q.Centers() {
+		got = append(got, cen.Members())
+	}
+	c.Check(got, check.DeepEquals, []cluster.Indices{{0, 1, 2}, {4, 5}, {3}, {6}})
+}
+
+func (s *S) TestDiameter(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var pts Points
+	for i := 0; i < 100; i++ {
+		pts = append(pts, []float64{rnd.NormFloat64() * 3, rnd.NormFloat64() * 3})
+	}
+	q, err := qt.New(pts, 2.5, nil)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(q.Cluster(), check.Equals, nil)
+	var n int
+	prev := len(pts)
+	for _, cen := range q.Centers() {
+		m := cen.Members()
+		n += len(m)
+		c.Check(len(m) <= prev, check.Equals, true)
+		prev = len(m)
+		for _, i := range m {
+			for _, j := range m {
+				c.Check(dist(pts[i], pts[j]) <= 2.5, check.Equals, true)
+			}
+		}
+	}
+	c.Check(n, check.Equals, len(pts))
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := qt.New(Points{}, 1, nil)
+	c.Check(err, check.ErrorMatches, "qt: no data")
+	_, err = qt.New(Points{{1}}, -1, nil)
+	c.Check(err, check.ErrorMatches, "qt: invalid diameter")
+	_, err = qt.New(Points{{1}, {1, 2}}, 1, nil)
+	c.Check(err, check.ErrorMatches, "qt: mismatched dimensions")
+}
@@ -0,0 +1,226 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rock implements the ROCK link-based agglomerative clustering algorithm for
+// categorical data.
+//
+// Reference:
+//
+//	Guha S, Rastogi R, Shim K. ROCK: a robust clustering algorithm for categorical
+//	attributes. Information Systems 25(5):345-366 (2000).
+package rock
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+	"sort"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	cluster int
+}
+
+func (v *value) Cluster() int { return v.cluster }
+
+type center struct {
+	point
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// ROCK implements clustering of categorical data using the ROCK algorithm. Each
+// dimension of the data is a categorical attribute whose values are compared only
+// for equality; NaN values denote missing attributes. Two values are neighbors if
+// the Jaccard similarity of their sets of attribute values is at least theta, and
+// the number of links between two values is the number of neighbors they share,
+// where each value is considered to be its own neighbor.
+// Clusters are merged in order of decreasing goodness until k clusters remain or
+// no remaining pair of clusters is linked. Values with no links remain in
+// singleton clusters, so more than k clusters may be returned.
+type ROCK struct {
+	k      int
+	theta  float64
+	values []value
+	ci     []cluster.Indices
+}
+
+// New creates a new ROCK Clusterer object populated with data from an Interface
+// value, data.
+func New(data cluster.Interface, k int, theta float64) (*ROCK, error) {
+	if k < 1 {
+		return nil, errors.New("rock: invalid k")
+	}
+	if theta < 0 || theta >= 1 {
+		return nil, errors.New("rock: theta out of range")
+	}
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "rock", Err: cluster.ErrEmptyData}
+	}
+	dim := len(data.Values(0))
+	va := make([]value, data.Len())
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dim {
+			return nil, &cluster.Error{Pkg: "rock", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...)}
+	}
+	return &ROCK{k: k, theta: theta, values: va}, nil
+}
+
+// Jaccard returns the Jaccard similarity of the sets of attribute values of x and
+// y, where attribute i of x and y have values x[i] and y[i]. NaN values are
+// treated as missing.
+func Jaccard(x, y []float64) float64 {
+	var inter, union int
+	for i, a := range x {
+		b := y[i]
+		switch na, nb := math.IsNaN(a), math.IsNaN(b); {
+		case na && nb:
+		case na || nb:
+			union++
+		case a == b:
+			inter++
+			union++
+		default:
+			union += 2
+		}
+	}
+	if union == 0 {
+		return 1
+	}
+	return float64(inter) / float64(union)
+}
+
+// Cluster runs a clustering of the data using the ROCK algorithm.
+func (r *ROCK) Cluster() error {
+	n := len(r.values)
+
+	// Each value is its own neighbor.
+	nbrs := make([][]int, n)
+	for i := range r.values {
+		nbrs[i] = append(nbrs[i], i)
+		for j := 0; j < i; j++ {
+			if Jaccard(r.values[i].point, r.values[j].point) >= r.theta {
+				nbrs[i] = append(nbrs[i], j)
+				nbrs[j] = append(nbrs[j], i)
+			}
+		}
+	}
+	links := make([]map[int]float64, n)
+	for i := range links {
+		links[i] = make(map[int]float64)
+	}
+	for _, nb := range nbrs {
+		for a, i := range nb {
+			for _, j := range nb[a+1:] {
+				links[i][j]++
+				links[j][i]++
+			}
+		}
+	}
+
+	f := 1 + 2*(1-r.theta)/(1+r.theta)
+	size := make([]float64, n)
+	members := make([]cluster.Indices, n)
+	active := make(map[int]bool, n)
+	for i := range size {
+		size[i] = 1
+		members[i] = cluster.Indices{i}
+		active[i] = true
+	}
+	goodness := func(i, j int) float64 {
+		ni, nj := size[i], size[j]
+		return links[i][j] / (math.Pow(ni+nj, f) - math.Pow(ni, f) - math.Pow(nj, f))
+	}
+	for len(active) > r.k {
+		bi, bj := -1, -1
+		max := 0.
+		for i := range active {
+			for j := range links[i] {
+				if j <= i {
+					continue
+				}
+				if g := goodness(i, j); g > max || (g == max && bi >= 0 && (i < bi || i == bi && j < bj)) {
+					bi, bj, max = i, j, g
+				}
+			}
+		}
+		if bi < 0 {
+			break
+		}
+		for k, l := range links[bj] {
+			if k == bi {
+				continue
+			}
+			links[bi][k] += l
+			links[k][bi] += l
+			delete(links[k], bj)
+		}
+		delete(links[bi], bj)
+		links[bj] = nil
+		size[bi] += size[bj]
+		members[bi] = append(members[bi], members[bj]...)
+		members[bj] = nil
+		delete(active, bj)
+	}
+
+	r.ci = r.ci[:0]
+	for i := 0; i < n; i++ {
+		if !active[i] {
+			continue
+		}
+		m := members[i]
+		sort.Ints(m)
+		for _, j := range m {
+			r.values[j].cluster = len(r.ci)
+		}
+		r.ci = append(r.ci, m)
+	}
+	return nil
+}
+
+// Centers returns the centers of the clusters determined by a previous call to
+// Cluster. The location of each center is the most frequent value of each
+// attribute among its members, with ties broken in favour of the smallest value.
+func (r *ROCK) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(r.ci))
+	for i, m := range r.ci {
+		p := make(point, len(r.values[m[0]].point))
+		for d := range p {
+			counts := make(map[float64]int)
+			for _, j := range m {
+				if x := r.values[j].point[d]; !math.IsNaN(x) {
+					counts[x]++
+				}
+			}
+			p[d] = math.NaN()
+			best := 0
+			for x, c := range counts {
+				if c > best || c == best && x < p[d] {
+					p[d], best = x, c
+				}
+			}
+		}
+		cs[i] = &center{point: p, indices: m}
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the ROCK.
+func (r *ROCK) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(r.values))
+	for i := range r.values {
+		vs[i] = &r.values[i]
+	}
+	return vs
+}
@@ -0,0 +1,69 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rock_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/rock"
+
+	"math"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+// Annotations are categorical feature annotations encoded as category codes.
+type Annotations [][]float64
+
+func (a Annotations) Len() int               { return len(a) }
+func (a Annotations) Values(i int) []float64 { return a[i] }
+
+var nan = math.NaN()
+
+var annots = Annotations{
+	{1, 1, 1, 1, 1},
+	{1, 1, 1, 1, 2},
+	{1, 1, 1, 2, 1},
+	{1, 1, 2, 1, 1},
+	{2, 2, 2, 2, 2},
+	{2, 2, 2, 2, 1},
+	{2, 2, 2, 1, 2},
+	{2, 2, nan, 2, 2},
+}
+
+func (s *S) TestJaccard(c *check.C) {
+	c.Check(rock.Jaccard([]float64{1, 1}, []float64{1, 1}), check.Equals, 1.)
+	c.Check(rock.Jaccard([]float64{1, 1}, []float64{1, 2}), check.Equals, 1./3)
+	c.Check(rock.Jaccard([]float64{1, nan}, []float64{1, 2}), check.Equals, 1./2)
+}
+
+func (s *S) TestROCK(c *check.C) {
+	r, err := rock.New(annots, 2, 0.5)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(r.Cluster(), check.Equals, nil)
+	var got []cluster.Indices
+	var cens [][]float64
+	for _, cen := range r.Centers() {
+		got = append(got, cen.Members())
+		cens = append(cens, cen.V())
+	}
+	c.Check(got, check.DeepEquals, []cluster.Indices{{0, 1, 2, 3}, {4, 5, 6, 7}})
+	c.Check(cens, check.DeepEquals, [][]float64{{1, 1, 1, 1, 1}, {2, 2, 2, 2, 2}})
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := rock.New(annots, 0, 0.5)
+	c.Check(err, check.ErrorMatches, "rock: invalid k")
+	_, err = rock.New(annots, 1, 1)
+	c.Check(err, check.ErrorMatches, "rock: theta out of range")
+	_, err = rock.New(Annotations{}, 1, 0.5)
+	c.Check(err, check.ErrorMatches, "rock: no data")
+}
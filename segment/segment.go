@@ -0,0 +1,215 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package segment implements contiguity-constrained clustering of ordered ℝⁿ data,
+// where each cluster is a run of consecutive elements of the data. This is suited
+// to segmentation of data ordered along a sequence, for example copy-number or
+// domain calls along a chromosome.
+package segment
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"errors"
+	"math"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	w       float64
+	cluster int
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+type center struct {
+	point
+	w       float64
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// Segmenter implements optimal segmentation of ordered data into k segments. The
+// segmentation found minimises the weighted within-segment sum of squares over all
+// partitions of the data into k runs of consecutive elements.
+type Segmenter struct {
+	k      int
+	dims   int
+	values []value
+	means  []center
+}
+
+// New creates a new Segmenter that will partition data into k segments. The order
+// of elements in data defines the contiguity constraint.
+func New(data cluster.Interface, k int) (*Segmenter, error) {
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "segment", Err: cluster.ErrEmptyData}
+	}
+	if k < 1 {
+		return nil, errors.New("segment: invalid k")
+	}
+	if k > data.Len() {
+		return nil, errors.New("segment: too many segments")
+	}
+	dim := len(data.Values(0))
+	va := make([]value, data.Len())
+	w, _ := data.(cluster.Weighter)
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dim {
+			return nil, &cluster.Error{Pkg: "segment", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...), w: 1}
+		if w != nil {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return &Segmenter{k: k, dims: dim, values: va}, nil
+}
+
+// Cluster runs a segmentation of the data by dynamic programming in O(kn²d) time.
+func (s *Segmenter) Cluster() error {
+	n := len(s.values)
+
+	// Prefix sums are calculated about the first element to reduce
+	// cancellation error.
+	shift := s.values[0].point
+	sw := make([]float64, n+1)
+	swx := make([][]float64, n+1)
+	swxx := make([]float64, n+1)
+	swx[0] = make([]float64, s.dims)
+	for i, v := range s.values {
+		swx[i+1] = make([]float64, s.dims)
+		var xx float64
+		for j, x := range v.point {
+			x -= shift[j]
+			swx[i+1][j] = swx[i][j] + v.w*x
+			xx += x * x
+		}
+		sw[i+1] = sw[i] + v.w
+		swxx[i+1] = swxx[i] + v.w*xx
+	}
+	// cost returns the weighted sum of squares of elements j through i.
+	cost := func(j, i int) float64 {
+		w := sw[i+1] - sw[j]
+		if w <= 0 {
+			return 0
+		}
+		var ss float64
+		for d := 0; d < s.dims; d++ {
+			x := swx[i+1][d] - swx[j][d]
+			ss += x * x
+		}
+		ss = swxx[i+1] - swxx[j] - ss/w
+		if ss < 0 {
+			return 0
+		}
+		return ss
+	}
+
+	// start[q][i] holds the index of the first element of the last of
+	// q+1 segments partitioning elements 0 through i.
+	start := make([][]int, s.k)
+	prev := make([]float64, n)
+	cur := make([]float64, n)
+	start[0] = make([]int, n)
+	for i := range prev {
+		prev[i] = cost(0, i)
+	}
+	for q := 1; q < s.k; q++ {
+		start[q] = make([]int, n)
+		for i := q; i < n; i++ {
+			best, min := -1, math.Inf(1)
+			for j := q; j <= i; j++ {
+				d := prev[j-1] + cost(j, i)
+				if d < min {
+					best, min = j, d
+				}
+			}
+			cur[i] = min
+			start[q][i] = best
+		}
+		prev, cur = cur, prev
+	}
+
+	s.means = make([]center, s.k)
+	for q, i := s.k-1, n-1; q >= 0; q-- {
+		j := start[q][i]
+		c := &s.means[q]
+		c.point = make(point, s.dims)
+		for m := j; m <= i; m++ {
+			v := &s.values[m]
+			v.cluster = q
+			for d, x := range v.point {
+				c.point[d] += v.w * x
+			}
+			c.w += v.w
+			c.indices = append(c.indices, m)
+		}
+		if c.w > 0 {
+			inv := 1 / c.w
+			for d := range c.point {
+				c.point[d] *= inv
+			}
+		}
+		i = j - 1
+	}
+
+	return nil
+}
+
+// Breakpoints returns the indices of the first element of each segment after the
+// first determined by a previous call to Cluster.
+func (s *Segmenter) Breakpoints() []int {
+	if s.means == nil {
+		return nil
+	}
+	b := make([]int, 0, len(s.means)-1)
+	for _, c := range s.means[1:] {
+		b = append(b, c.indices[0])
+	}
+	return b
+}
+
+// Within calculates the weighted sum of squares within each segment.
+// Returns nil if Cluster has not been called.
+func (s *Segmenter) Within() []float64 {
+	if s.means == nil {
+		return nil
+	}
+	ss := make([]float64, len(s.means))
+	for _, v := range s.values {
+		for j, x := range v.point {
+			d := s.means[v.cluster].point[j] - x
+			ss[v.cluster] += v.w * d * d
+		}
+	}
+	return ss
+}
+
+// Centers returns the k segment centers determined by a previous call to Cluster.
+// Centers are given in the order of their segments. The location of each center
+// is the weighted mean of its members.
+func (s *Segmenter) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(s.means))
+	for i := range s.means {
+		cs[i] = &s.means[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the Segmenter.
+func (s *Segmenter) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(s.values))
+	for i := range s.values {
+		vs[i] = &s.values[i]
+	}
+	return vs
+}
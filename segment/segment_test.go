@@ -0,0 +1,62 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package segment_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/segment"
+
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+// Bins is a set of read depths along a chromosome.
+type Bins [][]float64
+
+func (b Bins) Len() int               { return len(b) }
+func (b Bins) Values(i int) []float64 { return b[i] }
+
+var depths = Bins{
+	{2.0}, {2.1}, {1.9}, {2.0},
+	{3.1}, {2.9}, {3.0},
+	{2.0}, {1.9}, {2.1},
+	{1.0}, {1.1},
+}
+
+func (s *S) TestSegment(c *check.C) {
+	seg, err := segment.New(depths, 4)
+	c.Assert(err, check.Equals, nil)
+	c.Assert(seg.Cluster(), check.Equals, nil)
+	var got []cluster.Indices
+	for _, cen := range seg.Centers() {
+		got = append(got, cen.Members())
+	}
+	c.Check(got, check.DeepEquals, []cluster.Indices{{0, 1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {10, 11}})
+	c.Check(seg.Breakpoints(), check.DeepEquals, []int{4, 7, 10})
+
+	// The first and third segments would be merged by an unconstrained
+	// clustering but remain distinct here.
+	c.Check(seg.Centers()[0].V()[0], check.Equals, 2.)
+	c.Check(seg.Centers()[2].V()[0], check.Equals, 2.)
+	for _, v := range seg.Values()[:4] {
+		c.Check(v.Cluster(), check.Equals, 0)
+	}
+}
+
+func (s *S) TestErrors(c *check.C) {
+	_, err := segment.New(Bins{}, 1)
+	c.Check(err, check.ErrorMatches, "segment: no data")
+	_, err = segment.New(depths, 0)
+	c.Check(err, check.ErrorMatches, "segment: invalid k")
+	_, err = segment.New(Bins{{1}, {1, 2}}, 1)
+	c.Check(err, check.ErrorMatches, "segment: mismatched dimensions")
+}
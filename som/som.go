@@ -0,0 +1,306 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package som implements Kohonen self-organizing maps for ℝⁿ data.
+package som
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+)
+
+type point []float64
+
+func (p point) V() []float64 { return p }
+
+type value struct {
+	point
+	w       float64
+	cluster int
+}
+
+func (v *value) Weight() float64 { return v.w }
+func (v *value) Cluster() int    { return v.cluster }
+
+type center struct {
+	point
+	indices cluster.Indices
+}
+
+func (c *center) Members() cluster.Indices { return c.indices }
+
+// Topology specifies the arrangement of units in a Grid.
+type Topology int
+
+const (
+	// Rectangular arranges units on a square lattice, where each interior
+	// unit has four immediate neighbors.
+	Rectangular Topology = iota
+
+	// Hexagonal arranges units on a hexagonal lattice, where each interior
+	// unit has six immediate neighbors. Odd rows are offset by half a unit.
+	Hexagonal
+)
+
+// Grid describes the map of units.
+type Grid struct {
+	Rows, Cols int
+	Topology   Topology
+
+	// Toroidal specifies that the edges of the map wrap around so
+	// that the map has no boundary.
+	Toroidal bool
+}
+
+// Decay specifies how a training parameter changes from its initial to its final
+// value over the course of training.
+type Decay int
+
+const (
+	Exponential Decay = iota // Exponential decay, v₀·(v₁/v₀)^t.
+	Linear                   // Linear decay, v₀ + (v₁-v₀)·t.
+)
+
+// Schedule describes the training of a map. Training progresses from t=0 to t=1 over
+// all presentations of the data.
+type Schedule struct {
+	// Epochs is the number of passes through the data.
+	Epochs int
+
+	// Rate and FinalRate are the initial and final learning rates.
+	Rate, FinalRate float64
+
+	// Radius and FinalRadius are the initial and final widths of the
+	// Gaussian neighborhood function in grid units. If Radius is zero,
+	// half the larger grid dimension is used. If FinalRadius is zero,
+	// a value of 0.5 is used.
+	Radius, FinalRadius float64
+
+	// Decay is the decay applied to the learning rate and radius.
+	Decay Decay
+}
+
+func (s Schedule) at(t, v0, v1 float64) float64 {
+	if s.Decay == Linear {
+		return v0 + (v1-v0)*t
+	}
+	return v0 * math.Pow(v1/v0, t)
+}
+
+// SOM implements a self-organizing map trained using the online Kohonen algorithm.
+// Each unit of the map is a cluster whose members are the values for which it is
+// the best matching unit.
+type SOM struct {
+	grid   Grid
+	sched  Schedule
+	dims   int
+	values []value
+	units  []center
+	rnd    *rand.Rand // rnd is the source of random choices; nil uses math/rand.
+}
+
+// New creates a new SOM populated with data from an Interface value, data, that will
+// be trained on the map described by grid according to the schedule sched. Weights
+// of values scale the learning rate for each presentation.
+func New(data cluster.Interface, grid Grid, sched Schedule) (*SOM, error) {
+	if grid.Rows < 1 || grid.Cols < 1 {
+		return nil, errors.New("som: invalid grid")
+	}
+	if sched.Epochs < 1 || sched.Rate <= 0 || sched.FinalRate <= 0 {
+		return nil, errors.New("som: invalid schedule")
+	}
+	if sched.Radius == 0 {
+		sched.Radius = math.Max(float64(grid.Rows), float64(grid.Cols)) / 2
+	}
+	if sched.FinalRadius == 0 {
+		sched.FinalRadius = 0.5
+	}
+	if sched.Radius < 0 || sched.FinalRadius < 0 {
+		return nil, errors.New("som: invalid schedule")
+	}
+	if data.Len() == 0 {
+		return nil, &cluster.Error{Pkg: "som", Err: cluster.ErrEmptyData}
+	}
+	va := make([]value, data.Len())
+	dim := len(data.Values(0))
+	w, isWeighter := data.(cluster.Weighter)
+	for i := range va {
+		vec := data.Values(i)
+		if len(vec) != dim {
+			return nil, &cluster.Error{Pkg: "som", Err: cluster.ErrDimensionMismatch}
+		}
+		va[i] = value{point: append(point(nil), vec...), w: 1}
+		if isWeighter {
+			va[i].w = w.Weight(i)
+		}
+	}
+	return &SOM{grid: grid, sched: sched, dims: dim, values: va}, nil
+}
+
+// Position returns the location of unit u on the map in grid units.
+func (s *SOM) Position(u int) (x, y float64) {
+	r, c := u/s.grid.Cols, u%s.grid.Cols
+	if s.grid.Topology == Hexagonal {
+		return float64(c) + 0.5*float64(r%2), float64(r) * math.Sqrt(3) / 2
+	}
+	return float64(c), float64(r)
+}
+
+// gridDist returns the squared distance between units a and b on the map.
+func (s *SOM) gridDist(a, b int) float64 {
+	ax, ay := s.Position(a)
+	bx, by := s.Position(b)
+	dx, dy := math.Abs(ax-bx), math.Abs(ay-by)
+	if s.grid.Toroidal {
+		w, h := float64(s.grid.Cols), float64(s.grid.Rows)
+		if s.grid.Topology == Hexagonal {
+			h *= math.Sqrt(3) / 2
+		}
+		dx = math.Min(dx, w-dx)
+		dy = math.Min(dy, h-dy)
+	}
+	return dx*dx + dy*dy
+}
+
+// bmu returns the index of the best matching unit for v.
+func (s *SOM) bmu(v point) int {
+	best, min := 0, math.Inf(1)
+	for u, c := range s.units {
+		var d float64
+		for j, x := range v {
+			e := x - c.point[j]
+			d += e * e
+		}
+		if d < min {
+			best, min = u, d
+		}
+	}
+	return best
+}
+
+// SetRand sets the source of the random choices made by Cluster to rnd. If rnd is
+// nil, the global math/rand source is used.
+func (s *SOM) SetRand(rnd *rand.Rand) { s.rnd = rnd }
+
+// Cluster trains the map and assigns each value to its best matching unit. The
+// codebook is initialised with randomly chosen values.
+func (s *SOM) Cluster() error {
+	return s.ClusterContext(context.Background())
+}
+
+// ClusterContext trains the map as for Cluster, checking ctx before each epoch. If
+// ctx is done, ClusterContext returns ctx.Err() after assigning each value to its
+// best matching unit in the partially trained map.
+func (s *SOM) ClusterContext(ctx context.Context) error {
+	intn, perm := rand.Intn, rand.Perm
+	if s.rnd != nil {
+		intn, perm = s.rnd.Intn, s.rnd.Perm
+	}
+	n := s.grid.Rows * s.grid.Cols
+	s.units = make([]center, n)
+	for u := range s.units {
+		s.units[u].point = append(point(nil), s.values[intn(len(s.values))].point...)
+	}
+
+	// Precompute the grid distances between units.
+	gd := make([][]float64, n)
+	for a := range gd {
+		gd[a] = make([]float64, n)
+		for b := range gd[a] {
+			gd[a][b] = s.gridDist(a, b)
+		}
+	}
+
+	total := float64(s.sched.Epochs * len(s.values))
+	var (
+		step int
+		err  error
+	)
+	for e := 0; e < s.sched.Epochs; e++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		for _, i := range perm(len(s.values)) {
+			t := float64(step) / total
+			step++
+			rate := s.sched.at(t, s.sched.Rate, s.sched.FinalRate)
+			radius := s.sched.at(t, s.sched.Radius, s.sched.FinalRadius)
+			inv := 1 / (2 * radius * radius)
+
+			v := s.values[i]
+			b := s.bmu(v.point)
+			for u := range s.units {
+				h := rate * v.w * math.Exp(-gd[b][u]*inv)
+				if h > 1 {
+					h = 1
+				}
+				if h < 1e-12 {
+					continue
+				}
+				c := s.units[u].point
+				for j, x := range v.point {
+					c[j] += h * (x - c[j])
+				}
+			}
+		}
+	}
+
+	for i := range s.values {
+		b := s.bmu(s.values[i].point)
+		s.values[i].cluster = b
+		s.units[b].indices = append(s.units[b].indices, i)
+	}
+	return err
+}
+
+// Codebook returns the weight vectors of the units of the map trained by a previous
+// call to Cluster. Unit u is at row u/Cols and column u%Cols of the grid.
+func (s *SOM) Codebook() [][]float64 {
+	cb := make([][]float64, len(s.units))
+	for u, c := range s.units {
+		cb[u] = c.point
+	}
+	return cb
+}
+
+// QuantizationError returns the mean distance between values and their best
+// matching unit.
+func (s *SOM) QuantizationError() float64 {
+	var sum float64
+	for _, v := range s.values {
+		c := s.units[v.cluster].point
+		var d float64
+		for j, x := range v.point {
+			e := x - c[j]
+			d += e * e
+		}
+		sum += math.Sqrt(d)
+	}
+	return sum / float64(len(s.values))
+}
+
+// Centers returns the units of the map trained by a previous call to Cluster. Every
+// unit is returned, including those that are not the best matching unit of any
+// value.
+func (s *SOM) Centers() []cluster.Center {
+	cs := make([]cluster.Center, len(s.units))
+	for i := range s.units {
+		cs[i] = &s.units[i]
+	}
+	return cs
+}
+
+// Values returns a slice of the values in the SOM. The Cluster of each value is its
+// best matching unit.
+func (s *SOM) Values() []cluster.Value {
+	vs := make([]cluster.Value, len(s.values))
+	for i := range s.values {
+		vs[i] = &s.values[i]
+	}
+	return vs
+}
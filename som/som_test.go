@@ -0,0 +1,93 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package som_test
+
+import (
+	"github.com/biogo/cluster/som"
+
+	"math"
+	"math/rand"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type Points [][]float64
+
+func (p Points) Len() int               { return len(p) }
+func (p Points) Values(i int) []float64 { return p[i] }
+
+func (s *S) TestSOM(c *check.C) {
+	rnd := rand.New(rand.NewSource(1))
+	var data Points
+	for i := 0; i < 500; i++ {
+		data = append(data, []float64{rnd.Float64(), rnd.Float64()})
+	}
+	for _, topo := range []som.Topology{som.Rectangular, som.Hexagonal} {
+		grid := som.Grid{Rows: 5, Cols: 5, Topology: topo}
+		m, err := som.New(data, grid, som.Schedule{Epochs: 20, Rate: 0.5, FinalRate: 0.01})
+		c.Assert(err, check.Equals, nil)
+		m.SetRand(rnd)
+		c.Assert(m.Cluster(), check.Equals, nil)
+
+		c.Check(len(m.Codebook()), check.Equals, 25)
+		c.Check(len(m.Centers()), check.Equals, 25)
+		c.Check(m.QuantizationError() < 0.15, check.Equals, true)
+
+		var n int
+		for u, cen := range m.Centers() {
+			n += len(cen.Members())
+			for _, i := range cen.Members() {
+				c.Check(m.Values()[i].Cluster(), check.Equals, u)
+			}
+		}
+		c.Check(n, check.Equals, len(data))
+
+		// Adjacent units on the map have nearby codebook vectors.
+		cb := m.Codebook()
+		var adj, all float64
+		var na, nb int
+		for a := range cb {
+			for b := range cb[:a] {
+				d := math.Hypot(cb[a][0]-cb[b][0], cb[a][1]-cb[b][1])
+				ax, ay := m.Position(a)
+				bx, by := m.Position(b)
+				if math.Hypot(ax-bx, ay-by) < 1.01 {
+					adj += d
+					na++
+				}
+				all += d
+				nb++
+			}
+		}
+		c.Check(adj/float64(na) < 0.5*all/float64(nb), check.Equals, true)
+	}
+
+	var cb [2][][]float64
+	for i := range cb {
+		m, err := som.New(data, som.Grid{Rows: 3, Cols: 3}, som.Schedule{Epochs: 2, Rate: 0.5, FinalRate: 0.01})
+		c.Assert(err, check.Equals, nil)
+		m.SetRand(rand.New(rand.NewSource(2)))
+		c.Assert(m.Cluster(), check.Equals, nil)
+		cb[i] = m.Codebook()
+	}
+	c.Check(cb[1], check.DeepEquals, cb[0])
+}
+
+func (s *S) TestErrors(c *check.C) {
+	sched := som.Schedule{Epochs: 1, Rate: 0.5, FinalRate: 0.1}
+	_, err := som.New(Points{{1}}, som.Grid{}, sched)
+	c.Check(err, check.ErrorMatches, "som: invalid grid")
+	_, err = som.New(Points{{1}}, som.Grid{Rows: 1, Cols: 1}, som.Schedule{})
+	c.Check(err, check.ErrorMatches, "som: invalid schedule")
+	_, err = som.New(Points{}, som.Grid{Rows: 1, Cols: 1}, sched)
+	c.Check(err, check.ErrorMatches, "som: no data")
+}
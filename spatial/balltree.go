@@ -0,0 +1,175 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"math"
+	"sort"
+)
+
+// BallTree is a Euclidean Index backed by a ball tree. Each node of a ball tree
+// bounds its points by a hypersphere, which allows more effective pruning than the
+// axis-aligned splits of a kd-tree when the data are high dimensional. Queries on
+// a built BallTree are safe for concurrent use.
+type BallTree struct {
+	leafSize int
+	points   [][]float64
+	root     *ball
+}
+
+// ball is a node of a BallTree.
+type ball struct {
+	center      []float64
+	radius      float64
+	members     []int // Members holds the point indices of a leaf node.
+	left, right *ball
+}
+
+// NewBallTree returns a new empty BallTree holding at most leafSize points in each
+// leaf node. If leafSize is less than one, a leaf size of 16 is used.
+func NewBallTree(leafSize int) *BallTree {
+	if leafSize < 1 {
+		leafSize = 16
+	}
+	return &BallTree{leafSize: leafSize}
+}
+
+// Build constructs the ball tree over the values of data.
+func (t *BallTree) Build(data cluster.Interface) {
+	t.points = make([][]float64, data.Len())
+	idx := make([]int, data.Len())
+	for i := range t.points {
+		t.points[i] = append([]float64(nil), data.Values(i)...)
+		idx[i] = i
+	}
+	t.root = nil
+	if len(idx) != 0 {
+		t.root = t.build(idx)
+	}
+}
+
+// build returns a ball bounding the points indexed by idx, splitting the points
+// about the median of their projection onto the line between two distant members.
+func (t *BallTree) build(idx []int) *ball {
+	b := &ball{center: make([]float64, len(t.points[idx[0]]))}
+	for _, i := range idx {
+		for j, v := range t.points[i] {
+			b.center[j] += v
+		}
+	}
+	inv := 1 / float64(len(idx))
+	for j := range b.center {
+		b.center[j] *= inv
+	}
+	a := farthest(t.points, idx, b.center, &b.radius)
+	if len(idx) <= t.leafSize || b.radius == 0 {
+		b.members = idx
+		return b
+	}
+	var r float64
+	c := farthest(t.points, idx, t.points[a], &r)
+
+	pa, pc := t.points[a], t.points[c]
+	proj := projection{idx: idx, proj: make([]float64, len(idx))}
+	for k, i := range idx {
+		for j, v := range t.points[i] {
+			proj.proj[k] += (v - pa[j]) * (pc[j] - pa[j])
+		}
+	}
+	sort.Sort(proj)
+	m := len(idx) / 2
+	b.left = t.build(idx[:m])
+	b.right = t.build(idx[m:])
+	return b
+}
+
+// projection sorts point indices by their projection onto a line.
+type projection struct {
+	idx  []int
+	proj []float64
+}
+
+func (p projection) Len() int           { return len(p.idx) }
+func (p projection) Less(i, j int) bool { return p.proj[i] < p.proj[j] }
+func (p projection) Swap(i, j int) {
+	p.idx[i], p.idx[j] = p.idx[j], p.idx[i]
+	p.proj[i], p.proj[j] = p.proj[j], p.proj[i]
+}
+
+// farthest returns the index of the point in idx farthest from q and stores the
+// distance to that point in d.
+func farthest(points [][]float64, idx []int, q []float64, d *float64) int {
+	f := idx[0]
+	*d = 0
+	for _, i := range idx {
+		if di := euclidean(points[i], q); di > *d {
+			f, *d = i, di
+		}
+	}
+	return f
+}
+
+func euclidean(a, b []float64) float64 {
+	var sum float64
+	for i, v := range a {
+		d := v - b[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// NearestSet appends the k nearest neighbors of q to dst.
+func (t *BallTree) NearestSet(dst []Neighbor, q []float64, k int) []Neighbor {
+	if k < 1 || t.root == nil {
+		return dst
+	}
+	h := make(neighbors, 0, k)
+	t.nearest(t.root, q, k, &h)
+	return sortAppend(dst, h)
+}
+
+func (t *BallTree) nearest(b *ball, q []float64, k int, h *neighbors) {
+	if max, ok := h.full(k); ok && euclidean(q, b.center)-b.radius >= max {
+		return
+	}
+	if b.members != nil {
+		for _, i := range b.members {
+			h.keep(Neighbor{Index: i, Dist: euclidean(q, t.points[i])}, k)
+		}
+		return
+	}
+	near, far := b.left, b.right
+	if euclidean(q, far.center) < euclidean(q, near.center) {
+		near, far = far, near
+	}
+	t.nearest(near, q, k, h)
+	t.nearest(far, q, k, h)
+}
+
+// RangeSet appends the neighbors within distance r of q to dst.
+func (t *BallTree) RangeSet(dst []Neighbor, q []float64, r float64) []Neighbor {
+	if t.root == nil {
+		return dst
+	}
+	return sortAppend(dst, t.within(nil, t.root, q, r))
+}
+
+func (t *BallTree) within(dst []Neighbor, b *ball, q []float64, r float64) []Neighbor {
+	if euclidean(q, b.center)-b.radius > r {
+		return dst
+	}
+	if b.members != nil {
+		for _, i := range b.members {
+			if d := euclidean(q, t.points[i]); d <= r {
+				dst = append(dst, Neighbor{Index: i, Dist: d})
+			}
+		}
+		return dst
+	}
+	dst = t.within(dst, b.left, q, r)
+	return t.within(dst, b.right, q, r)
+}
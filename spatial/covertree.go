@@ -0,0 +1,138 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"math"
+	"sort"
+)
+
+// CoverTree is an Index backed by a simplified cover tree as described by Izbicki
+// and Shelton. Points can be added to a CoverTree after it has been built, making
+// it suitable for incremental and streaming clustering. Like VPTree, a CoverTree
+// can be used with any metric. Queries on a CoverTree are safe for concurrent use
+// if the Metric is, but must not be made concurrently with calls to Insert.
+type CoverTree struct {
+	metric cluster.Metric
+	points [][]float64
+	root   *cover
+}
+
+// cover is a node of a CoverTree. All children of a node at level l have a level
+// less than l and are within 2^l of the node. The distance to the furthest
+// descendant of the node is held in maxDist.
+type cover struct {
+	index    int
+	level    int
+	maxDist  float64
+	children []*cover
+}
+
+func covdist(level int) float64 { return math.Ldexp(1, level) }
+
+// NewCoverTree returns a new empty CoverTree using the metric m. If m is nil,
+// Euclidean distance is used.
+func NewCoverTree(m cluster.Metric) *CoverTree {
+	if m == nil {
+		m = cluster.Euclidean
+	}
+	return &CoverTree{metric: m}
+}
+
+// Build constructs the cover tree over the values of data, discarding any
+// previously held points.
+func (t *CoverTree) Build(data cluster.Interface) {
+	t.points = t.points[:0]
+	t.root = nil
+	for i := 0; i < data.Len(); i++ {
+		t.Insert(data.Values(i))
+	}
+}
+
+// Len returns the number of points held by the tree.
+func (t *CoverTree) Len() int { return len(t.points) }
+
+// Insert adds a copy of p to the tree and returns its index.
+func (t *CoverTree) Insert(p []float64) int {
+	i := len(t.points)
+	t.points = append(t.points, append([]float64(nil), p...))
+	if t.root == nil {
+		t.root = &cover{index: i}
+		return i
+	}
+	d := t.metric.Distance(t.points[t.root.index], p)
+	for d > covdist(t.root.level) {
+		t.root.level++
+	}
+	t.insert(t.root, i, d)
+	return i
+}
+
+// insert adds point i at distance d from n to the subtree rooted at n.
+func (t *CoverTree) insert(n *cover, i int, d float64) {
+	if d > n.maxDist {
+		n.maxDist = d
+	}
+	p := t.points[i]
+	for _, c := range n.children {
+		if dc := t.metric.Distance(t.points[c.index], p); dc <= covdist(c.level) {
+			t.insert(c, i, dc)
+			return
+		}
+	}
+	n.children = append(n.children, &cover{index: i, level: n.level - 1})
+}
+
+// NearestSet appends the k nearest neighbors of q to dst.
+func (t *CoverTree) NearestSet(dst []Neighbor, q []float64, k int) []Neighbor {
+	if k < 1 || t.root == nil {
+		return dst
+	}
+	h := make(neighbors, 0, k)
+	t.nearest(t.root, t.metric.Distance(q, t.points[t.root.index]), q, k, &h)
+	return sortAppend(dst, h)
+}
+
+func (t *CoverTree) nearest(n *cover, d float64, q []float64, k int, h *neighbors) {
+	h.keep(Neighbor{Index: n.index, Dist: d}, k)
+	if len(n.children) == 0 {
+		return
+	}
+	children := make([]Neighbor, len(n.children))
+	for j, c := range n.children {
+		children[j] = Neighbor{Index: j, Dist: t.metric.Distance(q, t.points[c.index])}
+	}
+	sort.Sort(byDist(children))
+	for _, c := range children {
+		child := n.children[c.Index]
+		if tau, ok := h.full(k); ok && c.Dist-child.maxDist > tau {
+			continue
+		}
+		t.nearest(child, c.Dist, q, k, h)
+	}
+}
+
+// RangeSet appends the neighbors within distance r of q to dst.
+func (t *CoverTree) RangeSet(dst []Neighbor, q []float64, r float64) []Neighbor {
+	if t.root == nil {
+		return dst
+	}
+	return sortAppend(dst, t.within(nil, t.root, t.metric.Distance(q, t.points[t.root.index]), q, r))
+}
+
+func (t *CoverTree) within(dst []Neighbor, n *cover, d float64, q []float64, r float64) []Neighbor {
+	if d-n.maxDist > r {
+		return dst
+	}
+	if d <= r {
+		dst = append(dst, Neighbor{Index: n.index, Dist: d})
+	}
+	for _, c := range n.children {
+		dst = t.within(dst, c, t.metric.Distance(q, t.points[c.index]), q, r)
+	}
+	return dst
+}
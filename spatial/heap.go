@@ -0,0 +1,58 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// neighbors is a max heap of Neighbor values sorted on Dist used to retain the k
+// nearest neighbors during a search.
+type neighbors []Neighbor
+
+func (h neighbors) Len() int            { return len(h) }
+func (h neighbors) Less(i, j int) bool  { return h[i].Dist > h[j].Dist }
+func (h neighbors) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *neighbors) Push(x interface{}) { *h = append(*h, x.(Neighbor)) }
+func (h *neighbors) Pop() interface{} {
+	n := (*h)[len(*h)-1]
+	*h = (*h)[:len(*h)-1]
+	return n
+}
+
+// keep retains n in the heap if it is among the k nearest seen so far.
+func (h *neighbors) keep(n Neighbor, k int) {
+	switch {
+	case len(*h) < k:
+		heap.Push(h, n)
+	case n.Dist < (*h)[0].Dist:
+		(*h)[0] = n
+		heap.Fix(h, 0)
+	}
+}
+
+// full returns whether the heap holds k neighbors and if so the distance to the
+// furthest of them.
+func (h neighbors) full(k int) (float64, bool) {
+	if len(h) < k {
+		return 0, false
+	}
+	return h[0].Dist, true
+}
+
+type byDist []Neighbor
+
+func (n byDist) Len() int      { return len(n) }
+func (n byDist) Swap(i, j int) { n[i], n[j] = n[j], n[i] }
+func (n byDist) Less(i, j int) bool {
+	return n[i].Dist < n[j].Dist || (n[i].Dist == n[j].Dist && n[i].Index < n[j].Index)
+}
+
+// sortAppend sorts n by increasing distance and appends it to dst.
+func sortAppend(dst, n []Neighbor) []Neighbor {
+	sort.Sort(byDist(n))
+	return append(dst, n...)
+}
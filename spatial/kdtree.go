@@ -0,0 +1,136 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/store/kdtree"
+
+	"math"
+	"sync"
+)
+
+// node is an indexed point satisfying the kdtree.Comparable interface.
+type node struct {
+	Point []float64
+	ID    int
+}
+
+func (p *node) Clone() kdtree.Comparable {
+	return &node{Point: append([]float64(nil), p.Point...), ID: p.ID}
+}
+func (p *node) Compare(c kdtree.Comparable, d kdtree.Dim) float64 {
+	q := c.(*node)
+	return p.Point[d] - q.Point[d]
+}
+func (p *node) Dims() int { return len(p.Point) }
+func (p *node) Distance(c kdtree.Comparable) float64 {
+	q := c.(*node)
+	var sum float64
+	for dim, c := range p.Point {
+		d := c - q.Point[dim]
+		sum += d * d
+	}
+	return sum
+}
+
+// nodes is a collection of node values that satisfies the kdtree.Interface.
+type nodes []*node
+
+func (p nodes) Index(i int) kdtree.Comparable         { return p[i] }
+func (p nodes) Len() int                              { return len(p) }
+func (p nodes) Pivot(d kdtree.Dim) int                { return plane{nodes: p, Dim: d}.Pivot() }
+func (p nodes) Slice(start, end int) kdtree.Interface { return p[start:end] }
+
+// plane wraps a nodes type allowing it to be pivoted on a dimension.
+type plane struct {
+	kdtree.Dim
+	nodes
+}
+
+func (p plane) Less(i, j int) bool { return p.nodes[i].Point[p.Dim] < p.nodes[j].Point[p.Dim] }
+func (p plane) Pivot() int         { return kdtree.Partition(p, kdtree.MedianOfRandoms(p, kdtree.Randoms)) }
+func (p plane) Slice(start, end int) kdtree.SortSlicer {
+	p.nodes = p.nodes[start:end]
+	return p
+}
+func (p plane) Swap(i, j int) { p.nodes[i], p.nodes[j] = p.nodes[j], p.nodes[i] }
+
+// rangeKeeper is a kdtree.Keeper that retains the ComparableDists within the
+// distance held by its first element. Unlike a kdtree.DistKeeper it does not
+// maintain the heap during the search, which avoids an allocation for each value
+// kept; the heap is sorted by kdtree.Tree.NearestSet on return.
+type rangeKeeper struct {
+	kdtree.Heap
+}
+
+func (k *rangeKeeper) Keep(c kdtree.ComparableDist) {
+	if c.Dist <= k.Heap[0].Dist {
+		k.Heap = append(k.Heap, c)
+	}
+}
+
+// KDTree is a Euclidean Index backed by a kd-tree. Queries on a built KDTree are
+// safe for concurrent use.
+type KDTree struct {
+	tree  *kdtree.Tree
+	pool  sync.Pool
+	store []node
+	nds   nodes
+}
+
+// NewKDTree returns a new empty KDTree.
+func NewKDTree() *KDTree {
+	return &KDTree{pool: sync.Pool{New: func() interface{} { return &rangeKeeper{} }}}
+}
+
+// Build constructs the kd-tree over the values of data. The point storage of a
+// previous build is reused.
+func (t *KDTree) Build(data cluster.Interface) {
+	n := data.Len()
+	if cap(t.store) < n {
+		t.store = append(t.store[:cap(t.store)], make([]node, n-cap(t.store))...)
+	}
+	t.store = t.store[:n]
+	t.nds = t.nds[:0]
+	for i := range t.store {
+		p := &t.store[i]
+		p.Point = append(p.Point[:0], data.Values(i)...)
+		p.ID = i
+		t.nds = append(t.nds, p)
+	}
+	t.tree = kdtree.New(t.nds, false)
+}
+
+// NearestSet appends the k nearest neighbors of q to dst.
+func (t *KDTree) NearestSet(dst []Neighbor, q []float64, k int) []Neighbor {
+	if k < 1 {
+		return dst
+	}
+	keep := kdtree.NewNKeeper(k)
+	t.tree.NearestSet(keep, &node{Point: q})
+	return appendHits(dst, keep.Heap)
+}
+
+// RangeSet appends the neighbors within distance r of q to dst.
+func (t *KDTree) RangeSet(dst []Neighbor, q []float64, r float64) []Neighbor {
+	hits := t.pool.Get().(*rangeKeeper)
+	hits.Heap = append(hits.Heap[:0], kdtree.ComparableDist{Comparable: nil, Dist: r * r})
+	t.tree.NearestSet(hits, &node{Point: q})
+	dst = appendHits(dst, hits.Heap)
+	t.pool.Put(hits)
+	return dst
+}
+
+// appendHits appends the non-sentinel elements of the sorted heap h to dst.
+func appendHits(dst []Neighbor, h kdtree.Heap) []Neighbor {
+	for _, c := range h {
+		if c.Comparable == nil {
+			continue
+		}
+		dst = append(dst, Neighbor{Index: c.Comparable.(*node).ID, Dist: math.Sqrt(c.Dist)})
+	}
+	return dst
+}
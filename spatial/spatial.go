@@ -0,0 +1,31 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package spatial provides spatial indexes for neighbor queries over clustering data.
+package spatial
+
+import (
+	"github.com/biogo/cluster/cluster"
+)
+
+// Neighbor is a result of a neighbor query.
+type Neighbor struct {
+	Index int     // Index is the index of the neighbor in the data used to build the Index.
+	Dist  float64 // Dist is the distance from the query to the neighbor.
+}
+
+// Index is a spatial index over a set of points.
+type Index interface {
+	// Build constructs the index over the values of data, discarding any data
+	// previously held by the index.
+	Build(data cluster.Interface)
+
+	// NearestSet appends the k nearest neighbors of q to dst in order of
+	// increasing distance and returns the extended slice.
+	NearestSet(dst []Neighbor, q []float64, k int) []Neighbor
+
+	// RangeSet appends all the neighbors within distance r of q to dst in order
+	// of increasing distance and returns the extended slice.
+	RangeSet(dst []Neighbor, q []float64, r float64) []Neighbor
+}
@@ -0,0 +1,157 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/spatial"
+
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type points [][]float64
+
+func (p points) Len() int               { return len(p) }
+func (p points) Values(i int) []float64 { return p[i] }
+
+func random(rnd *rand.Rand, n, dim int) points {
+	p := make(points, n)
+	for i := range p {
+		p[i] = make([]float64, dim)
+		for j := range p[i] {
+			p[i][j] = rnd.Float64()
+		}
+	}
+	return p
+}
+
+func euclidean(x, y []float64) float64 {
+	var ss float64
+	for i := range x {
+		d := x[i] - y[i]
+		ss += d * d
+	}
+	return math.Sqrt(ss)
+}
+
+func manhattan(x, y []float64) float64 {
+	var sum float64
+	for i := range x {
+		sum += math.Abs(x[i] - y[i])
+	}
+	return sum
+}
+
+// brute returns all the points of p as neighbors of q in order of increasing distance.
+func brute(p points, q []float64, dist func(x, y []float64) float64) []spatial.Neighbor {
+	n := make([]spatial.Neighbor, len(p))
+	for i := range p {
+		n[i] = spatial.Neighbor{Index: i, Dist: dist(p[i], q)}
+	}
+	sort.Slice(n, func(i, j int) bool { return n[i].Dist < n[j].Dist })
+	return n
+}
+
+func sameNeighbors(c *check.C, got, want []spatial.Neighbor) {
+	c.Assert(len(got), check.Equals, len(want))
+	for i := range got {
+		c.Check(math.Abs(got[i].Dist-want[i].Dist) < 1e-12, check.Equals, true)
+		if got[i].Index != want[i].Index {
+			// Allow reordering of equidistant neighbors.
+			c.Check(got[i].Dist, check.Equals, want[i].Dist)
+		}
+	}
+}
+
+func checkIndex(c *check.C, idx spatial.Index, dim int, dist func(x, y []float64) float64) {
+	rnd := rand.New(rand.NewSource(1))
+	p := random(rnd, 500, dim)
+	idx.Build(p)
+	for i := 0; i < 50; i++ {
+		q := random(rnd, 1, dim)[0]
+		want := brute(p, q, dist)
+		for _, k := range []int{1, 5, 20} {
+			sameNeighbors(c, idx.NearestSet(nil, q, k), want[:k])
+		}
+		r := 0.1 * float64(dim)
+		var within []spatial.Neighbor
+		for _, n := range want {
+			if n.Dist <= r {
+				within = append(within, n)
+			}
+		}
+		sameNeighbors(c, idx.RangeSet(nil, q, r), within)
+	}
+	dst := []spatial.Neighbor{{Index: -1}}
+	dst = idx.NearestSet(dst, p[0], 1)
+	c.Check(dst, check.DeepEquals, []spatial.Neighbor{{Index: -1}, {Index: 0, Dist: 0}})
+}
+
+func (s *S) TestKDTree(c *check.C) {
+	checkIndex(c, spatial.NewKDTree(), 3, euclidean)
+}
+
+func (s *S) TestBallTree(c *check.C) {
+	for _, dim := range []int{2, 3, 30} {
+		checkIndex(c, spatial.NewBallTree(8), dim, euclidean)
+	}
+}
+
+func (s *S) TestVPTree(c *check.C) {
+	for _, dim := range []int{2, 3, 30} {
+		checkIndex(c, spatial.NewVPTree(nil), dim, euclidean)
+		checkIndex(c, spatial.NewVPTree(cluster.MetricFunc(manhattan)), dim, manhattan)
+	}
+
+	// The shape of the tree, and so the order of tied neighbors, does not
+	// depend on the global random source.
+	var grid points
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			grid = append(grid, []float64{float64(i), float64(j)})
+		}
+	}
+	var hits [2][]spatial.Neighbor
+	for i := range hits {
+		rand.Int63()
+		t := spatial.NewVPTree(nil)
+		t.Build(grid)
+		for _, q := range grid {
+			hits[i] = t.NearestSet(hits[i], q, 3)
+		}
+	}
+	c.Check(hits[1], check.DeepEquals, hits[0])
+}
+
+func (s *S) TestCoverTree(c *check.C) {
+	for _, dim := range []int{2, 3, 30} {
+		checkIndex(c, spatial.NewCoverTree(nil), dim, euclidean)
+		checkIndex(c, spatial.NewCoverTree(cluster.MetricFunc(manhattan)), dim, manhattan)
+	}
+
+	p := random(rand.New(rand.NewSource(1)), 200, 3)
+	t := spatial.NewCoverTree(nil)
+	t.Build(p[:100])
+	for i, v := range p[100:] {
+		c.Check(t.Insert(v), check.Equals, 100+i)
+	}
+	c.Check(t.Len(), check.Equals, len(p))
+	t.Insert(p[0]) // Duplicate points are allowed.
+	p = append(p, p[0])
+	for _, q := range p {
+		sameNeighbors(c, t.NearestSet(nil, q, 3), brute(p, q, euclidean)[:3])
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package spatial
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"math/rand"
+	"sort"
+)
+
+// VPTree is an Index backed by a vantage-point tree. A VPTree only requires that
+// distances satisfy the triangle inequality and so can be used with any metric,
+// including metrics over non-coordinate data encoded as values. Queries on a built
+// VPTree are safe for concurrent use if the Metric is.
+type VPTree struct {
+	metric cluster.Metric
+	points [][]float64
+	root   *vantage
+
+	// rnd is the source used to choose vantage points.
+	rnd *rand.Rand
+}
+
+// vantage is a node of a VPTree. Points closer to the vantage point than mu are
+// held in the inside subtree and the remainder in the outside subtree.
+type vantage struct {
+	index           int
+	mu              float64
+	inside, outside *vantage
+}
+
+// NewVPTree returns a new empty VPTree using the metric m. If m is nil, Euclidean
+// distance is used.
+func NewVPTree(m cluster.Metric) *VPTree {
+	if m == nil {
+		m = cluster.Euclidean
+	}
+	return &VPTree{metric: m}
+}
+
+// Build constructs the vantage-point tree over the values of data. Vantage points
+// are chosen randomly from a source local to the tree that is reseeded by each
+// Build, so the shape of the tree depends only on data.
+func (t *VPTree) Build(data cluster.Interface) {
+	t.rnd = rand.New(rand.NewSource(1))
+	t.points = make([][]float64, data.Len())
+	idx := make([]Neighbor, data.Len())
+	for i := range t.points {
+		t.points[i] = append([]float64(nil), data.Values(i)...)
+		idx[i].Index = i
+	}
+	t.root = t.build(idx)
+}
+
+// build returns a vantage node for the points in idx. The Dist fields of idx are
+// used as scratch space.
+func (t *VPTree) build(idx []Neighbor) *vantage {
+	if len(idx) == 0 {
+		return nil
+	}
+	r := t.rnd.Intn(len(idx))
+	idx[0], idx[r] = idx[r], idx[0]
+	v := &vantage{index: idx[0].Index}
+	rest := idx[1:]
+	if len(rest) == 0 {
+		return v
+	}
+	p := t.points[v.index]
+	for i := range rest {
+		rest[i].Dist = t.metric.Distance(p, t.points[rest[i].Index])
+	}
+	sort.Sort(byDist(rest))
+	m := len(rest) / 2
+	v.mu = rest[m].Dist
+	// Move the split to the first point at distance mu so that
+	// all inside points are strictly closer than mu.
+	for m > 0 && rest[m-1].Dist == v.mu {
+		m--
+	}
+	v.inside = t.build(rest[:m])
+	v.outside = t.build(rest[m:])
+	return v
+}
+
+// NearestSet appends the k nearest neighbors of q to dst.
+func (t *VPTree) NearestSet(dst []Neighbor, q []float64, k int) []Neighbor {
+	if k < 1 || t.root == nil {
+		return dst
+	}
+	h := make(neighbors, 0, k)
+	t.nearest(t.root, q, k, &h)
+	return sortAppend(dst, h)
+}
+
+func (t *VPTree) nearest(v *vantage, q []float64, k int, h *neighbors) {
+	if v == nil {
+		return
+	}
+	d := t.metric.Distance(q, t.points[v.index])
+	h.keep(Neighbor{Index: v.index, Dist: d}, k)
+	if d < v.mu {
+		t.nearest(v.inside, q, k, h)
+		if tau, ok := h.full(k); !ok || d+tau >= v.mu {
+			t.nearest(v.outside, q, k, h)
+		}
+		return
+	}
+	t.nearest(v.outside, q, k, h)
+	if tau, ok := h.full(k); !ok || d-tau < v.mu {
+		t.nearest(v.inside, q, k, h)
+	}
+}
+
+// RangeSet appends the neighbors within distance r of q to dst.
+func (t *VPTree) RangeSet(dst []Neighbor, q []float64, r float64) []Neighbor {
+	return sortAppend(dst, t.within(nil, t.root, q, r))
+}
+
+func (t *VPTree) within(dst []Neighbor, v *vantage, q []float64, r float64) []Neighbor {
+	if v == nil {
+		return dst
+	}
+	d := t.metric.Distance(q, t.points[v.index])
+	if d <= r {
+		dst = append(dst, Neighbor{Index: v.index, Dist: d})
+	}
+	if d-r < v.mu {
+		dst = t.within(dst, v.inside, q, r)
+	}
+	if d+r >= v.mu {
+		dst = t.within(dst, v.outside, q, r)
+	}
+	return dst
+}
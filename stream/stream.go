@@ -0,0 +1,123 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package stream provides cluster.Stream implementations reading data from channels
+// and text input.
+package stream
+
+import (
+	"github.com/biogo/cluster/cluster"
+
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Channel is a cluster.Stream of unit weight values received from a channel.
+type Channel struct {
+	c <-chan []float64
+}
+
+// NewChannel returns a Channel reading values from c. The stream is exhausted when c
+// is closed.
+func NewChannel(c <-chan []float64) *Channel { return &Channel{c: c} }
+
+// Next returns the next value received from the channel with a weight of 1.
+func (s *Channel) Next() ([]float64, float64, bool) {
+	v, ok := <-s.c
+	return v, 1, ok
+}
+
+// Value is a weighted data value.
+type Value struct {
+	V []float64
+	W float64
+}
+
+// WeightedChannel is a cluster.Stream of weighted values received from a channel.
+type WeightedChannel struct {
+	c <-chan Value
+}
+
+// NewWeightedChannel returns a WeightedChannel reading values from c. The stream is
+// exhausted when c is closed.
+func NewWeightedChannel(c <-chan Value) *WeightedChannel { return &WeightedChannel{c: c} }
+
+// Next returns the next value received from the channel.
+func (s *WeightedChannel) Next() ([]float64, float64, bool) {
+	v, ok := <-s.c
+	return v.V, v.W, ok
+}
+
+// Reader is a cluster.Stream of values parsed from text. Each non-empty line of the
+// input holds a single value as a set of numeric fields separated by white space
+// or commas. Lines beginning with '#' are ignored.
+type Reader struct {
+	// Weighted specifies that the last field of each line is the
+	// weight of the value. Otherwise values have a weight of 1.
+	Weighted bool
+
+	s    *bufio.Scanner
+	line int
+	dim  int
+	err  error
+}
+
+// NewReader returns a Reader reading from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{s: bufio.NewScanner(r), dim: -1}
+}
+
+func isSep(r rune) bool { return r == ',' || unicode.IsSpace(r) }
+
+// Next returns the next value parsed from the input. When Next returns false, Err
+// should be called to determine whether the input was exhausted or a read or parse
+// error occurred.
+func (r *Reader) Next() ([]float64, float64, bool) {
+	if r.err != nil {
+		return nil, 0, false
+	}
+	for r.s.Scan() {
+		r.line++
+		text := strings.TrimSpace(r.s.Text())
+		if text == "" || text[0] == '#' {
+			continue
+		}
+		fields := strings.FieldsFunc(text, isSep)
+		v := make([]float64, len(fields))
+		for i, f := range fields {
+			var err error
+			v[i], err = strconv.ParseFloat(f, 64)
+			if err != nil {
+				r.err = fmt.Errorf("stream: line %d: %w", r.line, err)
+				return nil, 0, false
+			}
+		}
+		w := 1.
+		if r.Weighted {
+			if len(v) < 2 {
+				r.err = fmt.Errorf("stream: line %d: missing weight", r.line)
+				return nil, 0, false
+			}
+			w = v[len(v)-1]
+			v = v[:len(v)-1]
+		}
+		if r.dim < 0 {
+			r.dim = len(v)
+		} else if len(v) != r.dim {
+			r.err = fmt.Errorf("stream: line %d: %w", r.line, cluster.ErrDimensionMismatch)
+			return nil, 0, false
+		}
+		return v, w, true
+	}
+	r.err = r.s.Err()
+	return nil, 0, false
+}
+
+// Err returns the first error encountered by the Reader, or nil if the input was
+// read and parsed without error.
+func (r *Reader) Err() error { return r.err }
@@ -0,0 +1,96 @@
+// Copyright ©2026 The bíogo Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stream_test
+
+import (
+	"github.com/biogo/cluster/cluster"
+	"github.com/biogo/cluster/stream"
+
+	"strings"
+	"testing"
+
+	"gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { check.TestingT(t) }
+
+type S struct{}
+
+var _ = check.Suite(&S{})
+
+type value struct {
+	v []float64
+	w float64
+}
+
+func drain(s cluster.Stream) []value {
+	var vals []value
+	for {
+		v, w, ok := s.Next()
+		if !ok {
+			return vals
+		}
+		vals = append(vals, value{v, w})
+	}
+}
+
+func (s *S) TestChannel(c *check.C) {
+	ch := make(chan []float64)
+	go func() {
+		for i := 0; i < 3; i++ {
+			ch <- []float64{float64(i), float64(i * i)}
+		}
+		close(ch)
+	}()
+	c.Check(drain(stream.NewChannel(ch)), check.DeepEquals, []value{
+		{[]float64{0, 0}, 1},
+		{[]float64{1, 1}, 1},
+		{[]float64{2, 4}, 1},
+	})
+
+	wch := make(chan stream.Value, 2)
+	wch <- stream.Value{V: []float64{1}, W: 2}
+	wch <- stream.Value{V: []float64{3}, W: 0.5}
+	close(wch)
+	c.Check(drain(stream.NewWeightedChannel(wch)), check.DeepEquals, []value{
+		{[]float64{1}, 2},
+		{[]float64{3}, 0.5},
+	})
+}
+
+func (s *S) TestReader(c *check.C) {
+	const input = `# x y weight
+1 2 0.5
+3,4,2
+
+  5 ,	6 1
+`
+	r := stream.NewReader(strings.NewReader(input))
+	r.Weighted = true
+	c.Check(drain(r), check.DeepEquals, []value{
+		{[]float64{1, 2}, 0.5},
+		{[]float64{3, 4}, 2},
+		{[]float64{5, 6}, 1},
+	})
+	c.Check(r.Err(), check.Equals, nil)
+
+	r = stream.NewReader(strings.NewReader(input))
+	c.Check(len(drain(r)), check.Equals, 3)
+	c.Check(r.Err(), check.Equals, nil)
+
+	for _, t := range []struct {
+		input string
+		err   string
+	}{
+		{"1 2\n1 x\n", `stream: line 2: strconv.ParseFloat: parsing "x": invalid syntax`},
+		{"1 2\n1 2 3\n", "stream: line 2: mismatched dimensions"},
+	} {
+		r = stream.NewReader(strings.NewReader(t.input))
+		c.Check(len(drain(r)), check.Equals, 1)
+		c.Check(r.Err(), check.ErrorMatches, t.err)
+		_, _, ok := r.Next()
+		c.Check(ok, check.Equals, false)
+	}
+}